@@ -13,7 +13,7 @@ func main() {
 		Package:    "js",
 		Prefix:     "pkg/js",
 		Private:    true,
-		Files:      []string{`pkg/js/helpers.js`},
+		Files:      []string{`pkg/js/helpers.js`, `pkg/js/underscore.js`},
 	}
 	embed.Run(conf)
 
@@ -18,14 +18,13 @@ import (
 	"flag"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"log"
 	"os"
-	"strconv"
 	"strings"
 
-	"github.com/StackExchange/dnscontrol/providers/bind"
+	"github.com/StackExchange/dnscontrol/pkg/zoneformat"
 	"github.com/miekg/dns"
-	"github.com/miekg/dns/dnsutil"
 	"github.com/pkg/errors"
 )
 
@@ -62,83 +61,17 @@ func parseargs(args []string) (zonename string, filename string, r io.Reader, er
 	return zonename, filename, r, nil
 }
 
-// pretty outputs the zonefile using the prettyprinter.
-func pretty(zonename string, filename string, r io.Reader, defaultTTL uint32) {
+// readZone reads all records of the zonefile.
+func readZone(zonename string, filename string, r io.Reader) []dns.RR {
 	var l []dns.RR
-	for x := range dns.ParseZone(r, zonename, filename) {
-		if x.Error == nil {
-			l = append(l, x.RR)
-		}
-	}
-	bind.WriteZoneFile(os.Stdout, l, zonename)
-}
-
-// rrFormat outputs the zonefile in either DSL or TSV format.
-func rrFormat(zonename string, filename string, r io.Reader, defaultTTL uint32, dsl bool) {
-	zonenamedot := zonename + "."
-
 	for x := range dns.ParseZone(r, zonename, filename) {
 		if x.Error != nil {
 			log.Println(x.Error)
 			continue
 		}
-
-		// Skip comments. Parse the formatted version.
-		line := x.String()
-		if line[0] == ';' {
-			continue
-		}
-		items := strings.SplitN(line, "\t", 5)
-		if len(items) < 5 {
-			log.Fatalf("Too few items in: %v", line)
-		}
-
-		target := items[4]
-
-		hdr := x.Header()
-		nameFqdn := hdr.Name
-		name := dnsutil.TrimDomainName(nameFqdn, zonenamedot)
-		ttl := strconv.FormatUint(uint64(hdr.Ttl), 10)
-		classStr := dns.ClassToString[hdr.Class]
-		typeStr := dns.TypeToString[hdr.Rrtype]
-
-		// MX records should split out the prio vs. target.
-		if hdr.Rrtype == dns.TypeMX {
-			target = strings.Replace(target, " ", "\t", 1)
-		}
-
-		// NS records at the apex should be NAMESERVER() records.
-		if hdr.Rrtype == dns.TypeNS && name == "@" {
-			typeStr = "NAMESERVER"
-		}
-
-		if !dsl { // TSV format:
-			fmt.Printf("%s\t%s\t%s\t%s\t%s\n", name, ttl, classStr, typeStr, target)
-		} else { // DSL format:
-			switch hdr.Rrtype { // #rtype_variations
-			case dns.TypeMX:
-				m := strings.SplitN(target, "\t", 2)
-				target = m[0] + ", '" + m[1] + "'"
-			case dns.TypeSOA:
-				continue
-			case dns.TypeTXT:
-				if len(x.RR.(*dns.TXT).Txt) == 1 {
-					target = `'` + x.RR.(*dns.TXT).Txt[0] + `'`
-				} else {
-					target = `['` + strings.Join(x.RR.(*dns.TXT).Txt, `', '`) + `']`
-				}
-			default:
-				target = "'" + target + "'"
-			}
-			if hdr.Ttl == defaultTTL {
-				ttl = ""
-			} else {
-				ttl = fmt.Sprintf(", TTL(%d)", hdr.Ttl)
-			}
-			fmt.Printf(",\n\t%s('%s', %s%s)", typeStr, name, target, ttl)
-		}
+		l = append(l, x.RR)
 	}
-
+	return l
 }
 
 func main() {
@@ -154,14 +87,24 @@ func main() {
 	defTTL := uint32(*flagDefaultTTL)
 
 	switch *flagMode {
-	case "pretty":
-		pretty(zonename, filename, reader, defTTL)
 	case "dsl":
-		fmt.Printf(`D("%s", %s, DnsProvider(%s)`, zonename, *flagRegText, *flagProviderText)
-		rrFormat(zonename, filename, reader, defTTL, true)
-		fmt.Println("\n)")
-	case "tsv":
-		rrFormat(zonename, filename, reader, defTTL, false)
+		// $GENERATE directives become GENERATE() calls, instead of many records.
+		text, err := ioutil.ReadAll(reader)
+		if err != nil {
+			log.Fatal(err)
+		}
+		rest, gens := zoneformat.ExtractGenerates(string(text), zonename)
+		records := readZone(zonename, filename, strings.NewReader(rest))
+		err = zoneformat.WriteDSL(os.Stdout, records, zonename, *flagRegText, *flagProviderText, defTTL, gens...)
+		if err != nil {
+			log.Fatal(err)
+		}
+	case "pretty", "tsv":
+		records := readZone(zonename, filename, reader)
+		err = zoneformat.Write(os.Stdout, *flagMode, records, zonename, *flagRegText, *flagProviderText, defTTL)
+		if err != nil {
+			log.Fatal(err)
+		}
 	default:
 		fmt.Println("convertzone [-flags] ZONENAME FILENAME")
 		flag.Usage()
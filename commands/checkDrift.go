@@ -0,0 +1,135 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+
+	"github.com/StackExchange/dnscontrol/pkg/engine"
+	"github.com/StackExchange/dnscontrol/pkg/printer"
+	"github.com/urfave/cli"
+)
+
+// The exit codes of check-drift. Drift does not use 1, which dnscontrol exits with
+// on usage errors and fatal errors, so any code but DriftInSync and DriftDetected is an error.
+const (
+	DriftInSync   = 0 // the providers match the configuration
+	DriftError    = 2 // the check failed, i.e. a provider could not be read
+	DriftDetected = 3 // at least one provider needs corrections
+)
+
+var _ = cmd(catMain, func() *cli.Command {
+	var args CheckDriftArgs
+	return &cli.Command{
+		Name:  "check-drift",
+		Usage: "read live configuration and report whether it differs from the configuration, with distinct exit codes for monitoring",
+		Action: func(ctx *cli.Context) error {
+			code, err := CheckDrift(args)
+			if err != nil {
+				return cli.NewExitError(err, DriftError)
+			}
+			if code != DriftInSync {
+				return cli.NewExitError("", code)
+			}
+			return nil
+		},
+		Flags: args.flags(),
+	}
+}())
+
+// CheckDriftArgs contains all data/flags needed to run check-drift, independently of CLI.
+type CheckDriftArgs struct {
+	GetDNSConfigArgs
+	GetCredentialsArgs
+	FilterArgs
+	StateFile string
+	Summary   bool
+}
+
+func (args *CheckDriftArgs) flags() []cli.Flag {
+	flags := args.GetDNSConfigArgs.flags()
+	flags = append(flags, args.GetCredentialsArgs.flags()...)
+	flags = append(flags, args.FilterArgs.flags()...)
+	flags = append(flags, cli.StringFlag{
+		Name:        "state",
+		Destination: &args.StateFile,
+		Value:       "dnscontrol-state.json",
+		Usage:       `File listing the records DNSControl created, for domains that use PURGE_OWNED. It is only read`,
+	})
+	flags = append(flags, cli.BoolFlag{
+		Name:        "summary",
+		Destination: &args.Summary,
+		Usage:       `After the corrections, print one line per domain and provider: in sync, drift or error`,
+	})
+	return flags
+}
+
+// CheckDrift computes the corrections like preview does, without making them.
+// It returns DriftDetected if any provider needs corrections, and an error if the configuration
+// is invalid or any provider failed. Errors take precedence over drift, since a provider
+// that could not be read may have drifted too.
+func CheckDrift(args CheckDriftArgs) (int, error) {
+	cfg, err := GetDNSConfig(args.GetDNSConfigArgs)
+	if err != nil {
+		return DriftError, err
+	}
+	if _, err := InitializeProviders(args.CredsFile, cfg, false); err != nil {
+		return DriftError, err
+	}
+	state, err := readState(cfg, args.StateFile)
+	if err != nil {
+		return DriftError, err
+	}
+	ctx, stop := interruptContext()
+	defer stop()
+	result, err := engine.Run(ctx, cfg, false, engine.Options{
+		Domains:   args.domains(),
+		Providers: args.providers(),
+		State:     state,
+		Printer:   printer.ConsolePrinter{},
+	})
+	if err != nil {
+		return DriftError, err
+	}
+	if args.Summary {
+		printDriftSummary(os.Stdout, result)
+	}
+	switch {
+	case result.Interrupted:
+		return DriftError, fmt.Errorf("Interrupted: %d domains were not checked", result.SkippedDomains)
+	case result.HasErrors():
+		return DriftError, fmt.Errorf("Drift check failed")
+	case result.Corrections() > 0:
+		fmt.Printf("Drift detected: %d corrections.\n", result.Corrections())
+		return DriftDetected, nil
+	}
+	fmt.Println("No drift detected.")
+	return DriftInSync, nil
+}
+
+// printDriftSummary prints the state of every provider that was checked.
+func printDriftSummary(w io.Writer, result *engine.Result) {
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(tw, "DOMAIN\tPROVIDER\tSTATUS\tCORRECTIONS")
+	for _, d := range result.Domains {
+		for _, p := range d.Providers {
+			if p.Skipped {
+				continue
+			}
+			name := p.Name
+			if p.Registrar {
+				name += " (registrar)"
+			}
+			status := "in sync"
+			switch {
+			case p.Error != nil:
+				status = "error: " + p.Error.Error()
+			case len(p.Corrections) > 0:
+				status = "drift"
+			}
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%d\n", d.Name, name, status, len(p.Corrections))
+		}
+	}
+	tw.Flush()
+}
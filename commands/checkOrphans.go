@@ -0,0 +1,116 @@
+package commands
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/StackExchange/dnscontrol/models"
+	"github.com/StackExchange/dnscontrol/providers"
+	"github.com/urfave/cli"
+	"golang.org/x/net/idna"
+)
+
+var _ = cmd(catUtils, func() *cli.Command {
+	var args CheckOrphansArgs
+	return &cli.Command{
+		Name:  "check-orphans",
+		Usage: "reports zones that exist at a provider but are not in your configuration, and domains missing at their providers",
+		Action: func(ctx *cli.Context) error {
+			return exit(CheckOrphans(args))
+		},
+		Flags: args.flags(),
+	}
+}())
+
+// CheckOrphansArgs args required for the check-orphans subcommand.
+type CheckOrphansArgs struct {
+	GetDNSConfigArgs
+	GetCredentialsArgs
+}
+
+func (args *CheckOrphansArgs) flags() []cli.Flag {
+	flags := args.GetDNSConfigArgs.flags()
+	flags = append(flags, args.GetCredentialsArgs.flags()...)
+	return flags
+}
+
+// CheckOrphans contains all data/flags needed to run check-orphans, independently of CLI.
+// It returns an error if any zone is orphaned or missing.
+func CheckOrphans(args CheckOrphansArgs) error {
+	cfg, err := GetDNSConfig(args.GetDNSConfigArgs)
+	if err != nil {
+		return err
+	}
+	_, err = InitializeProviders(args.CredsFile, cfg, false)
+	if err != nil {
+		return err
+	}
+
+	// The domains declared for every DNS provider.
+	declared := map[string]map[string]bool{}
+	instances := map[string]*models.DNSProviderInstance{}
+	for _, domain := range cfg.Domains {
+		name, err := idna.ToASCII(domain.Name)
+		if err != nil {
+			return err
+		}
+		for _, p := range domain.DNSProviderInstances {
+			if declared[p.Name] == nil {
+				declared[p.Name] = map[string]bool{}
+				instances[p.Name] = p
+			}
+			declared[p.Name][strings.ToLower(name)] = true
+		}
+	}
+	names := make([]string, 0, len(instances))
+	for name := range instances {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	orphans, missing := 0, 0
+	for _, name := range names {
+		p := instances[name]
+		lister, ok := p.Driver.(providers.ZoneLister)
+		if !ok {
+			fmt.Printf("*** %s: provider type %s can not list zones, skipping\n", name, p.ProviderType)
+			continue
+		}
+		fmt.Printf("*** %s\n", name)
+		zones, err := lister.ListZones()
+		if err != nil {
+			return fmt.Errorf("Listing zones of %s: %s", name, err)
+		}
+		existing := map[string]bool{}
+		for _, z := range zones {
+			existing[strings.ToLower(strings.TrimSuffix(z, "."))] = true
+		}
+		for _, z := range sortedKeys(existing) {
+			if !declared[name][z] {
+				fmt.Printf("  - ORPHAN  %s exists at %s but is not in the configuration\n", z, name)
+				orphans++
+			}
+		}
+		for _, d := range sortedKeys(declared[name]) {
+			if !existing[d] {
+				fmt.Printf("  - MISSING %s is in the configuration but does not exist at %s\n", d, name)
+				missing++
+			}
+		}
+	}
+	if orphans > 0 || missing > 0 {
+		return fmt.Errorf("Found %d orphaned and %d missing zones", orphans, missing)
+	}
+	fmt.Println("No orphaned or missing zones found.")
+	return nil
+}
+
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
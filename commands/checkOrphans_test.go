@@ -0,0 +1,30 @@
+package commands
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCheckOrphans(t *testing.T) {
+	dir, cleanup := testFiles(t, map[string]string{
+		"creds.json":             `{"bind": {"directory": "$DIR/zones"}}`,
+		"zones/example.com.zone": "@ IN A 1.2.3.4\n",
+		"zones/orphan.com.zone":  "@ IN A 1.2.3.4\n",
+		"insync.js":              `D("example.com", NewRegistrar("none", "NONE"), DnsProvider(NewDnsProvider("bind", "BIND"))); D("orphan.com", "none", DnsProvider("bind"));`,
+		"dnsconfig.js":           `D("example.com", NewRegistrar("none", "NONE"), DnsProvider(NewDnsProvider("bind", "BIND"))); D("missing.com", "none", DnsProvider("bind"));`,
+	})
+	defer cleanup()
+	args := CheckOrphansArgs{GetCredentialsArgs: GetCredentialsArgs{CredsFile: filepath.Join(dir, "creds.json")}}
+
+	args.JSFile = filepath.Join(dir, "insync.js")
+	if err := CheckOrphans(args); err != nil {
+		t.Errorf("expected no orphaned or missing zones, got %v", err)
+	}
+
+	args.JSFile = filepath.Join(dir, "dnsconfig.js")
+	err := CheckOrphans(args)
+	if err == nil || !strings.Contains(err.Error(), "Found 1 orphaned and 1 missing zones") {
+		t.Errorf("expected 1 orphaned and 1 missing zone, got %v", err)
+	}
+}
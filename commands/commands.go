@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"github.com/StackExchange/dnscontrol/models"
+	"github.com/StackExchange/dnscontrol/pkg/engine"
 	"github.com/urfave/cli"
 )
 
@@ -102,45 +103,8 @@ func preloadProviders(cfg *models.DNSConfig, err error) (*models.DNSConfig, erro
 	if err != nil {
 		return cfg, err
 	}
-	//build name to type maps
-	cfg.RegistrarsByName = map[string]*models.RegistrarConfig{}
-	cfg.DNSProvidersByName = map[string]*models.DNSProviderConfig{}
-	for _, reg := range cfg.Registrars {
-		cfg.RegistrarsByName[reg.Name] = reg
-	}
-	for _, p := range cfg.DNSProviders {
-		cfg.DNSProvidersByName[p.Name] = p
-	}
-	// make registrar and dns provider shims. Include name, type, and other metadata, but can't inatantiate
-	// driver until we load creds in later
-	for _, d := range cfg.Domains {
-		reg, ok := cfg.RegistrarsByName[d.RegistrarName]
-		if !ok {
-			return nil, fmt.Errorf("Registrar named %s expected for %s, but never registered", d.RegistrarName, d.Name)
-		}
-		d.RegistrarInstance = &models.RegistrarInstance{
-			ProviderBase: models.ProviderBase{
-				Name:         reg.Name,
-				ProviderType: reg.Type,
-			},
-		}
-		for pName, n := range d.DNSProviderNames {
-			prov, ok := cfg.DNSProvidersByName[pName]
-			if !ok {
-				return nil, fmt.Errorf("DNS Provider named %s expected for %s, but never registered", pName, d.Name)
-			}
-			d.DNSProviderInstances = append(d.DNSProviderInstances, &models.DNSProviderInstance{
-				ProviderBase: models.ProviderBase{
-					Name:         pName,
-					ProviderType: prov.Type,
-				},
-				NumberOfNameservers: n,
-			})
-		}
-		// sort so everything is deterministic
-		sort.Slice(d.DNSProviderInstances, func(i, j int) bool {
-			return d.DNSProviderInstances[i].Name < d.DNSProviderInstances[j].Name
-		})
+	if err := engine.Preload(cfg); err != nil {
+		return nil, err
 	}
 	return cfg, nil
 }
@@ -150,6 +114,7 @@ type ExecuteDSLArgs struct {
 	JSFile   string
 	JSONFile string
 	DevMode  bool
+	AllowEnv string
 }
 
 func (args *ExecuteDSLArgs) flags() []cli.Flag {
@@ -172,6 +137,12 @@ func (args *ExecuteDSLArgs) flags() []cli.Flag {
 			Destination: &args.DevMode,
 			Usage:       "Use helpers.js from disk instead of embedded copy",
 		},
+		cli.StringFlag{
+			Name:        "allow-env",
+			EnvVar:      "DNSCONTROL_ALLOW_ENV",
+			Destination: &args.AllowEnv,
+			Usage:       "Comma-separated environment variables that ENV() may read, or patterns like DNS_*",
+		},
 	}
 }
 
@@ -235,34 +206,18 @@ func (args *FilterArgs) flags() []cli.Flag {
 	}
 }
 
-func (args *FilterArgs) shouldRunProvider(name string, dc *models.DomainConfig) bool {
-	if args.Providers == "all" {
-		return true
-	}
+// providers returns the providers selected by -providers, or nil for the default ones.
+func (args *FilterArgs) providers() []string {
 	if args.Providers == "" {
-		for _, pri := range dc.DNSProviderInstances {
-			if pri.Name == name {
-				return pri.IsDefault
-			}
-		}
-		return true
-	}
-	for _, prov := range strings.Split(args.Providers, ",") {
-		if prov == name {
-			return true
-		}
+		return nil
 	}
-	return false
+	return strings.Split(args.Providers, ",")
 }
 
-func (args *FilterArgs) shouldRunDomain(d string) bool {
+// domains returns the domains selected by -domains, or nil for all of them.
+func (args *FilterArgs) domains() []string {
 	if args.Domains == "" {
-		return true
-	}
-	for _, dom := range strings.Split(args.Domains, ",") {
-		if dom == d {
-			return true
-		}
+		return nil
 	}
-	return false
+	return strings.Split(args.Domains, ",")
 }
@@ -0,0 +1,84 @@
+package commands
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/StackExchange/dnscontrol/pkg/js"
+	"github.com/urfave/cli"
+)
+
+var _ = cmd(catUtils, func() *cli.Command {
+	var args FmtArgs
+	return &cli.Command{
+		Name:  "fmt",
+		Usage: "rewrite dnsconfig.js in the canonical style: quoting, one record per line, sorted records and aligned arguments",
+		Action: func(ctx *cli.Context) error {
+			return exit(Fmt(args))
+		},
+		Flags: args.flags(),
+	}
+}())
+
+// FmtArgs contains all data/flags needed to run fmt, independently of CLI.
+type FmtArgs struct {
+	InputFile  string
+	OutputFile string
+	Check      bool
+}
+
+func (args *FmtArgs) flags() []cli.Flag {
+	return []cli.Flag{
+		cli.StringFlag{
+			Name:        "config",
+			Value:       "dnsconfig.js",
+			Destination: &args.InputFile,
+			Usage:       "File containing dns config in javascript DSL",
+		},
+		cli.StringFlag{
+			Name:        "o",
+			Destination: &args.OutputFile,
+			Usage:       "File to write the formatted config to, or - for stdout. The default is to rewrite the config",
+		},
+		cli.BoolFlag{
+			Name:        "check",
+			Destination: &args.Check,
+			Usage:       "Do not write anything. Fail if the config is not formatted, i.e. in continuous integration",
+		},
+	}
+}
+
+// Fmt formats the config. With Check, it returns an error if formatting would change it.
+func Fmt(args FmtArgs) error {
+	src, err := ioutil.ReadFile(args.InputFile)
+	if err != nil {
+		return err
+	}
+	out, err := js.Format(args.InputFile, src)
+	if err != nil {
+		return err
+	}
+	if args.Check {
+		if string(out) != string(src) {
+			return fmt.Errorf("%s is not formatted; run dnscontrol fmt", args.InputFile)
+		}
+		return nil
+	}
+	switch args.OutputFile {
+	case "-":
+		_, err = os.Stdout.Write(out)
+		return err
+	case "":
+		if string(out) == string(src) {
+			return nil
+		}
+		info, err := os.Stat(args.InputFile)
+		if err != nil {
+			return err
+		}
+		return ioutil.WriteFile(args.InputFile, out, info.Mode())
+	default:
+		return ioutil.WriteFile(args.OutputFile, out, 0644)
+	}
+}
@@ -0,0 +1,133 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/StackExchange/dnscontrol/models"
+	"github.com/StackExchange/dnscontrol/pkg/zoneformat"
+	"github.com/StackExchange/dnscontrol/providers"
+	"github.com/StackExchange/dnscontrol/providers/config"
+	"github.com/miekg/dns"
+	"github.com/urfave/cli"
+)
+
+var _ = cmd(catUtils, func() *cli.Command {
+	var args GetZonesArgs
+	return &cli.Command{
+		Name:      "get-zones",
+		Usage:     "downloads zones from a provider and prints them as dnsconfig.js, a BIND zonefile or TSV",
+		ArgsUsage: "credkey providertype zone [zone ...] (or \"all\" for every zone in the account)",
+		Action: func(ctx *cli.Context) error {
+			if ctx.NArg() < 3 {
+				return cli.NewExitError("Arguments should be: credkey providertype zone [zone ...]", 1)
+			}
+			args.CredName = ctx.Args().Get(0)
+			args.ProviderType = ctx.Args().Get(1)
+			args.ZoneNames = ctx.Args()[2:]
+			return exit(GetZones(args))
+		},
+		Flags: args.flags(),
+	}
+}())
+
+// GetZonesArgs args required for the get-zones subcommand.
+type GetZonesArgs struct {
+	GetCredentialsArgs
+	CredName     string   // key in creds.json
+	ProviderType string   // provider type, e.g. BIND or ROUTE53
+	ZoneNames    []string // the zones to download, or "all"
+	Format       string
+	OutputFile   string
+	DefaultTTL   int
+}
+
+func (args *GetZonesArgs) flags() []cli.Flag {
+	flags := args.GetCredentialsArgs.flags()
+	flags = append(flags,
+		cli.StringFlag{
+			Name:        "format",
+			Destination: &args.Format,
+			Value:       "pretty",
+			Usage:       `Output format: "pretty" (BIND zonefile), "dsl" (dnsconfig.js) or "tsv"`,
+		},
+		cli.StringFlag{
+			Name:        "out",
+			Destination: &args.OutputFile,
+			Usage:       "Write to this file instead of stdout",
+		},
+		cli.IntFlag{
+			Name:        "ttl",
+			Destination: &args.DefaultTTL,
+			Value:       int(models.DefaultTTL),
+			Usage:       "Default TTL. With -format=dsl, records with this TTL are written without TTL()",
+		},
+	)
+	return flags
+}
+
+// GetZones contains all data/flags needed to run get-zones, independently of CLI.
+func GetZones(args GetZonesArgs) error {
+	providerConfigs, err := config.LoadProviderConfigs(args.CredsFile)
+	if err != nil {
+		return err
+	}
+	creds, ok := providerConfigs[args.CredName]
+	if !ok {
+		return fmt.Errorf("No credentials named %s in %s", args.CredName, args.CredsFile)
+	}
+	provider, err := providers.CreateDNSProvider(args.ProviderType, creds, nil)
+	if err != nil {
+		return err
+	}
+	getter, ok := provider.(providers.ZoneRecordsGetter)
+	if !ok {
+		return fmt.Errorf("Provider type %s can not download zones", args.ProviderType)
+	}
+
+	zones := args.ZoneNames
+	if len(zones) == 1 && zones[0] == "all" {
+		lister, ok := provider.(providers.ZoneLister)
+		if !ok {
+			return fmt.Errorf("Provider type %s can not list zones", args.ProviderType)
+		}
+		if zones, err = lister.ListZones(); err != nil {
+			return err
+		}
+	}
+
+	var w io.Writer = os.Stdout
+	if args.OutputFile != "" {
+		f, err := os.Create(args.OutputFile)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+
+	for i, zone := range zones {
+		recs, err := getter.GetZoneRecords(zone)
+		if err != nil {
+			return fmt.Errorf("Downloading %s: %s", zone, err)
+		}
+		rrs := make([]dns.RR, 0, len(recs))
+		for _, rec := range recs {
+			rr, err := zoneformat.ToRR(rec)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "WARNING: %s: skipping record %s\n", zone, err)
+				continue
+			}
+			rrs = append(rrs, rr)
+		}
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		provider := fmt.Sprintf("%q", args.CredName)
+		if err := zoneformat.Write(w, args.Format, rrs, zone, "REG_CHANGEME", provider, uint32(args.DefaultTTL)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
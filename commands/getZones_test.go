@@ -0,0 +1,70 @@
+package commands
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	_ "github.com/StackExchange/dnscontrol/providers/bind"
+)
+
+// testFiles writes files in a temporary directory, and returns its name.
+func testFiles(t *testing.T, files map[string]string) (string, func()) {
+	dir, err := ioutil.TempDir("", "commands")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, content := range files {
+		fn := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(fn), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(fn, []byte(strings.Replace(content, "$DIR", dir, -1)), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir, func() { os.RemoveAll(dir) }
+}
+
+func TestGetZones(t *testing.T) {
+	dir, cleanup := testFiles(t, map[string]string{
+		"creds.json":             `{"bind": {"directory": "$DIR/zones"}}`,
+		"zones/example.com.zone": "$TTL 300\n@ IN A 1.2.3.4\nwww 600 IN CNAME @\n",
+		"zones/example.org.zone": "@ IN MX 10 mail.example.org.\n",
+	})
+	defer cleanup()
+	args := GetZonesArgs{
+		GetCredentialsArgs: GetCredentialsArgs{CredsFile: filepath.Join(dir, "creds.json")},
+		CredName:           "bind",
+		ProviderType:       "BIND",
+		ZoneNames:          []string{"all"},
+		Format:             "dsl",
+		OutputFile:         filepath.Join(dir, "out.js"),
+		DefaultTTL:         300,
+	}
+	if err := GetZones(args); err != nil {
+		t.Fatal(err)
+	}
+	out, err := ioutil.ReadFile(args.OutputFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range []string{
+		`D("example.com", REG_CHANGEME, DnsProvider("bind"),`,
+		`A('@', '1.2.3.4')`,
+		`CNAME('www', 'example.com.', TTL(600))`,
+		`D("example.org", REG_CHANGEME, DnsProvider("bind"),`,
+		`MX('@', 10, 'mail.example.org.')`,
+	} {
+		if !strings.Contains(string(out), s) {
+			t.Errorf("expected %s in:\n%s", s, out)
+		}
+	}
+
+	args.CredName = "missing"
+	if err := GetZones(args); err == nil || !strings.Contains(err.Error(), "No credentials named missing") {
+		t.Errorf("expected an error about the missing credentials, got %v", err)
+	}
+}
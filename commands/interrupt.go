@@ -0,0 +1,40 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// interruptContext returns a context that is cancelled when the user presses Ctrl-C
+// (or the process receives SIGTERM). A push then finishes the correction in progress,
+// but starts no new ones. A second Ctrl-C exits immediately.
+// stop must be called when the context is no longer needed.
+func interruptContext() (ctx context.Context, stop func()) {
+	ctx, cancel := context.WithCancel(context.Background())
+	sigs := make(chan os.Signal, 2)
+	signal.Notify(sigs, os.Interrupt, syscall.SIGTERM)
+	done := make(chan struct{})
+	go func() {
+		select {
+		case <-sigs:
+		case <-done:
+			return
+		}
+		fmt.Fprintln(os.Stderr, "\nInterrupted. Finishing the correction in progress; press Ctrl-C again to exit immediately.")
+		cancel()
+		select {
+		case <-sigs:
+			fmt.Fprintln(os.Stderr, "Exiting. Corrections in progress may or may not have been made.")
+			os.Exit(130)
+		case <-done:
+		}
+	}()
+	return ctx, func() {
+		signal.Stop(sigs)
+		close(done)
+		cancel()
+	}
+}
@@ -2,15 +2,15 @@ package commands
 
 import (
 	"fmt"
-	"log"
+	"io"
 	"os"
 
 	"github.com/StackExchange/dnscontrol/models"
-	"github.com/StackExchange/dnscontrol/pkg/nameservers"
-	"github.com/StackExchange/dnscontrol/pkg/normalize"
+	"github.com/StackExchange/dnscontrol/pkg/engine"
 	"github.com/StackExchange/dnscontrol/pkg/notifications"
+	"github.com/StackExchange/dnscontrol/pkg/ownership"
+	"github.com/StackExchange/dnscontrol/pkg/plan"
 	"github.com/StackExchange/dnscontrol/pkg/printer"
-	"github.com/StackExchange/dnscontrol/providers"
 	"github.com/StackExchange/dnscontrol/providers/config"
 	"github.com/urfave/cli"
 )
@@ -32,18 +32,65 @@ type PreviewArgs struct {
 	GetDNSConfigArgs
 	GetCredentialsArgs
 	FilterArgs
-	Notify bool
+	SafetyArgs
+	Notify              bool
+	PlanOut             string
+	StateFile           string
+	Explain             bool
+	ZoneDiff            bool
+	Concurrency         int
+	ProviderConcurrency string
+	Output              string
 }
 
 func (args *PreviewArgs) flags() []cli.Flag {
 	flags := args.GetDNSConfigArgs.flags()
 	flags = append(flags, args.GetCredentialsArgs.flags()...)
 	flags = append(flags, args.FilterArgs.flags()...)
+	flags = append(flags, args.SafetyArgs.flags()...)
 	flags = append(flags, cli.BoolFlag{
 		Name:        "notify",
 		Destination: &args.Notify,
 		Usage:       `set to true to send notifications to configured destinations`,
 	})
+	flags = append(flags, cli.StringFlag{
+		Name:        "plan-out",
+		Destination: &args.PlanOut,
+		Usage:       `Write all corrections to this plan file, to be applied later with push -plan-in`,
+	})
+	flags = append(flags, cli.StringFlag{
+		Name:        "state",
+		Destination: &args.StateFile,
+		Value:       "dnscontrol-state.json",
+		Usage:       `File listing the records DNSControl created, for domains that use PURGE_OWNED`,
+	})
+	flags = append(flags, cli.BoolFlag{
+		Name:        "explain",
+		Destination: &args.Explain,
+		Usage:       `Show how the provider-specific canonicalizers changed the records, and which corrections they caused or suppressed`,
+	})
+	flags = append(flags, cli.BoolFlag{
+		Name:        "zone-diff",
+		Destination: &args.ZoneDiff,
+		Usage:       `Also show the changes as a unified diff of the zonefile, colored on a terminal unless NO_COLOR is set`,
+	})
+	flags = append(flags, cli.IntFlag{
+		Name:        "concurrency",
+		Destination: &args.Concurrency,
+		Value:       1,
+		Usage:       `Number of domains to process in parallel`,
+	})
+	flags = append(flags, cli.StringFlag{
+		Name:        "provider-concurrency",
+		Destination: &args.ProviderConcurrency,
+		Usage:       `Limit how many domains may call the API of a provider type at once (comma separated list of TYPE=N, i.e. ROUTE53=2,GCLOUD=4)`,
+	})
+	flags = append(flags, cli.StringFlag{
+		Name:        "output",
+		Destination: &args.Output,
+		Value:       "console",
+		Usage:       `Output format: "console" for humans, or "json" for one JSON event per line`,
+	})
 	return flags
 }
 
@@ -63,6 +110,8 @@ var _ = cmd(catMain, func() *cli.Command {
 type PushArgs struct {
 	PreviewArgs
 	Interactive bool
+	PlanIn      string
+	Force       bool
 }
 
 func (args *PushArgs) flags() []cli.Flag {
@@ -72,103 +121,172 @@ func (args *PushArgs) flags() []cli.Flag {
 		Destination: &args.Interactive,
 		Usage:       "Interactive. Confirm or Exclude each correction before they run",
 	})
+	flags = append(flags, cli.StringFlag{
+		Name:        "plan-in",
+		Destination: &args.PlanIn,
+		Usage:       "Only perform the corrections listed in this plan file (written by preview -plan-out). Refuse if they changed since",
+	})
+	flags = append(flags, cli.BoolFlag{
+		Name:        "force",
+		Destination: &args.Force,
+		Usage:       "Push even if the changes exceed the -safety-max-changes or -safety-max-percent limits",
+	})
 	return flags
 }
 
 // Preview implements the preview subcommand.
 func Preview(args PreviewArgs) error {
-	return run(args, false, false, printer.ConsolePrinter{})
+	out, err := args.printer(os.Stdout)
+	if err != nil {
+		return err
+	}
+	return run(args, false, false, false, nil, out)
 }
 
 // Push implements the push subcommand.
 func Push(args PushArgs) error {
-	return run(args.PreviewArgs, true, args.Interactive, printer.ConsolePrinter{})
+	out, err := args.printer(os.Stdout)
+	if err != nil {
+		return err
+	}
+	if _, ok := out.(printer.ConsolePrinter); !ok && args.Interactive {
+		return fmt.Errorf("Interactive mode requires -output console")
+	}
+	var approved *plan.Plan
+	if args.PlanIn != "" {
+		if approved, err = plan.Read(args.PlanIn); err != nil {
+			return err
+		}
+	}
+	return run(args.PreviewArgs, true, args.Interactive, args.Force, approved, out)
+}
+
+// printer returns the printer selected by the -output flag.
+// The JSON printer writes to stdout; providers log their progress to stderr, so that it stays pure JSON.
+func (args *PreviewArgs) printer(stdout io.Writer) (printer.CLI, error) {
+	switch args.Output {
+	case "", "console":
+		return printer.ConsolePrinter{}, nil
+	case "json":
+		return printer.NewJSONPrinter(stdout), nil
+	default:
+		return nil, fmt.Errorf("Unknown output format %q. Valid formats are console and json", args.Output)
+	}
+}
+
+// isTerminal reports whether f is a terminal, rather than a file or a pipe.
+func isTerminal(f *os.File) bool {
+	fi, err := f.Stat()
+	return err == nil && fi.Mode()&os.ModeCharDevice != 0
+}
+
+// SafetyArgs configures the guard against pushing changes that would
+// modify or delete a large part of a zone (see engine.Safety).
+type SafetyArgs struct {
+	engine.Safety
+}
+
+func (args *SafetyArgs) flags() []cli.Flag {
+	return []cli.Flag{
+		cli.IntFlag{
+			Name:        "safety-max-changes",
+			Destination: &args.MaxChanges,
+			Usage:       `Refuse to push if more than this many existing records of a domain would be modified or deleted (0 means no limit)`,
+		},
+		cli.IntFlag{
+			Name:        "safety-max-percent",
+			Destination: &args.MaxPercent,
+			Usage:       `Refuse to push if more than this percentage of the existing records of a domain would be modified or deleted (0 means no limit)`,
+		},
+	}
 }
 
-// run is the main routine common to preview/push
-func run(args PreviewArgs, push bool, interactive bool, out printer.CLI) error {
-	// TODO: make truly CLI independent. Perhaps return results on a channel as they occur
+// run is the main routine common to preview/push.
+// If approved is not nil, corrections are only run if they are exactly the ones in that plan.
+// force overrides the safety limits.
+func run(args PreviewArgs, push bool, interactive bool, force bool, approved *plan.Plan, out printer.CLI) error {
 	cfg, err := GetDNSConfig(args.GetDNSConfigArgs)
 	if err != nil {
 		return err
 	}
-	errs := normalize.NormalizeAndValidateConfig(cfg)
-	if PrintValidationErrors(errs) {
-		return fmt.Errorf("Exiting due to validation errors")
+	limits, err := engine.ParseProviderConcurrency(args.ProviderConcurrency)
+	if err != nil {
+		return err
 	}
-	// TODO:
 	notifier, err := InitializeProviders(args.CredsFile, cfg, args.Notify)
 	if err != nil {
 		return err
 	}
-	anyErrors := false
-	totalCorrections := 0
-DomainLoop:
-	for _, domain := range cfg.Domains {
-		if !args.shouldRunDomain(domain.Name) {
-			continue
-		}
-		out.StartDomain(domain.Name)
-		nsList, err := nameservers.DetermineNameservers(domain)
-		if err != nil {
+	opts := engine.Options{
+		Domains:             args.domains(),
+		Providers:           args.providers(),
+		Concurrency:         args.Concurrency,
+		ProviderConcurrency: limits,
+		Safety:              args.Safety,
+		Force:               force,
+		Interactive:         interactive,
+		Approved:            approved,
+		ZoneDiff:            args.ZoneDiff,
+		Explain:             args.Explain,
+		Color:               args.Output != "json" && os.Getenv("NO_COLOR") == "" && isTerminal(os.Stdout),
+		Notifier:            notifier,
+		Printer:             out,
+	}
+	if args.PlanOut != "" {
+		opts.PlanOut = plan.New()
+	}
+	if opts.State, err = readState(cfg, args.StateFile); err != nil {
+		return err
+	}
+	ctx, stop := interruptContext()
+	defer stop()
+	// Run validates the configuration before it does anything else.
+	result, err := engine.Run(ctx, cfg, push, opts)
+	if err != nil {
+		return err
+	}
+	// Providers that succeeded changed the ownership even if others failed.
+	if opts.State != nil && opts.State.Changed() {
+		if err := opts.State.Write(args.StateFile); err != nil {
 			return err
 		}
-		domain.Nameservers = nsList
-		nameservers.AddNSRecords(domain)
-		for _, provider := range domain.DNSProviderInstances {
-			dc, err := domain.Copy()
-			if err != nil {
-				return err
-			}
-			shouldrun := args.shouldRunProvider(provider.Name, dc)
-			out.StartDNSProvider(provider.Name, !shouldrun)
-			if !shouldrun {
-				continue
-			}
-			corrections, err := provider.Driver.GetDomainCorrections(dc)
-			out.EndProvider(len(corrections), err)
-			if err != nil {
-				anyErrors = true
-				continue DomainLoop
-			}
-			totalCorrections += len(corrections)
-			anyErrors = printOrRunCorrections(domain.Name, provider.Name, corrections, out, push, interactive, notifier) || anyErrors
-		}
-		run := args.shouldRunProvider(domain.RegistrarName, domain)
-		out.StartRegistrar(domain.RegistrarName, !run)
-		if !run {
-			continue
-		}
-		if len(domain.Nameservers) == 0 && domain.Metadata["no_ns"] != "true" {
-			out.Warnf("No nameservers declared; skipping registrar. Add {no_ns:'true'} to force.\n")
-			continue
-		}
-		dc, err := domain.Copy()
-		if err != nil {
-			log.Fatal(err)
-		}
-		corrections, err := domain.RegistrarInstance.Driver.GetRegistrarCorrections(dc)
-		out.EndProvider(len(corrections), err)
-		if err != nil {
-			anyErrors = true
-			continue
-		}
-		totalCorrections += len(corrections)
-		anyErrors = printOrRunCorrections(domain.Name, domain.RegistrarName, corrections, out, push, interactive, notifier) || anyErrors
+		out.Debugf("State written to %s\n", args.StateFile)
 	}
+	if result.Interrupted {
+		notifier.Done()
+		return fmt.Errorf("Interrupted: %d corrections were run, %d were skipped and %d domains were not processed",
+			result.Ran, result.Skipped, result.SkippedDomains)
+	}
+	totalCorrections := result.Corrections()
 	if os.Getenv("TEAMCITY_VERSION") != "" {
 		fmt.Fprintf(os.Stderr, "##teamcity[buildStatus status='SUCCESS' text='%d corrections']", totalCorrections)
 	}
 	notifier.Done()
+	if opts.PlanOut != nil {
+		if err := opts.PlanOut.Write(args.PlanOut); err != nil {
+			return err
+		}
+		out.Debugf("Plan written to %s\n", args.PlanOut)
+	}
 	out.Debugf("Done. %d corrections.\n", totalCorrections)
-	if anyErrors {
+	if result.HasErrors() {
 		return fmt.Errorf("Completed with errors")
 	}
 	return nil
 }
 
+// readState reads the ownership state file, if any domain uses PURGE_OWNED.
+func readState(cfg *models.DNSConfig, file string) (*ownership.State, error) {
+	for _, d := range cfg.Domains {
+		if d.PurgeOwned {
+			return ownership.Read(file)
+		}
+	}
+	return nil, nil
+}
+
 // InitializeProviders takes a creds file path and a DNSConfig object. Creates all providers with the proper types, and returns them.
-// nonDefaultProviders is a list of providers that should not be run unless explicitly asked for by flags.
+// Providers marked "_exclude_from_defaults" are not run unless explicitly asked for by flags.
 func InitializeProviders(credsFile string, cfg *models.DNSConfig, notifyFlag bool) (notify notifications.Notifier, err error) {
 	var providerConfigs map[string]map[string]string
 	var notificationCfg map[string]string
@@ -182,62 +300,6 @@ func InitializeProviders(credsFile string, cfg *models.DNSConfig, notifyFlag boo
 	if notifyFlag {
 		notificationCfg = providerConfigs["notifications"]
 	}
-	isNonDefault := map[string]bool{}
-	for name, vals := range providerConfigs {
-		// add "_exclude_from_defaults":"true" to a provider to exclude it from being run unless
-		// -providers=all or -providers=name
-		if vals["_exclude_from_defaults"] == "true" {
-			isNonDefault[name] = true
-		}
-	}
-	registrars := map[string]providers.Registrar{}
-	dnsProviders := map[string]providers.DNSServiceProvider{}
-	for _, d := range cfg.Domains {
-		if registrars[d.RegistrarName] == nil {
-			rCfg := cfg.RegistrarsByName[d.RegistrarName]
-			r, err := providers.CreateRegistrar(rCfg.Type, providerConfigs[d.RegistrarName])
-			if err != nil {
-				return nil, err
-			}
-			registrars[d.RegistrarName] = r
-		}
-		d.RegistrarInstance.Driver = registrars[d.RegistrarName]
-		d.RegistrarInstance.IsDefault = !isNonDefault[d.RegistrarName]
-		for _, pInst := range d.DNSProviderInstances {
-			if dnsProviders[pInst.Name] == nil {
-				dCfg := cfg.DNSProvidersByName[pInst.Name]
-				prov, err := providers.CreateDNSProvider(dCfg.Type, providerConfigs[dCfg.Name], dCfg.Metadata)
-				if err != nil {
-					return nil, err
-				}
-				dnsProviders[pInst.Name] = prov
-			}
-			pInst.Driver = dnsProviders[pInst.Name]
-			pInst.IsDefault = !isNonDefault[pInst.Name]
-		}
-	}
+	err = engine.InitializeProviders(cfg, providerConfigs)
 	return
 }
-
-func printOrRunCorrections(domain string, provider string, corrections []*models.Correction, out printer.CLI, push bool, interactive bool, notifier notifications.Notifier) (anyErrors bool) {
-	anyErrors = false
-	if len(corrections) == 0 {
-		return false
-	}
-	for i, correction := range corrections {
-		out.PrintCorrection(i, correction)
-		var err error
-		if push {
-			if interactive && !out.PromptToRun() {
-				continue
-			}
-			err = correction.F()
-			out.EndCorrection(err)
-			if err != nil {
-				anyErrors = true
-			}
-		}
-		notifier.Notify(domain, provider, correction.Msg, err, !push)
-	}
-	return anyErrors
-}
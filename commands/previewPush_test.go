@@ -0,0 +1,50 @@
+package commands
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/StackExchange/dnscontrol/pkg/printer"
+)
+
+func TestPushJSONOutput(t *testing.T) {
+	dir, cleanup := testFiles(t, map[string]string{
+		"creds.json":   `{"bind": {"directory": "$DIR/zones"}}`,
+		"dnsconfig.js": `D("example.com", NewRegistrar("none", "NONE"), DnsProvider(NewDnsProvider("bind", "BIND")), A("www", "1.2.3.4"));`,
+		"zones/.keep":  "",
+	})
+	defer cleanup()
+	args := PreviewArgs{Output: "json", Concurrency: 1}
+	args.JSFile = filepath.Join(dir, "dnsconfig.js")
+	args.CredsFile = filepath.Join(dir, "creds.json")
+	args.StateFile = filepath.Join(dir, "state.json")
+
+	stdout := os.Stdout
+	buf := &bytes.Buffer{}
+	out, err := args.printer(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := run(args, true, false, false, nil, out); err != nil {
+		t.Fatal(err)
+	}
+	if os.Stdout != stdout {
+		t.Error("-output json must not replace os.Stdout")
+	}
+	// BIND logs that it creates the zonefile, which must not end up among the events.
+	events := map[string]int{}
+	for s := bufio.NewScanner(buf); s.Scan(); {
+		var e printer.Event
+		if err := json.Unmarshal(s.Bytes(), &e); err != nil {
+			t.Fatalf("expected only JSON events, got %q: %s", s.Text(), err)
+		}
+		events[e.Event]++
+	}
+	if events[printer.EventCorrectionResult] != 1 {
+		t.Errorf("expected the result of 1 correction, got %v", events)
+	}
+}
@@ -3,12 +3,14 @@ package commands
 import (
 	"encoding/json"
 	"fmt"
-	"io/ioutil"
 	"os"
+	"strings"
 
 	"github.com/StackExchange/dnscontrol/models"
+	"github.com/StackExchange/dnscontrol/pkg/engine"
 	"github.com/StackExchange/dnscontrol/pkg/js"
 	"github.com/StackExchange/dnscontrol/pkg/normalize"
+	"github.com/StackExchange/dnscontrol/pkg/printer"
 	"github.com/urfave/cli"
 )
 
@@ -80,19 +82,7 @@ func PrintIR(args PrintIRArgs) error {
 
 // PrintValidationErrors formats and prints the validation errors and warnings.
 func PrintValidationErrors(errs []error) (fatal bool) {
-	if len(errs) == 0 {
-		return false
-	}
-	fmt.Printf("%d Validation errors:\n", len(errs))
-	for _, err := range errs {
-		if _, ok := err.(normalize.Warning); ok {
-			fmt.Printf("WARNING: %s\n", err)
-		} else {
-			fatal = true
-			fmt.Printf("ERROR: %s\n", err)
-		}
-	}
-	return
+	return engine.PrintValidationErrors(errs, printer.ConsolePrinter{})
 }
 
 // ExecuteDSL executes the dnsconfig.js contents.
@@ -100,11 +90,19 @@ func ExecuteDSL(args ExecuteDSLArgs) (*models.DNSConfig, error) {
 	if args.JSFile == "" {
 		return nil, fmt.Errorf("No config specified")
 	}
-	text, err := ioutil.ReadFile(args.JSFile)
-	if err != nil {
+	if _, err := os.Stat(args.JSFile); err != nil {
 		return nil, fmt.Errorf("Reading js file %s: %s", args.JSFile, err)
 	}
-	dnsConfig, err := js.ExecuteJavascript(string(text), args.DevMode)
+	allowEnv := []string{}
+	for _, name := range strings.Split(args.AllowEnv, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			allowEnv = append(allowEnv, name)
+		}
+	}
+	dnsConfig, err := js.ExecuteJavascriptFile(args.JSFile, args.DevMode, allowEnv)
+	if e, ok := err.(*js.Error); ok {
+		return nil, e // already names the file
+	}
 	if err != nil {
 		return nil, fmt.Errorf("Executing javascript in %s: %s", args.JSFile, err)
 	}
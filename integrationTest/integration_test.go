@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"testing"
 
@@ -112,7 +113,7 @@ func runTests(t *testing.T, prv providers.DNSServiceProvider, domainName string,
 				}
 				dom.Records = append(dom.Records, &rc)
 			}
-			dom.IgnoredLabels = tst.IgnoredLabels
+			dom.Ignored = tst.Ignored
 			models.PostProcessRecords(dom.Records)
 			dom2, _ := dom.Copy()
 			// get corrections for first time
@@ -132,7 +133,7 @@ func runTests(t *testing.T, prv providers.DNSServiceProvider, domainName string,
 				if *verbose {
 					t.Log(c.Msg)
 				}
-				err = c.F()
+				err = c.Run(context.Background())
 				if !skipVal && err != nil {
 					t.Fatal(err)
 				}
@@ -168,7 +169,7 @@ func TestDualProviders(t *testing.T) {
 		}
 		for i, c := range cs {
 			t.Logf("#%d: %s", i+1, c.Msg)
-			if err = c.F(); err != nil {
+			if err = c.Run(context.Background()); err != nil {
 				t.Fatal(err)
 			}
 		}
@@ -197,9 +198,9 @@ func TestDualProviders(t *testing.T) {
 }
 
 type TestCase struct {
-	Desc          string
-	Records       []*rec
-	IgnoredLabels []string
+	Desc    string
+	Records []*rec
+	Ignored []*models.IgnoreRule
 }
 
 type rec models.RecordConfig
@@ -299,18 +300,18 @@ func (r *rec) ttl(t uint32) *rec {
 
 func tc(desc string, recs ...*rec) *TestCase {
 	var records []*rec
-	var ignored []string
+	var ignored []*models.IgnoreRule
 	for _, r := range recs {
 		if r.Type == "IGNORE" {
-			ignored = append(ignored, r.Name)
+			ignored = append(ignored, &models.IgnoreRule{Label: r.Name})
 		} else {
 			records = append(records, r)
 		}
 	}
 	return &TestCase{
-		Desc:          desc,
-		Records:       records,
-		IgnoredLabels: ignored,
+		Desc:    desc,
+		Records: records,
+		Ignored: ignored,
 	}
 }
 
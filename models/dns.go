@@ -1,8 +1,7 @@
 package models
 
 import (
-	"bytes"
-	"encoding/gob"
+	"context"
 	"encoding/json"
 	"fmt"
 	"log"
@@ -354,11 +353,23 @@ type DomainConfig struct {
 	RegistrarName    string         `json:"registrar"`
 	DNSProviderNames map[string]int `json:"dnsProviders"`
 
-	Metadata      map[string]string `json:"meta,omitempty"`
-	Records       Records           `json:"records"`
-	Nameservers   []*Nameserver     `json:"nameservers,omitempty"`
-	KeepUnknown   bool              `json:"keepunknown,omitempty"`
-	IgnoredLabels []string          `json:"ignored_labels,omitempty"`
+	Metadata    map[string]string `json:"meta,omitempty"`
+	Records     Records           `json:"records"`
+	Nameservers []*Nameserver     `json:"nameservers,omitempty"`
+	KeepUnknown bool              `json:"keepunknown,omitempty"`
+	PurgeOwned  bool              `json:"purgeowned,omitempty"` // only delete the records in Owned
+	Ignored     []*IgnoreRule     `json:"ignored,omitempty"`
+
+	// Owned holds the OwnershipKey of the records DNSControl created, as loaded from the state file.
+	Owned map[string]bool `json:"-"`
+
+	// OnDiff, if set, is called by the differ with the existing records and the records
+	// the zone will hold once the corrections are made. It is used to render zone diffs.
+	OnDiff func(existing, result Records) `json:"-"`
+
+	// Explain, if set, is called by the differ to print which canonicalizers changed the
+	// records, and which corrections they caused or suppressed.
+	Explain func(format string, args ...interface{}) `json:"-"`
 
 	// These fields contain instantiated provider instances once everything is linked up.
 	// This linking is in two phases:
@@ -369,30 +380,76 @@ type DomainConfig struct {
 }
 
 // Copy returns a deep copy of the DomainConfig.
+// The provider instances are shared, not copied.
 func (dc *DomainConfig) Copy() (*DomainConfig, error) {
-	newDc := &DomainConfig{}
-	// provider instances are interfaces that gob hates if you don't register them.
-	// and the specific types are not gob encodable since nothing is exported.
-	// should find a better solution for this now.
-	//
-	// current strategy: remove everything, gob copy it. Then set both to stored copy.
-	reg := dc.RegistrarInstance
-	dnsps := dc.DNSProviderInstances
-	dc.RegistrarInstance = nil
-	dc.DNSProviderInstances = nil
-	err := copyObj(dc, newDc)
-	dc.RegistrarInstance = reg
-	newDc.RegistrarInstance = reg
-	dc.DNSProviderInstances = dnsps
-	newDc.DNSProviderInstances = dnsps
-	return newDc, err
+	newDc := *dc
+	newDc.DNSProviderNames = copyIntMap(dc.DNSProviderNames)
+	newDc.Metadata = copyStringMap(dc.Metadata)
+	if dc.Owned != nil {
+		newDc.Owned = make(map[string]bool, len(dc.Owned))
+		for k, v := range dc.Owned {
+			newDc.Owned[k] = v
+		}
+	}
+	if dc.Records != nil {
+		newDc.Records = make(Records, len(dc.Records))
+		for i, r := range dc.Records {
+			newDc.Records[i], _ = r.Copy()
+		}
+	}
+	if dc.Nameservers != nil {
+		newDc.Nameservers = make([]*Nameserver, len(dc.Nameservers))
+		for i, n := range dc.Nameservers {
+			ns := *n
+			newDc.Nameservers[i] = &ns
+		}
+	}
+	if dc.Ignored != nil {
+		newDc.Ignored = make([]*IgnoreRule, len(dc.Ignored))
+		for i, ig := range dc.Ignored {
+			rule := *ig
+			rule.Types = append([]string(nil), ig.Types...)
+			newDc.Ignored[i] = &rule
+		}
+	}
+	if dc.DNSProviderInstances != nil {
+		newDc.DNSProviderInstances = append([]*DNSProviderInstance(nil), dc.DNSProviderInstances...)
+	}
+	return &newDc, nil
 }
 
 // Copy returns a deep copy of a RecordConfig.
+// Original, the provider-specific record, is shared.
 func (rc *RecordConfig) Copy() (*RecordConfig, error) {
-	newR := &RecordConfig{}
-	err := copyObj(rc, newR)
-	return newR, err
+	newR := *rc
+	newR.Metadata = copyStringMap(rc.Metadata)
+	newR.R53Alias = copyStringMap(rc.R53Alias)
+	if rc.TxtStrings != nil {
+		newR.TxtStrings = append([]string(nil), rc.TxtStrings...)
+	}
+	return &newR, nil
+}
+
+func copyStringMap(m map[string]string) map[string]string {
+	if m == nil {
+		return nil
+	}
+	c := make(map[string]string, len(m))
+	for k, v := range m {
+		c[k] = v
+	}
+	return c
+}
+
+func copyIntMap(m map[string]int) map[string]int {
+	if m == nil {
+		return nil
+	}
+	c := make(map[string]int, len(m))
+	for k, v := range m {
+		c[k] = v
+	}
+	return c
 }
 
 // Punycode will convert all records to punycode format.
@@ -544,16 +601,6 @@ func SplitCombinedCaaValue(s string) (tag string, flag uint8, value string, err
 	return
 }
 
-func copyObj(input interface{}, output interface{}) error {
-	buf := &bytes.Buffer{}
-	enc := gob.NewEncoder(buf)
-	dec := gob.NewDecoder(buf)
-	if err := enc.Encode(input); err != nil {
-		return err
-	}
-	return dec.Decode(output)
-}
-
 // HasRecordTypeName returns True if there is a record with this rtype and name.
 func (dc *DomainConfig) HasRecordTypeName(rtype, name string) bool {
 	for _, r := range dc.Records {
@@ -595,7 +642,101 @@ func InterfaceToIP(i interface{}) (net.IP, error) {
 }
 
 // Correction is anything that can be run. Implementation is up to the specific provider.
+// Providers that support cancellation set FC instead of F.
 type Correction struct {
-	F   func() error `json:"-"`
+	F   func() error                    `json:"-"`
+	FC  func(ctx context.Context) error `json:"-"`
 	Msg string
+	// Kind says what the correction does, if the provider knows.
+	Kind CorrectionKind `json:",omitempty"`
+	// DependsOn lists corrections (of the same provider and domain) that must
+	// succeed before this one may run.
+	DependsOn []*Correction `json:"-"`
+	// Changes lists the record changes the correction makes, if the provider knows them.
+	Changes []Change `json:",omitempty"`
+}
+
+// Change is a change of a single record, as computed by the differ.
+type Change struct {
+	Action string `json:"action"` // CREATE, MODIFY or DELETE
+	Type   string `json:"type"`
+	Name   string `json:"name"`          // the full name of the record
+	Old    string `json:"old,omitempty"` // the content before the change, without the TTL; empty for CREATE
+	OldTTL uint32 `json:"old_ttl,omitempty"`
+	New    string `json:"new,omitempty"` // the content after the change, without the TTL; empty for DELETE
+	NewTTL uint32 `json:"new_ttl,omitempty"`
+}
+
+// CorrectionKind is the kind of change a correction makes.
+type CorrectionKind string
+
+// The kinds of corrections.
+const (
+	CorrectionUnknown     CorrectionKind = ""
+	CorrectionCreate      CorrectionKind = "create"
+	CorrectionModify      CorrectionKind = "modify"
+	CorrectionDelete      CorrectionKind = "delete"
+	CorrectionNameservers CorrectionKind = "nameservers" // a registrar changes the delegation
+)
+
+// OrderCorrections returns the corrections in an order that runs every correction after
+// the ones it depends on. Apart from that the order is kept.
+// It returns an error if the dependencies form a cycle or refer to a correction that is not in the list.
+func OrderCorrections(corrections []*Correction) ([]*Correction, error) {
+	pending := map[*Correction]bool{}
+	for _, c := range corrections {
+		pending[c] = true
+	}
+	for _, c := range corrections {
+		for _, d := range c.DependsOn {
+			if !pending[d] {
+				return nil, fmt.Errorf("correction %q depends on a correction that will not run: %q", c.Msg, d.Msg)
+			}
+		}
+	}
+	// Repeatedly take the first correction that is ready, so that one that had
+	// to wait runs as soon as possible.
+	ordered := make([]*Correction, 0, len(corrections))
+	first := 0 // all corrections before this one are ordered
+	for len(ordered) < len(corrections) {
+		next := -1
+		for i := first; i < len(corrections); i++ {
+			if c := corrections[i]; pending[c] && c.ready(pending) {
+				next = i
+				break
+			}
+		}
+		if next < 0 {
+			return nil, fmt.Errorf("corrections depend on each other in a cycle")
+		}
+		ordered = append(ordered, corrections[next])
+		delete(pending, corrections[next])
+		for first < len(corrections) && !pending[corrections[first]] {
+			first++
+		}
+	}
+	return ordered, nil
+}
+
+// ready reports whether none of the dependencies of c are pending.
+func (c *Correction) ready(pending map[*Correction]bool) bool {
+	for _, d := range c.DependsOn {
+		if pending[d] {
+			return false
+		}
+	}
+	return true
+}
+
+// Run runs the correction. If the provider does not support cancellation, Run returns
+// an *OutcomeUnknownError when ctx is done, as the correction may still complete in the background.
+func (c *Correction) Run(ctx context.Context) error {
+	if c.FC != nil {
+		return c.FC(ctx)
+	}
+	var err error
+	if cerr := runContext(ctx, func() { err = c.F() }); cerr != nil {
+		return &OutcomeUnknownError{Err: cerr}
+	}
+	return err
 }
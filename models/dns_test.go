@@ -1,6 +1,9 @@
 package models
 
 import (
+	"fmt"
+	"reflect"
+	"strconv"
 	"testing"
 )
 
@@ -88,3 +91,81 @@ func TestDowncase(t *testing.T) {
 		t.Errorf("%v: target1 expected (%v) got (%v)\n", dc.Records, "targetmx", dc.Records[1].Target)
 	}
 }
+
+func TestOrderCorrections(t *testing.T) {
+	a := &Correction{Msg: "a"}
+	b := &Correction{Msg: "b"}
+	c := &Correction{Msg: "c", DependsOn: []*Correction{a}}
+	d := &Correction{Msg: "d"}
+	ordered, err := OrderCorrections([]*Correction{c, b, a, d})
+	if err != nil {
+		t.Fatal(err)
+	}
+	msgs := ""
+	for _, o := range ordered {
+		msgs += o.Msg
+	}
+	if msgs != "bacd" {
+		t.Errorf("expected order bacd, got %s", msgs)
+	}
+
+	a.DependsOn = []*Correction{c}
+	if _, err := OrderCorrections([]*Correction{a, c}); err == nil {
+		t.Errorf("expected an error for a cycle")
+	}
+	if _, err := OrderCorrections([]*Correction{c}); err == nil {
+		t.Errorf("expected an error for a missing dependency")
+	}
+}
+
+func TestDomainConfigCopy(t *testing.T) {
+	reg := &RegistrarInstance{}
+	dc := &DomainConfig{
+		Name:                 "example.com",
+		DNSProviderNames:     map[string]int{"bind": 0},
+		Metadata:             map[string]string{"a": "b"},
+		Records:              Records{{Type: "TXT", Name: "@", NameFQDN: "example.com", Target: "x", TxtStrings: []string{"x"}, Metadata: map[string]string{}}},
+		Nameservers:          []*Nameserver{{Name: "ns1.example.com"}},
+		Ignored:              []*IgnoreRule{{Label: "foo", Types: []string{"A"}}},
+		RegistrarInstance:    reg,
+		DNSProviderInstances: []*DNSProviderInstance{{}},
+	}
+	c, err := dc.Copy()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(dc, c) {
+		t.Fatalf("copy differs:\n%+v\n%+v", dc, c)
+	}
+	c.DNSProviderNames["other"] = 1
+	c.Metadata["a"] = "c"
+	c.Records[0].TxtStrings[0] = "y"
+	c.Records[0].Metadata["k"] = "v"
+	c.Nameservers[0].Name = "ns2.example.com"
+	c.Ignored[0].Types[0] = "AAAA"
+	if len(dc.DNSProviderNames) != 1 || dc.Metadata["a"] != "b" || dc.Records[0].TxtStrings[0] != "x" || len(dc.Records[0].Metadata) != 0 ||
+		dc.Nameservers[0].Name != "ns1.example.com" || dc.Ignored[0].Types[0] != "A" {
+		t.Errorf("changing the copy changed the original: %+v", dc)
+	}
+	if c.RegistrarInstance != reg || c.DNSProviderInstances[0] != dc.DNSProviderInstances[0] {
+		t.Errorf("expected the provider instances to be shared")
+	}
+}
+
+func BenchmarkDomainConfigCopy(b *testing.B) {
+	for _, n := range []int{1000, 10000, 100000} {
+		b.Run(strconv.Itoa(n), func(b *testing.B) {
+			dc := &DomainConfig{Name: "10.in-addr.arpa", Metadata: map[string]string{}}
+			for i := 0; i < n; i++ {
+				name := fmt.Sprintf("%d.%d.%d", i%256, i/256%256, i/65536)
+				dc.Records = append(dc.Records, &RecordConfig{Type: "PTR", Name: name, NameFQDN: name + ".10.in-addr.arpa", Target: fmt.Sprintf("host%d.example.com.", i), TTL: 300, Metadata: map[string]string{}})
+			}
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, err := dc.Copy(); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
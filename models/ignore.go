@@ -0,0 +1,168 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// IgnoreRule describes existing records that DNSControl must leave alone, as declared with IGNORE().
+//
+// Label and Target are glob patterns: "*" matches any text within a single label,
+// "**" matches any text including dots, and "?" matches a single character other than a dot.
+// A Label of just "*" is the exception: it matches the wildcard record only, as it did
+// before IGNORE took patterns.
+type IgnoreRule struct {
+	Label  string   `json:"label"`            // matched against the short name ("@" for the apex) and the FQDN
+	Types  []string `json:"types,omitempty"`  // record types; empty means all types
+	Target string   `json:"target,omitempty"` // matched against the target; empty means any target
+}
+
+func (r *IgnoreRule) String() string {
+	s := r.Label
+	if len(r.Types) > 0 {
+		s += " " + strings.Join(r.Types, ",")
+	}
+	if r.Target != "" {
+		s += " " + r.Target
+	}
+	return s
+}
+
+// Validate returns an error if the rule is malformed.
+func (r *IgnoreRule) Validate() error {
+	if err := validateGlob(r.Label); err != nil {
+		return fmt.Errorf("IGNORE(%s): label %s", r, err)
+	}
+	if r.Target != "" {
+		if err := validateGlob(r.Target); err != nil {
+			return fmt.Errorf("IGNORE(%s): target %s", r, err)
+		}
+	}
+	for _, t := range r.Types {
+		if t == "" || strings.ToUpper(t) != t || strings.ContainsAny(t, " ,*") {
+			return fmt.Errorf("IGNORE(%s): invalid record type %q", r, t)
+		}
+	}
+	return nil
+}
+
+// Matches reports whether the record, in the given domain, is ignored by the rule.
+func (r *IgnoreRule) Matches(rec *RecordConfig, domain string) bool {
+	if len(r.Types) > 0 {
+		found := false
+		for _, t := range r.Types {
+			if t == rec.Type {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	// providers differ in how they set Name, but all set NameFQDN
+	name := rec.Name
+	if rec.NameFQDN != "" {
+		name = shortName(rec.NameFQDN, domain)
+	}
+	if r.Label == "*" {
+		if name != "*" {
+			return false
+		}
+	} else if !globMatch(r.Label, name) && !globMatch(r.Label, rec.NameFQDN) {
+		return false
+	}
+	// targets may be given with or without the trailing dot
+	return r.Target == "" || globMatch(r.Target, rec.Target) || globMatch(r.Target, strings.TrimSuffix(rec.Target, "."))
+}
+
+// IgnoredBy returns the first IGNORE rule of the domain that matches the record, or nil.
+func (dc *DomainConfig) IgnoredBy(rec *RecordConfig) *IgnoreRule {
+	for _, r := range dc.Ignored {
+		if r.Matches(rec, dc.Name) {
+			return r
+		}
+	}
+	return nil
+}
+
+// UnmarshalJSON also reads the "ignored_labels" of JSON written before IGNORE took rules,
+// as rules that match those labels.
+func (dc *DomainConfig) UnmarshalJSON(b []byte) error {
+	type domainConfig DomainConfig // without this method, so that it is not called again
+	d := struct {
+		*domainConfig
+		IgnoredLabels []string `json:"ignored_labels"`
+	}{domainConfig: (*domainConfig)(dc)}
+	if err := json.Unmarshal(b, &d); err != nil {
+		return err
+	}
+	for _, label := range d.IgnoredLabels {
+		dc.Ignored = append(dc.Ignored, &IgnoreRule{Label: label})
+	}
+	return nil
+}
+
+func shortName(fqdn, domain string) string {
+	switch {
+	case strings.EqualFold(fqdn, domain):
+		return "@"
+	case strings.HasSuffix(strings.ToLower(fqdn), "."+strings.ToLower(domain)):
+		return fqdn[:len(fqdn)-len(domain)-1]
+	}
+	return fqdn
+}
+
+func validateGlob(pattern string) error {
+	switch {
+	case pattern == "":
+		return fmt.Errorf("is empty")
+	case strings.ContainsAny(pattern, " \t"):
+		return fmt.Errorf("%q contains whitespace", pattern)
+	case strings.Contains(pattern, "***"):
+		return fmt.Errorf("%q contains ***", pattern)
+	}
+	return nil
+}
+
+// globMatch reports whether s matches the pattern, ignoring case.
+func globMatch(pattern, s string) bool {
+	return globMatchLower(strings.ToLower(pattern), strings.ToLower(s))
+}
+
+func globMatchLower(p, s string) bool {
+	for len(p) > 0 {
+		switch {
+		case strings.HasPrefix(p, "**"):
+			p = p[2:]
+			for i := 0; i <= len(s); i++ {
+				if globMatchLower(p, s[i:]) {
+					return true
+				}
+			}
+			return false
+		case p[0] == '*':
+			p = p[1:]
+			for i := 0; i <= len(s); i++ {
+				if globMatchLower(p, s[i:]) {
+					return true
+				}
+				if i < len(s) && s[i] == '.' {
+					break
+				}
+			}
+			return false
+		case len(s) == 0:
+			return false
+		case p[0] == '?':
+			if s[0] == '.' {
+				return false
+			}
+		case p[0] != s[0]:
+			return false
+		}
+		p, s = p[1:], s[1:]
+	}
+	return len(s) == 0
+}
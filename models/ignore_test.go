@@ -0,0 +1,86 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestGlobMatch(t *testing.T) {
+	tests := []struct {
+		pattern, s string
+		match      bool
+	}{
+		{"foo", "foo", true},
+		{"foo", "FOO", true},
+		{"foo", "foo.bar", false},
+		{"*", "foo", true},
+		{"*", "foo.bar", false},
+		{"*.k8s", "web.k8s", true},
+		{"*.k8s", "a.web.k8s", false},
+		{"**.k8s", "a.web.k8s", true},
+		{"**.k8s", "k8s", false},
+		{"_acme-challenge.**", "_acme-challenge.www", true},
+		{"_acme-challenge.**", "_acme-challenge", false},
+		{"w?w", "www", true},
+		{"w?w", "w.w", false},
+		{"10.0.*.*", "10.0.3.4", true},
+		{"10.0.*", "10.0.3.4", false},
+		{"", "", true},
+	}
+	for _, tst := range tests {
+		if got := globMatch(tst.pattern, tst.s); got != tst.match {
+			t.Errorf("globMatch(%q, %q) = %v, expected %v", tst.pattern, tst.s, got, tst.match)
+		}
+	}
+}
+
+func TestIgnoreRuleMatches(t *testing.T) {
+	rec := &RecordConfig{Type: "CNAME", Name: "www", NameFQDN: "www.example.com", Target: "edge.cdn.example.net."}
+	apex := &RecordConfig{Type: "A", Name: "@", NameFQDN: "example.com", Target: "1.2.3.4"}
+	wildcard := &RecordConfig{Type: "A", Name: "*", NameFQDN: "*.example.com", Target: "1.2.3.4"}
+	tests := []struct {
+		rule  IgnoreRule
+		rec   *RecordConfig
+		match bool
+	}{
+		{IgnoreRule{Label: "www"}, rec, true},
+		{IgnoreRule{Label: "www.example.com"}, rec, true},
+		{IgnoreRule{Label: "w*"}, rec, true},
+		{IgnoreRule{Label: "www", Types: []string{"A", "CNAME"}}, rec, true},
+		{IgnoreRule{Label: "www", Types: []string{"A"}}, rec, false},
+		{IgnoreRule{Label: "www", Target: "**.cdn.example.net"}, rec, true},
+		{IgnoreRule{Label: "www", Target: "**.cdn.example.net."}, rec, true},
+		{IgnoreRule{Label: "www", Target: "*.example.net"}, rec, false},
+		{IgnoreRule{Label: "@"}, apex, true},
+		{IgnoreRule{Label: "*"}, wildcard, true}, // IGNORE("*") is the wildcard record only
+		{IgnoreRule{Label: "*"}, apex, false},
+		{IgnoreRule{Label: "*"}, rec, false},
+		{IgnoreRule{Label: "**"}, rec, true},
+		{IgnoreRule{Label: "*.example.com"}, rec, true},
+		{IgnoreRule{Label: "*.example.com"}, wildcard, true},
+		{IgnoreRule{Label: "@"}, rec, false},
+	}
+	for _, tst := range tests {
+		if got := tst.rule.Matches(tst.rec, "example.com"); got != tst.match {
+			t.Errorf("IGNORE(%s) matches %s %s: got %v, expected %v", &tst.rule, tst.rec.Type, tst.rec.NameFQDN, got, tst.match)
+		}
+	}
+}
+
+func TestDomainConfigIgnoredLabels(t *testing.T) {
+	dc := &DomainConfig{}
+	in := `{"name": "foo.com", "ignored_labels": ["www"], "ignored": [{"label": "*.k8s", "types": ["A"]}]}`
+	if err := json.Unmarshal([]byte(in), dc); err != nil {
+		t.Fatal(err)
+	}
+	if dc.Name != "foo.com" || len(dc.Ignored) != 2 || dc.Ignored[0].String() != "*.k8s A" || dc.Ignored[1].String() != "www" {
+		t.Errorf("expected the rule and the label, got %+v", dc)
+	}
+	out, err := json.Marshal(dc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(out) != `{"name":"foo.com","registrar":"","dnsProviders":null,"records":null,"ignored":[{"label":"*.k8s","types":["A"]},{"label":"www"}]}` {
+		t.Errorf("expected only the new form to be written, got %s", out)
+	}
+}
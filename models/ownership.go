@@ -0,0 +1,18 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+// OwnershipKey identifies a record by its name, type and content, but not its TTL or metadata.
+// It is what the state file stores for domains that use PURGE_OWNED.
+func (rc *RecordConfig) OwnershipKey() string {
+	return fmt.Sprintf("%s %s %s", strings.ToLower(rc.NameFQDN), rc.Type, rc.Content())
+}
+
+// IsOwned reports whether the record may be deleted: always, unless the domain uses PURGE_OWNED
+// and the record is not listed in Owned.
+func (dc *DomainConfig) IsOwned(rec *RecordConfig) bool {
+	return !dc.PurgeOwned || dc.Owned[rec.OwnershipKey()]
+}
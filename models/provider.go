@@ -1,5 +1,13 @@
 package models
 
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/StackExchange/dnscontrol/pkg/retry"
+)
+
 type DNSProvider interface {
 	GetNameservers(domain string) ([]*Nameserver, error)
 	GetDomainCorrections(dc *DomainConfig) ([]*Correction, error)
@@ -9,10 +17,73 @@ type Registrar interface {
 	GetRegistrarCorrections(dc *DomainConfig) ([]*Correction, error)
 }
 
+// DNSProviderContext is implemented by DNS providers whose API calls can be cancelled.
+// Callers should not use it directly, but the methods of DNSProviderInstance, which
+// also handle providers that do not implement it.
+type DNSProviderContext interface {
+	GetNameserversContext(ctx context.Context, domain string) ([]*Nameserver, error)
+	GetDomainCorrectionsContext(ctx context.Context, dc *DomainConfig) ([]*Correction, error)
+}
+
+// RegistrarContext is implemented by registrars whose API calls can be cancelled.
+// Callers should use the methods of RegistrarInstance instead.
+type RegistrarContext interface {
+	GetRegistrarCorrectionsContext(ctx context.Context, dc *DomainConfig) ([]*Correction, error)
+}
+
 type ProviderBase struct {
 	Name         string
 	IsDefault    bool
 	ProviderType string
+	Timeout      time.Duration // for each API call; 0 means no timeout
+	Retry        retry.Policy  // for API calls that fail temporarily
+}
+
+// withTimeout returns a context that expires after the timeout of the provider, if it has one.
+func (p *ProviderBase) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if p.Timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, p.Timeout)
+}
+
+// OutcomeUnknownError is returned by a correction that was given up on while its provider may still
+// be making it, because the provider does not support cancellation. It may or may not have been made.
+type OutcomeUnknownError struct {
+	Err error // why it was given up on, i.e. a timeout
+}
+
+func (e *OutcomeUnknownError) Error() string {
+	return fmt.Sprintf("%s; the correction may or may not have been made", e.Err)
+}
+
+// wrapError makes a timeout error say which provider timed out.
+func (p *ProviderBase) wrapError(err error) error {
+	if u, ok := err.(*OutcomeUnknownError); ok {
+		return &OutcomeUnknownError{Err: p.wrapError(u.Err)}
+	}
+	if err == context.DeadlineExceeded {
+		return fmt.Errorf("%s did not respond within %s", p.Name, p.Timeout)
+	}
+	return err
+}
+
+// attempt calls f with the timeout of the provider, retrying as its retry policy and retryable allow.
+func (p *ProviderBase) attempt(ctx context.Context, retryable retry.Classifier, f func(ctx context.Context) error) error {
+	return p.Retry.Do(ctx, retryable, func() error {
+		ctx, cancel := p.withTimeout(ctx)
+		defer cancel()
+		return p.wrapError(f(ctx))
+	})
+}
+
+// RunCorrection runs a correction of this provider, giving up when ctx is done or the provider times out.
+// It is retried only if the provider rejected it because of rate limits, never if its outcome is unknown.
+func (p *ProviderBase) RunCorrection(ctx context.Context, c *Correction) error {
+	return p.attempt(ctx, func(err error) bool {
+		_, unknown := err.(*OutcomeUnknownError)
+		return !unknown && retry.RateLimited(err)
+	}, c.Run)
 }
 
 type RegistrarInstance struct {
@@ -20,8 +91,111 @@ type RegistrarInstance struct {
 	Driver Registrar
 }
 
+// GetRegistrarCorrections asks the registrar for corrections, giving up when ctx is done or the registrar times out.
+// Temporary errors are retried according to the retry policy.
+func (r *RegistrarInstance) GetRegistrarCorrections(ctx context.Context, dc *DomainConfig) (corrections []*Correction, err error) {
+	err = r.attempt(ctx, retry.Temporary, func(ctx context.Context) error {
+		return withCopy(dc, func(dc *DomainConfig) (err error) {
+			if rc, ok := r.Driver.(RegistrarContext); ok {
+				corrections, err = rc.GetRegistrarCorrectionsContext(ctx, dc)
+				return err
+			}
+			var cc []*Correction
+			var cerr error
+			if err = runContext(ctx, func() { cc, cerr = r.Driver.GetRegistrarCorrections(dc) }); err != nil {
+				return err
+			}
+			corrections = cc
+			return cerr
+		})
+	})
+	return corrections, err
+}
+
 type DNSProviderInstance struct {
 	ProviderBase
 	Driver              DNSProvider
 	NumberOfNameservers int
 }
+
+// GetNameservers asks the provider for the nameservers of a domain, giving up when ctx is done or the provider times out.
+// Temporary errors are retried according to the retry policy.
+func (p *DNSProviderInstance) GetNameservers(ctx context.Context, domain string) (nss []*Nameserver, err error) {
+	err = p.attempt(ctx, retry.Temporary, func(ctx context.Context) (err error) {
+		if pc, ok := p.Driver.(DNSProviderContext); ok {
+			nss, err = pc.GetNameserversContext(ctx, domain)
+			return err
+		}
+		var ns []*Nameserver
+		var cerr error
+		if err = runContext(ctx, func() { ns, cerr = p.Driver.GetNameservers(domain) }); err != nil {
+			return err
+		}
+		nss = ns
+		return cerr
+	})
+	return nss, err
+}
+
+// GetDomainCorrections asks the provider for corrections, giving up when ctx is done or the provider times out.
+// Temporary errors are retried according to the retry policy.
+func (p *DNSProviderInstance) GetDomainCorrections(ctx context.Context, dc *DomainConfig) (corrections []*Correction, err error) {
+	err = p.attempt(ctx, retry.Temporary, func(ctx context.Context) error {
+		return withCopy(dc, func(dc *DomainConfig) (err error) {
+			if pc, ok := p.Driver.(DNSProviderContext); ok {
+				corrections, err = pc.GetDomainCorrectionsContext(ctx, dc)
+				return err
+			}
+			var cc []*Correction
+			var cerr error
+			if err = runContext(ctx, func() { cc, cerr = p.Driver.GetDomainCorrections(dc) }); err != nil {
+				return err
+			}
+			corrections = cc
+			return cerr
+		})
+	})
+	return corrections, err
+}
+
+// withCopy calls f with a copy of dc, and copies it back into dc only if f succeeds.
+// Drivers change dc in place, i.e. to transform records, so an attempt that failed must not
+// leave those changes behind for the next one.
+func withCopy(dc *DomainConfig, f func(dc *DomainConfig) error) error {
+	c, err := dc.Copy()
+	if err != nil {
+		return err
+	}
+	if err := f(c); err != nil {
+		return err
+	}
+	*dc = *c
+	return nil
+}
+
+// runContext runs f, but returns ctx.Err() as soon as ctx is done.
+// This lets callers give up on drivers that do not support cancellation themselves.
+// f keeps running in the background in that case, so the caller must not
+// use anything f writes to unless runContext returned nil.
+func runContext(ctx context.Context, f func()) error {
+	if ctx.Done() == nil {
+		f()
+		return nil
+	}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		f()
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		select {
+		case <-done: // finished at the last moment
+			return nil
+		default:
+			return ctx.Err()
+		}
+	}
+}
@@ -0,0 +1,161 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/StackExchange/dnscontrol/pkg/retry"
+)
+
+type slowProvider struct {
+	delay time.Duration
+}
+
+func (s slowProvider) GetNameservers(domain string) ([]*Nameserver, error) {
+	time.Sleep(s.delay)
+	return StringsToNameservers([]string{"ns1.example.com"}), nil
+}
+
+func (s slowProvider) GetDomainCorrections(dc *DomainConfig) ([]*Correction, error) {
+	time.Sleep(s.delay)
+	return []*Correction{{Msg: "fix", F: func() error { return nil }}}, nil
+}
+
+func TestDNSProviderInstanceTimeout(t *testing.T) {
+	p := &DNSProviderInstance{
+		ProviderBase: ProviderBase{Name: "slow", Timeout: 10 * time.Millisecond},
+		Driver:       slowProvider{delay: time.Second},
+	}
+	_, err := p.GetNameservers(context.Background(), "example.com")
+	if err == nil || !strings.Contains(err.Error(), "slow did not respond within 10ms") {
+		t.Errorf("expected timeout error, got %v", err)
+	}
+	_, err = p.GetDomainCorrections(context.Background(), &DomainConfig{Name: "example.com"})
+	if err == nil {
+		t.Errorf("expected timeout error, got nil")
+	}
+}
+
+func TestDNSProviderInstanceNoTimeout(t *testing.T) {
+	p := &DNSProviderInstance{
+		ProviderBase: ProviderBase{Name: "fast"},
+		Driver:       slowProvider{delay: time.Millisecond},
+	}
+	nss, err := p.GetNameservers(context.Background(), "example.com")
+	if err != nil || len(nss) != 1 {
+		t.Fatalf("expected 1 nameserver, got %v, %v", nss, err)
+	}
+	corrections, err := p.GetDomainCorrections(context.Background(), &DomainConfig{Name: "example.com"})
+	if err != nil || len(corrections) != 1 {
+		t.Fatalf("expected 1 correction, got %v, %v", corrections, err)
+	}
+	if err := p.RunCorrection(context.Background(), corrections[0]); err != nil {
+		t.Errorf("expected correction to run, got %v", err)
+	}
+}
+
+func TestCorrectionRun(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	var got context.Context
+	c := &Correction{FC: func(ctx context.Context) error { got = ctx; return ctx.Err() }}
+	if err := c.Run(ctx); err != context.Canceled || got != ctx {
+		t.Errorf("FC: expected the context to be passed, got %v", err)
+	}
+	c = &Correction{F: func() error { time.Sleep(time.Second); return nil }}
+	if err, ok := c.Run(ctx).(*OutcomeUnknownError); !ok || err.Err != context.Canceled {
+		t.Errorf("F: expected an unknown outcome because of %v, got %v", context.Canceled, err)
+	}
+}
+
+func TestRunCorrectionTimeout(t *testing.T) {
+	p := &ProviderBase{Name: "slow", Timeout: 10 * time.Millisecond, Retry: retry.Policy{MaxAttempts: 3, Delay: time.Millisecond}}
+	var calls int32
+	c := &Correction{F: func() error {
+		atomic.AddInt32(&calls, 1)
+		time.Sleep(time.Second)
+		return fmt.Errorf("429 Too Many Requests")
+	}}
+	err := p.RunCorrection(context.Background(), c)
+	if _, ok := err.(*OutcomeUnknownError); !ok || !strings.Contains(err.Error(), "slow did not respond within 10ms") {
+		t.Errorf("expected an unknown outcome because of the timeout, got %v", err)
+	}
+	if n := atomic.LoadInt32(&calls); n != 1 {
+		t.Errorf("a correction with an unknown outcome must not be retried, got %d calls", n)
+	}
+}
+
+type flakyProvider struct {
+	failures *int
+}
+
+func (f flakyProvider) GetNameservers(domain string) ([]*Nameserver, error) {
+	if *f.failures > 0 {
+		*f.failures--
+		return nil, fmt.Errorf("503 Service Unavailable")
+	}
+	return nil, nil
+}
+
+func (f flakyProvider) GetDomainCorrections(dc *DomainConfig) ([]*Correction, error) {
+	return nil, nil
+}
+
+func TestDNSProviderInstanceRetry(t *testing.T) {
+	failures := 2
+	p := &DNSProviderInstance{
+		ProviderBase: ProviderBase{Name: "flaky", Retry: retry.Policy{MaxAttempts: 3, Delay: time.Millisecond}},
+		Driver:       flakyProvider{failures: &failures},
+	}
+	if _, err := p.GetNameservers(context.Background(), "example.com"); err != nil {
+		t.Errorf("expected success after retries, got %v", err)
+	}
+	failures = 3
+	if _, err := p.GetNameservers(context.Background(), "example.com"); err == nil {
+		t.Errorf("expected error after 3 attempts")
+	}
+}
+
+// mutatingProvider changes the records it is given, like providers that transform them,
+// and fails the first time.
+type mutatingProvider struct {
+	failures *int
+	seen     *[]string
+}
+
+func (m mutatingProvider) GetNameservers(domain string) ([]*Nameserver, error) {
+	return nil, nil
+}
+
+func (m mutatingProvider) GetDomainCorrections(dc *DomainConfig) ([]*Correction, error) {
+	*m.seen = append(*m.seen, dc.Records[0].Target)
+	dc.Records[0].Target = "transformed-" + dc.Records[0].Target
+	if *m.failures > 0 {
+		*m.failures--
+		return nil, fmt.Errorf("429 Too Many Requests")
+	}
+	return nil, nil
+}
+
+func TestGetDomainCorrectionsRetryCopies(t *testing.T) {
+	failures := 1
+	var seen []string
+	p := &DNSProviderInstance{
+		ProviderBase: ProviderBase{Name: "mutating", Retry: retry.Policy{MaxAttempts: 2, Delay: time.Millisecond}},
+		Driver:       mutatingProvider{failures: &failures, seen: &seen},
+	}
+	dc := &DomainConfig{Name: "example.com", Records: Records{{Type: "A", Name: "www", Target: "1.2.3.4"}}}
+	if _, err := p.GetDomainCorrections(context.Background(), dc); err != nil {
+		t.Fatal(err)
+	}
+	if len(seen) != 2 || seen[0] != "1.2.3.4" || seen[1] != "1.2.3.4" {
+		t.Errorf("expected every attempt to see the original record, got %v", seen)
+	}
+	if dc.Records[0].Target != "transformed-1.2.3.4" {
+		t.Errorf("expected the changes of the successful attempt to be kept, got %s", dc.Records[0].Target)
+	}
+}
@@ -0,0 +1,51 @@
+package models
+
+import "strings"
+
+// The metadata of a record that restricts it to some of the DNS providers of its domain,
+// as set by ONLY_PROVIDERS() and EXCEPT_PROVIDERS(). Both hold comma-separated provider names.
+const (
+	MetaOnlyProviders   = "only_providers"
+	MetaExceptProviders = "except_providers"
+)
+
+// ProviderNames returns the names of the DNS providers listed in the metadata key,
+// which is MetaOnlyProviders or MetaExceptProviders.
+func (rc *RecordConfig) ProviderNames(key string) []string {
+	names := []string{}
+	for _, n := range strings.Split(rc.Metadata[key], ",") {
+		if n = strings.TrimSpace(n); n != "" {
+			names = append(names, n)
+		}
+	}
+	return names
+}
+
+// ForProvider reports whether the record is to be sent to the named DNS provider.
+// Records without MetaOnlyProviders and MetaExceptProviders are sent to every provider.
+func (rc *RecordConfig) ForProvider(name string) bool {
+	if only := rc.ProviderNames(MetaOnlyProviders); len(only) > 0 && !hasName(only, name) {
+		return false
+	}
+	return !hasName(rc.ProviderNames(MetaExceptProviders), name)
+}
+
+// ForProvider returns the records that are to be sent to the named DNS provider.
+func (r Records) ForProvider(name string) Records {
+	recs := Records{}
+	for _, rec := range r {
+		if rec.ForProvider(name) {
+			recs = append(recs, rec)
+		}
+	}
+	return recs
+}
+
+func hasName(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
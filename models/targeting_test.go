@@ -0,0 +1,33 @@
+package models
+
+import "testing"
+
+func TestForProvider(t *testing.T) {
+	tests := []struct {
+		meta     map[string]string
+		provider string
+		expected bool
+	}{
+		{nil, "cf", true},
+		{map[string]string{MetaOnlyProviders: "cf"}, "cf", true},
+		{map[string]string{MetaOnlyProviders: "cf"}, "bind", false},
+		{map[string]string{MetaOnlyProviders: "cf, r53"}, "r53", true},
+		{map[string]string{MetaExceptProviders: "bind"}, "bind", false},
+		{map[string]string{MetaExceptProviders: "bind"}, "cf", true},
+		{map[string]string{MetaOnlyProviders: "cf,bind", MetaExceptProviders: "bind"}, "bind", false},
+		{map[string]string{MetaOnlyProviders: ""}, "bind", true},
+	}
+	for _, tst := range tests {
+		rec := &RecordConfig{Type: "A", Name: "www", Metadata: tst.meta}
+		if got := rec.ForProvider(tst.provider); got != tst.expected {
+			t.Errorf("%v: ForProvider(%q) = %v, expected %v", tst.meta, tst.provider, got, tst.expected)
+		}
+	}
+	recs := Records{
+		{Type: "A", Name: "a"},
+		{Type: "A", Name: "b", Metadata: map[string]string{MetaOnlyProviders: "cf"}},
+	}
+	if got := recs.ForProvider("bind"); len(got) != 1 || got[0].Name != "a" {
+		t.Errorf("expected only a for bind, got %v", got)
+	}
+}
@@ -0,0 +1,71 @@
+package engine
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/StackExchange/dnscontrol/pkg/notifications"
+)
+
+// providerLimiter caps how many domains may use a provider type at the same time.
+// Some provider APIs are rate limited, so running too many domains against them in parallel only causes errors.
+type providerLimiter map[string]chan struct{}
+
+func newProviderLimiter(limits map[string]int) providerLimiter {
+	l := providerLimiter{}
+	for pType, n := range limits {
+		l[pType] = make(chan struct{}, n)
+	}
+	return l
+}
+
+// acquire blocks until the provider type may be used. The returned function must be called when done.
+func (l providerLimiter) acquire(pType string) (release func()) {
+	sem, ok := l[pType]
+	if !ok {
+		return func() {}
+	}
+	sem <- struct{}{}
+	return func() { <-sem }
+}
+
+// ParseProviderConcurrency parses limits per provider type, i.e. "ROUTE53=2,GCLOUD=4",
+// as given to the -provider-concurrency flag.
+func ParseProviderConcurrency(s string) (map[string]int, error) {
+	limits := map[string]int{}
+	if s == "" {
+		return limits, nil
+	}
+	for _, item := range strings.Split(s, ",") {
+		parts := strings.SplitN(item, "=", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("Invalid provider concurrency %q: expected TYPE=N", item)
+		}
+		n, err := strconv.Atoi(strings.TrimSpace(parts[1]))
+		if err != nil || n < 1 {
+			return nil, fmt.Errorf("Invalid provider concurrency %q: N must be a positive number", item)
+		}
+		limits[strings.TrimSpace(parts[0])] = n
+	}
+	return limits, nil
+}
+
+// syncNotifier makes a Notifier safe to use from several goroutines.
+type syncNotifier struct {
+	mu sync.Mutex
+	n  notifications.Notifier
+}
+
+func (s *syncNotifier) Notify(domain, provider string, message string, err error, preview bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.n.Notify(domain, provider, message, err, preview)
+}
+
+func (s *syncNotifier) Done() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.n.Done()
+}
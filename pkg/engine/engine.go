@@ -0,0 +1,582 @@
+// Package engine previews and pushes a DNS configuration, independently of the CLI.
+//
+// Programs that embed DNSControl call Preview or Push with a configuration and
+// the credentials of its providers, and get back a Result describing what was
+// (or would be) changed. Progress is reported as it happens to an optional
+// printer.CLI and to an optional callback receiving printer.Events.
+package engine
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/StackExchange/dnscontrol/models"
+	"github.com/StackExchange/dnscontrol/pkg/nameservers"
+	"github.com/StackExchange/dnscontrol/pkg/normalize"
+	"github.com/StackExchange/dnscontrol/pkg/notifications"
+	"github.com/StackExchange/dnscontrol/pkg/ownership"
+	"github.com/StackExchange/dnscontrol/pkg/plan"
+	"github.com/StackExchange/dnscontrol/pkg/printer"
+	"github.com/StackExchange/dnscontrol/pkg/retry"
+	"github.com/StackExchange/dnscontrol/pkg/zoneformat"
+)
+
+// Options configures a preview or push.
+type Options struct {
+	// Domains limits the run to these domains. Empty means all domains.
+	Domains []string
+	// Providers limits the run to these providers (and registrars). Empty means all,
+	// except those marked "_exclude_from_defaults" in the credentials. "all" means all.
+	Providers []string
+
+	Concurrency         int            // domains processed in parallel; 0 or 1 means one at a time
+	ProviderConcurrency map[string]int // limits how many domains may use a provider type at once
+
+	Safety      Safety
+	Force       bool // push even if the corrections exceed the safety limits
+	Interactive bool // ask Printer.PromptToRun before every correction; requires a Printer that can prompt
+
+	Approved *plan.Plan // if set, push only if the corrections are exactly the ones in this plan
+	PlanOut  *plan.Plan // if set, every correction is added to this plan
+
+	ZoneDiff bool // also show the changes of every provider as a unified diff of the zonefile
+	Color    bool // color the zone diffs for a terminal
+	Explain  bool // print which canonicalizers changed the records, and which corrections they caused or suppressed
+
+	// State lists the records DNSControl owns. It is required if a domain uses PURGE_OWNED.
+	// Push updates it once all corrections of a provider are made; the caller must write it.
+	State *ownership.State
+
+	Notifier notifications.Notifier // optional
+
+	Printer printer.CLI         // optional; receives the progress as text
+	OnEvent func(printer.Event) // optional; receives the progress as structured events
+}
+
+// Preview computes the corrections needed to make the providers match cfg, without making them.
+// creds holds the credentials of every provider, as in creds.json.
+func Preview(ctx context.Context, cfg *models.DNSConfig, creds map[string]map[string]string, opts Options) (*Result, error) {
+	if err := Prepare(cfg, creds); err != nil {
+		return nil, err
+	}
+	return Run(ctx, cfg, false, opts)
+}
+
+// Push computes the corrections needed to make the providers match cfg, and makes them.
+// creds holds the credentials of every provider, as in creds.json.
+func Push(ctx context.Context, cfg *models.DNSConfig, creds map[string]map[string]string, opts Options) (*Result, error) {
+	if err := Prepare(cfg, creds); err != nil {
+		return nil, err
+	}
+	return Run(ctx, cfg, true, opts)
+}
+
+// Run previews or pushes cfg, whose providers must have been set up with Prepare or InitializeProviders.
+//
+// Once ctx is cancelled, no new domains or corrections are started, but the
+// correction in progress is finished; Result.Interrupted is then set.
+// The error is only set if the run could not be completed. Failures of individual
+// providers or corrections are recorded in the Result instead.
+func Run(ctx context.Context, cfg *models.DNSConfig, push bool, opts Options) (*Result, error) {
+	result := &Result{}
+	col := &collector{result: result, prompt: opts.Printer}
+	if opts.Printer != nil {
+		col.outs = append(col.outs, opts.Printer)
+	}
+	if opts.OnEvent != nil {
+		col.outs = append(col.outs, printer.NewEventPrinter(opts.OnEvent))
+	}
+	var out printer.CLI = col
+
+	errs := normalize.NormalizeAndValidateConfig(cfg)
+	if PrintValidationErrors(errs, out) {
+		return result, fmt.Errorf("Exiting due to validation errors")
+	}
+	if opts.State == nil {
+		for _, domain := range cfg.Domains {
+			if domain.PurgeOwned {
+				return result, fmt.Errorf("%s uses PURGE_OWNED, which requires a state file", domain.Name)
+			}
+		}
+	}
+	concurrency := opts.Concurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	if opts.Interactive && concurrency > 1 {
+		out.Warnf("Interactive mode processes one domain at a time; ignoring -concurrency.\n")
+		concurrency = 1
+	}
+	notifier := opts.Notifier
+	if notifier == nil {
+		notifier = notifications.Init(nil)
+	}
+	r := &runner{
+		ctx:      ctx,
+		opts:     opts,
+		push:     push,
+		parallel: concurrency > 1,
+		notifier: &syncNotifier{n: notifier},
+		limiter:  newProviderLimiter(opts.ProviderConcurrency),
+	}
+	domains := []*models.DomainConfig{}
+	for _, domain := range cfg.Domains {
+		if r.shouldRunDomain(domain.Name) {
+			domains = append(domains, domain)
+		}
+	}
+	anyErrors, err := r.runDomains(domains, concurrency, out)
+	if err != nil {
+		return result, err
+	}
+	result.failed = anyErrors
+	result.Interrupted = ctx.Err() != nil
+	result.Ran = int(r.ran)
+	result.Skipped = int(r.skipped)
+	result.SkippedDomains = int(r.skippedDomains)
+	return result, nil
+}
+
+// PrintValidationErrors prints the errors found by normalize, and reports whether any of them is fatal.
+func PrintValidationErrors(errs []error, out printer.CLI) (fatal bool) {
+	if len(errs) == 0 {
+		return false
+	}
+	out.Debugf("%d Validation errors:\n", len(errs))
+	for _, err := range errs {
+		if _, ok := err.(normalize.Warning); ok {
+			out.Warnf("%s\n", err)
+		} else {
+			fatal = true
+			out.Errorf("%s\n", err)
+		}
+	}
+	return
+}
+
+// runner holds the state shared by all domains during a preview or push.
+type runner struct {
+	ctx      context.Context // cancelled when the user interrupts the run
+	opts     Options
+	push     bool
+	parallel bool
+	notifier notifications.Notifier
+	limiter  providerLimiter
+
+	// Counters reported when the run is interrupted.
+	ran            int32 // corrections run
+	skipped        int32 // corrections not run
+	skippedDomains int32 // domains not processed at all
+}
+
+// domainResult is the outcome of processing a single domain.
+type domainResult struct {
+	corrections int
+	anyErrors   bool  // a provider or correction failed, or a push was refused
+	declined    bool  // a correction was declined in interactive mode
+	err         error // fatal; stops processing of further domains
+}
+
+func (r *runner) shouldRunProvider(name string, dc *models.DomainConfig) bool {
+	if len(r.opts.Providers) == 1 && r.opts.Providers[0] == "all" {
+		return true
+	}
+	if len(r.opts.Providers) == 0 {
+		for _, pri := range dc.DNSProviderInstances {
+			if pri.Name == name {
+				return pri.IsDefault
+			}
+		}
+		return true
+	}
+	for _, prov := range r.opts.Providers {
+		if prov == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *runner) shouldRunDomain(d string) bool {
+	if len(r.opts.Domains) == 0 {
+		return true
+	}
+	for _, dom := range r.opts.Domains {
+		if dom == d {
+			return true
+		}
+	}
+	return false
+}
+
+// runDomains processes the domains using a pool of concurrency workers.
+// The output of each domain is printed as a whole, in the order of domains.
+func (r *runner) runDomains(domains []*models.DomainConfig, concurrency int, out printer.CLI) (anyErrors bool, err error) {
+	recorders := make([]*printer.Recorder, len(domains))
+	results := make([]chan domainResult, len(domains))
+	for i := range domains {
+		recorders[i] = &printer.Recorder{}
+		results[i] = make(chan domainResult, 1)
+	}
+	queue := make(chan int)
+	var stopped int32
+	for w := 0; w < concurrency; w++ {
+		go func() {
+			for i := range queue {
+				if atomic.LoadInt32(&stopped) != 0 {
+					results[i] <- domainResult{}
+					continue
+				}
+				if r.ctx.Err() != nil {
+					atomic.AddInt32(&r.skippedDomains, 1)
+					results[i] <- domainResult{}
+					continue
+				}
+				// A single worker prints directly, so that interactive prompts work.
+				var o printer.CLI = recorders[i]
+				if concurrency == 1 {
+					o = out
+				}
+				res := r.runDomain(domains[i], o)
+				if res.err != nil {
+					atomic.StoreInt32(&stopped, 1)
+				}
+				results[i] <- res
+			}
+		}()
+	}
+	go func() {
+		for i := range domains {
+			queue <- i
+		}
+		close(queue)
+	}()
+	// Wait for every domain, even after a fatal error, so no push is cut off halfway.
+	for i := range domains {
+		res := <-results[i]
+		recorders[i].Replay(out)
+		anyErrors = anyErrors || res.anyErrors
+		if err == nil {
+			err = res.err
+		}
+	}
+	return anyErrors, err
+}
+
+// runDomain previews or pushes one domain: all its DNS providers, then its registrar.
+func (r *runner) runDomain(domain *models.DomainConfig, out printer.CLI) (res domainResult) {
+	out.StartDomain(domain.Name)
+	defer out.EndDomain(domain.Name)
+	nsList, err := nameservers.DetermineNameservers(withRetryNotify(r.ctx, "Getting nameservers", out), domain, out, r.limiter.acquire)
+	if err != nil {
+		if r.ctx.Err() != nil {
+			atomic.AddInt32(&r.skippedDomains, 1)
+			return
+		}
+		res.err = err
+		return
+	}
+	domain.Nameservers = nsList
+	nameservers.AddNSRecords(domain)
+	// Every provider gets its own copy, so that one can not alter the records another one sees.
+	// The copy holds only the records meant for the provider (see ONLY_PROVIDERS and EXCEPT_PROVIDERS).
+	copies := make([]*models.DomainConfig, len(domain.DNSProviderInstances))
+	for i, provider := range domain.DNSProviderInstances {
+		if copies[i], err = domain.Copy(); err != nil {
+			res.err = err
+			return
+		}
+		copies[i].Records = copies[i].Records.ForProvider(provider.Name)
+	}
+	// Every DNS provider computes its corrections before any of them is pushed, and none is pushed
+	// unless all of them succeeded, so that a failing provider never leaves the others half changed.
+	// With -concurrency the providers do so in parallel, else one after the other.
+	runs := make([]*providerRun, len(copies))
+	r.forEachProvider(len(runs), func(i int) {
+		runs[i] = r.readDNSProvider(domain.Name, domain.DNSProviderInstances[i], copies[i])
+	})
+	providersOK := true
+	for _, p := range runs {
+		providersOK = providersOK && p.ok
+	}
+	var skip error // why no corrections are made
+	if !providersOK {
+		skip = errProviderFailed
+	} else if err := r.opts.Safety.check(domain, runs); err != nil {
+		switch {
+		case !r.push:
+			out.Warnf("%s. Push will refuse this without -force.\n", err)
+		case r.opts.Force:
+			out.Warnf("%s. Continuing due to -force.\n", err)
+		default:
+			out.Warnf("Refusing to push: %s. Use -force to override.\n", err)
+			skip, providersOK, res.anyErrors = errSafetyLimits, false, true
+		}
+	}
+	if r.parallel {
+		r.forEachProvider(len(runs), func(i int) { r.correctDNSProvider(domain.Name, runs[i], skip, runs[i].out) })
+		for _, p := range runs {
+			p.out.Replay(out)
+		}
+	} else {
+		// The corrections are printed directly, so that interactive prompts work.
+		for _, p := range runs {
+			p.out.Replay(out)
+			r.correctDNSProvider(domain.Name, p, skip, out)
+		}
+	}
+	for _, p := range runs {
+		res.corrections += p.res.corrections
+		res.anyErrors = res.anyErrors || p.res.anyErrors
+	}
+	if !providersOK {
+		// Never touch the registrar when a DNS provider could not be handled.
+		return
+	}
+	if r.push && res.anyErrors {
+		// Nor when corrections of a DNS provider failed: the new nameservers may not serve the zone.
+		out.StartRegistrar(domain.RegistrarName, true)
+		out.Warnf("Not changing the registrar of %s, as corrections of its DNS providers failed.\n", domain.Name)
+		return
+	}
+	run := r.shouldRunProvider(domain.RegistrarName, domain)
+	out.StartRegistrar(domain.RegistrarName, !run)
+	if !run {
+		return
+	}
+	if len(domain.Nameservers) == 0 && domain.Metadata["no_ns"] != "true" {
+		out.Warnf("No nameservers declared; skipping registrar. Add {no_ns:'true'} to force.\n")
+		return
+	}
+	dc, err := domain.Copy()
+	if err != nil {
+		res.err = err
+		return
+	}
+	release := r.limiter.acquire(domain.RegistrarInstance.ProviderType)
+	defer release()
+	corrections, err := domain.RegistrarInstance.GetRegistrarCorrections(withRetryNotify(r.ctx, domain.RegistrarName, out), dc)
+	out.EndProvider(len(corrections), err)
+	if err != nil {
+		res.anyErrors = true
+		return
+	}
+	rr, _ := r.handleCorrections(domain.Name, &domain.RegistrarInstance.ProviderBase, true, corrections, out)
+	res.corrections += rr.corrections
+	res.anyErrors = res.anyErrors || rr.anyErrors
+	return
+}
+
+// forEachProvider calls f for 0 <= i < n, in parallel with -concurrency, and returns when all calls returned.
+func (r *runner) forEachProvider(n int, f func(i int)) {
+	if !r.parallel {
+		for i := 0; i < n; i++ {
+			f(i)
+		}
+		return
+	}
+	wg := sync.WaitGroup{}
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			f(i)
+		}(i)
+	}
+	wg.Wait()
+}
+
+// providerRun is a DNS provider of a domain, between computing its corrections and making them.
+type providerRun struct {
+	provider    *models.DNSProviderInstance
+	dc          *models.DomainConfig
+	corrections []*models.Correction
+	out         *printer.Recorder
+	skipped     bool // not selected to run
+	ok          bool // false if the provider failed, or its corrections were refused
+	res         domainResult
+}
+
+// readDNSProvider computes the corrections of one DNS provider of a domain, and checks them.
+// Its output is recorded, to be replayed before the corrections are made.
+func (r *runner) readDNSProvider(domain string, provider *models.DNSProviderInstance, dc *models.DomainConfig) *providerRun {
+	p := &providerRun{provider: provider, dc: dc, out: &printer.Recorder{}, ok: true}
+	out := p.out
+	shouldrun := r.shouldRunProvider(provider.Name, dc)
+	out.StartDNSProvider(provider.Name, !shouldrun)
+	if !shouldrun {
+		p.skipped = true
+		return p
+	}
+	release := r.limiter.acquire(provider.ProviderType)
+	defer release()
+	if dc.PurgeOwned {
+		dc.Owned = r.opts.State.Owned(domain, provider.Name)
+	}
+	if r.opts.Explain {
+		dc.Explain = out.Debugf
+	}
+	var existing, result models.Records
+	if r.opts.ZoneDiff {
+		dc.OnDiff = func(e, after models.Records) { existing, result = e, after }
+	}
+	corrections, err := provider.GetDomainCorrections(withRetryNotify(r.ctx, provider.Name, out), dc)
+	out.EndProvider(len(corrections), err)
+	if err != nil {
+		p.res.anyErrors, p.ok = true, false
+		return p
+	}
+	if len(corrections) > 0 && result != nil {
+		buf := &bytes.Buffer{}
+		if err := zoneformat.WriteDiff(buf, existing, result, domain, r.opts.Color); err != nil {
+			out.Warnf("Can not render the zone diff: %s\n", err)
+		} else {
+			out.Debugf("%s", buf)
+		}
+	}
+	p.corrections, p.ok = r.checkCorrections(domain, &provider.ProviderBase, false, corrections, out)
+	p.res.anyErrors = !p.ok
+	return p
+}
+
+// correctDNSProvider prints or makes the corrections readDNSProvider computed.
+// If skip is set, i.e. because another provider of the domain failed, a push prints them as skipped instead.
+func (r *runner) correctDNSProvider(domain string, p *providerRun, skip error, out printer.CLI) {
+	if p.skipped || !p.ok {
+		return
+	}
+	p.res.corrections = len(p.corrections)
+	if r.push && skip != nil {
+		if len(p.corrections) == 0 {
+			return
+		}
+		for i, correction := range p.corrections {
+			out.PrintCorrection(i, correction)
+			out.EndCorrection(skip)
+			r.notifier.Notify(domain, p.provider.Name, correction.Msg, skip, false)
+		}
+		atomic.AddInt32(&r.skipped, int32(len(p.corrections)))
+		p.res.anyErrors = true
+		return
+	}
+	if r.push && len(p.corrections) > 0 {
+		release := r.limiter.acquire(p.provider.ProviderType)
+		defer release()
+	}
+	p.res.anyErrors, p.res.declined = r.printOrRunCorrections(domain, &p.provider.ProviderBase, p.corrections, out)
+	if p.dc.PurgeOwned && r.push && !p.res.anyErrors && !p.res.declined {
+		// The zone now holds exactly the declared records. That is also true if there was
+		// nothing to correct, i.e. when a zone that is already in sync is adopted.
+		r.opts.State.SetOwned(p.provider.Name, p.dc)
+	}
+}
+
+// handleCorrections checks the corrections, and then prints or runs them.
+func (r *runner) handleCorrections(domain string, provider *models.ProviderBase, registrar bool, corrections []*models.Correction, out printer.CLI) (res domainResult, ok bool) {
+	corrections, ok = r.checkCorrections(domain, provider, registrar, corrections, out)
+	if !ok {
+		res.anyErrors = true
+		return res, false
+	}
+	res.corrections = len(corrections)
+	res.anyErrors, res.declined = r.printOrRunCorrections(domain, provider, corrections, out)
+	return res, true
+}
+
+// checkCorrections orders the corrections, records them in the plan and checks them against the approved plan.
+func (r *runner) checkCorrections(domain string, provider *models.ProviderBase, registrar bool, corrections []*models.Correction, out printer.CLI) ([]*models.Correction, bool) {
+	corrections, err := models.OrderCorrections(corrections)
+	if err != nil {
+		out.Warnf("Refusing to push: %s\n", err)
+		return nil, false
+	}
+	if r.opts.PlanOut != nil {
+		r.opts.PlanOut.Add(domain, provider.Name, registrar, corrections)
+	}
+	if r.opts.Approved != nil {
+		if err := r.opts.Approved.Verify(domain, provider.Name, registrar, corrections); err != nil {
+			out.Warnf("Refusing to push: %s\n", err)
+			return nil, false
+		}
+	}
+	return corrections, true
+}
+
+// printOrRunCorrections prints the corrections and, when pushing, runs them.
+// Corrections whose dependencies failed are skipped.
+// Once the run is interrupted, or a correction's outcome is unknown, no further corrections are started.
+func (r *runner) printOrRunCorrections(domain string, provider *models.ProviderBase, corrections []*models.Correction, out printer.CLI) (anyErrors, declined bool) {
+	failed := map[*models.Correction]bool{} // failed, skipped or declined
+	for i, correction := range corrections {
+		if r.push && r.ctx.Err() != nil {
+			n := len(corrections) - i
+			atomic.AddInt32(&r.skipped, int32(n))
+			out.Warnf("Interrupted; skipping %d remaining corrections of %s.\n", n, provider.Name)
+			return true, declined
+		}
+		out.PrintCorrection(i, correction)
+		var err error
+		if r.push {
+			if dependencyFailed(correction, failed) {
+				failed[correction] = true
+				atomic.AddInt32(&r.skipped, 1)
+				err = errDependencyFailed
+				out.EndCorrection(err)
+				anyErrors = true
+				r.notifier.Notify(domain, provider.Name, correction.Msg, err, false)
+				continue
+			}
+			if r.opts.Interactive && !out.PromptToRun() {
+				failed[correction] = true
+				declined = true
+				continue
+			}
+			// The correction is not cancelled on interrupt, so it is never cut off halfway.
+			err = provider.RunCorrection(withRetryNotify(context.Background(), provider.Name, out), correction)
+			atomic.AddInt32(&r.ran, 1)
+			out.EndCorrection(err)
+			if err != nil {
+				failed[correction] = true
+				anyErrors = true
+			}
+			if _, unknown := err.(*models.OutcomeUnknownError); unknown {
+				// The provider may still be making it, and the next corrections could race with it.
+				r.notifier.Notify(domain, provider.Name, correction.Msg, err, false)
+				if n := len(corrections) - i - 1; n > 0 {
+					atomic.AddInt32(&r.skipped, int32(n))
+					out.Warnf("Skipping %d remaining corrections of %s, as the last one may still be in progress.\n", n, provider.Name)
+				}
+				return true, declined
+			}
+		}
+		r.notifier.Notify(domain, provider.Name, correction.Msg, err, !r.push)
+	}
+	return anyErrors, declined
+}
+
+var errDependencyFailed = errors.New("skipped, as a correction it depends on was not made")
+
+var errProviderFailed = errors.New("skipped, as another provider of the domain failed")
+
+var errSafetyLimits = errors.New("skipped, as the changes to the domain exceed the safety limits")
+
+func dependencyFailed(c *models.Correction, failed map[*models.Correction]bool) bool {
+	for _, d := range c.DependsOn {
+		if failed[d] {
+			return true
+		}
+	}
+	return false
+}
+
+// withRetryNotify returns a context that reports the retries of a provider's API calls to out.
+func withRetryNotify(ctx context.Context, provider string, out printer.Printer) context.Context {
+	return retry.WithNotify(ctx, func(err error, attempt, maxAttempts int, wait time.Duration) {
+		out.Warnf("%s: %s. Retrying in %s (attempt %d of %d).\n", provider, err, wait.Round(time.Millisecond), attempt+1, maxAttempts)
+	})
+}
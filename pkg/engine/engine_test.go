@@ -0,0 +1,484 @@
+package engine
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/StackExchange/dnscontrol/models"
+	"github.com/StackExchange/dnscontrol/pkg/ownership"
+	"github.com/StackExchange/dnscontrol/pkg/printer"
+	"github.com/StackExchange/dnscontrol/providers"
+	_ "github.com/StackExchange/dnscontrol/providers/bind"
+	"github.com/StackExchange/dnscontrol/providers/diff"
+)
+
+// failingProvider is a DNS provider that can not read any zone.
+type failingProvider struct{}
+
+func (failingProvider) GetNameservers(string) ([]*models.Nameserver, error) { return nil, nil }
+
+func (failingProvider) GetDomainCorrections(*models.DomainConfig) ([]*models.Correction, error) {
+	return nil, errors.New("can not read the zone")
+}
+
+// slowProvider is a DNS provider whose first correction takes longer than its timeout.
+type slowProvider struct {
+	ran *int32
+}
+
+func (slowProvider) GetNameservers(string) ([]*models.Nameserver, error) { return nil, nil }
+
+func (s slowProvider) GetDomainCorrections(*models.DomainConfig) ([]*models.Correction, error) {
+	return []*models.Correction{
+		{Msg: "slow", F: func() error { time.Sleep(time.Second); return nil }},
+		{Msg: "next", F: func() error { atomic.AddInt32(s.ran, 1); return nil }},
+	}, nil
+}
+
+var slowRan int32
+
+// busyProvider is a DNS provider that records the most API calls it had in flight at once.
+type busyProvider struct {
+	active, max *int32
+}
+
+func (b busyProvider) call() {
+	n := atomic.AddInt32(b.active, 1)
+	for m := atomic.LoadInt32(b.max); n > m && !atomic.CompareAndSwapInt32(b.max, m, n); m = atomic.LoadInt32(b.max) {
+	}
+	time.Sleep(5 * time.Millisecond)
+	atomic.AddInt32(b.active, -1)
+}
+
+func (b busyProvider) GetNameservers(string) ([]*models.Nameserver, error) {
+	b.call()
+	return models.StringsToNameservers([]string{"ns1.example.net"}), nil
+}
+
+func (b busyProvider) GetDomainCorrections(*models.DomainConfig) ([]*models.Correction, error) {
+	b.call()
+	return []*models.Correction{{Msg: "busy", F: func() error { b.call(); return nil }}}, nil
+}
+
+var busyActive, busyMax int32
+
+func init() {
+	// Only changes records declared with TTL 42, so that it does not affect the other tests.
+	diff.RegisterCanonicalizer("BIND", "test-ttl", func(rec *models.RecordConfig) {
+		if rec.TTL == 42 {
+			rec.TTL = 300
+		}
+	})
+	providers.RegisterDomainServiceProviderType("TESTFAIL", func(map[string]string, json.RawMessage) (providers.DNSServiceProvider, error) {
+		return failingProvider{}, nil
+	})
+	providers.RegisterDomainServiceProviderType("TESTSLOW", func(map[string]string, json.RawMessage) (providers.DNSServiceProvider, error) {
+		return slowProvider{ran: &slowRan}, nil
+	})
+	providers.RegisterDomainServiceProviderType("TESTBUSY", func(map[string]string, json.RawMessage) (providers.DNSServiceProvider, error) {
+		return busyProvider{active: &busyActive, max: &busyMax}, nil
+	})
+}
+
+func testConfig(records ...*models.RecordConfig) *models.DNSConfig {
+	return &models.DNSConfig{
+		Registrars:   []*models.RegistrarConfig{{Name: "none", Type: "NONE"}},
+		DNSProviders: []*models.DNSProviderConfig{{Name: "bind", Type: "BIND"}},
+		Domains: []*models.DomainConfig{{
+			Name:             "example.com",
+			RegistrarName:    "none",
+			DNSProviderNames: map[string]int{"bind": 0},
+			Records:          records,
+		}},
+	}
+}
+
+func testCreds(t *testing.T) (map[string]map[string]string, func()) {
+	dir, err := ioutil.TempDir("", "engine")
+	if err != nil {
+		t.Fatal(err)
+	}
+	creds := map[string]map[string]string{"bind": {"directory": dir}}
+	return creds, func() { os.RemoveAll(dir) }
+}
+
+func www() *models.RecordConfig {
+	return &models.RecordConfig{Type: "A", Name: "www", Target: "1.2.3.4", TTL: 300}
+}
+
+func TestPreviewAndPush(t *testing.T) {
+	creds, cleanup := testCreds(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	result, err := Preview(ctx, testConfig(www()), creds, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Domains) != 1 || result.Domains[0].Name != "example.com" {
+		t.Fatalf("expected a result for example.com, got %+v", result.Domains)
+	}
+	providers := result.Domains[0].Providers
+	if len(providers) != 2 || providers[0].Name != "bind" || providers[0].Registrar || !providers[1].Registrar {
+		t.Fatalf("expected bind followed by the registrar, got %+v", providers)
+	}
+	if n := len(providers[0].Corrections); n != 1 {
+		t.Fatalf("expected 1 correction, got %d", n)
+	}
+	if c := providers[0].Corrections[0]; c.Ran || c.Error != nil {
+		t.Errorf("preview must not run corrections, got %+v", c)
+	}
+	// BIND creates the SOA record too.
+	expected := models.Change{Action: "CREATE", Type: "A", Name: "www.example.com", New: "1.2.3.4", NewTTL: 300}
+	found := false
+	for _, ch := range providers[0].Corrections[0].Changes {
+		found = found || ch == expected
+	}
+	if !found {
+		t.Errorf("expected the change %+v, got %+v", expected, providers[0].Corrections[0].Changes)
+	}
+	if result.HasErrors() || result.Ran != 0 {
+		t.Errorf("unexpected errors or corrections run: %+v", result)
+	}
+
+	var events []printer.Event
+	result, err = Push(ctx, testConfig(www()), creds, Options{OnEvent: func(e printer.Event) { events = append(events, e) }})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Ran != 1 || result.HasErrors() {
+		t.Errorf("expected 1 correction to run without errors, got %+v", result)
+	}
+	if c := result.Domains[0].Providers[0].Corrections[0]; !c.Ran || c.Error != nil {
+		t.Errorf("expected the correction to have run, got %+v", c)
+	}
+	kinds := map[string]int{}
+	for _, e := range events {
+		if e.Domain != "example.com" {
+			t.Errorf("event %s has domain %q", e.Event, e.Domain)
+		}
+		kinds[e.Event]++
+	}
+	if kinds[printer.EventCorrection] != 1 || kinds[printer.EventCorrectionResult] != 1 || kinds[printer.EventDomain] != 1 {
+		t.Errorf("unexpected events: %v", kinds)
+	}
+
+	result, err = Preview(ctx, testConfig(www()), creds, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := result.Corrections(); n != 0 {
+		t.Errorf("expected no corrections after push, got %d", n)
+	}
+}
+
+func TestFilters(t *testing.T) {
+	creds, cleanup := testCreds(t)
+	defer cleanup()
+
+	result, err := Preview(context.Background(), testConfig(www()), creds, Options{Domains: []string{"example.org"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Domains) != 0 {
+		t.Errorf("expected example.com to be filtered out, got %+v", result.Domains)
+	}
+
+	result, err = Preview(context.Background(), testConfig(www()), creds, Options{Providers: []string{"none"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p := result.Domains[0].Providers[0]; !p.Skipped || len(p.Corrections) != 0 {
+		t.Errorf("expected bind to be skipped, got %+v", p)
+	}
+}
+
+func TestValidationErrors(t *testing.T) {
+	creds, cleanup := testCreds(t)
+	defer cleanup()
+
+	bad := &models.RecordConfig{Type: "A", Name: "www", Target: "not-an-ip", TTL: 300}
+	if _, err := Preview(context.Background(), testConfig(bad), creds, Options{}); err == nil {
+		t.Error("expected a validation error")
+	}
+}
+
+func TestProviderTargeting(t *testing.T) {
+	creds, cleanup := testCreds(t)
+	defer cleanup()
+	creds2, cleanup2 := testCreds(t)
+	defer cleanup2()
+	creds["bind2"] = creds2["bind"]
+	config := func(meta map[string]string) *models.DNSConfig {
+		rec := www()
+		rec.Metadata = meta
+		cfg := testConfig(rec)
+		cfg.DNSProviders = append(cfg.DNSProviders, &models.DNSProviderConfig{Name: "bind2", Type: "BIND"})
+		cfg.Domains[0].DNSProviderNames["bind2"] = 0
+		return cfg
+	}
+
+	if _, err := Push(context.Background(), config(map[string]string{models.MetaOnlyProviders: "bind2"}), creds, Options{}); err != nil {
+		t.Fatal(err)
+	}
+	for name, expected := range map[string]bool{"bind": false, "bind2": true} {
+		zone, err := ioutil.ReadFile(filepath.Join(creds[name]["directory"], "example.com.zone"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if found := strings.Contains(string(zone), "www"); found != expected {
+			t.Errorf("%s: expected www in the zone to be %v, got:\n%s", name, expected, zone)
+		}
+	}
+
+	if _, err := Preview(context.Background(), config(map[string]string{models.MetaExceptProviders: "bind3"}), creds, Options{}); err == nil {
+		t.Error("expected a validation error for an unknown provider")
+	}
+}
+
+func TestFailingProviderStopsPush(t *testing.T) {
+	for _, concurrency := range []int{1, 4} {
+		creds, cleanup := testCreds(t)
+		creds["failing"] = map[string]string{}
+		cfg := testConfig(www())
+		cfg.DNSProviders = append(cfg.DNSProviders, &models.DNSProviderConfig{Name: "failing", Type: "TESTFAIL"})
+		cfg.Domains[0].DNSProviderNames["failing"] = 0
+
+		result, err := Push(context.Background(), cfg, creds, Options{Concurrency: concurrency})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !result.HasErrors() || result.Ran != 0 || result.Skipped != 1 {
+			t.Errorf("concurrency %d: expected the correction of bind to be skipped, got %+v", concurrency, result)
+		}
+		for _, p := range result.Domains[0].Providers {
+			for _, c := range p.Corrections {
+				if c.Ran || c.Error == nil {
+					t.Errorf("concurrency %d: expected %s to report its correction as skipped, got %+v", concurrency, p.Name, c)
+				}
+			}
+		}
+		if _, err := os.Stat(filepath.Join(creds["bind"]["directory"], "example.com.zone")); !os.IsNotExist(err) {
+			t.Errorf("concurrency %d: expected bind not to write the zone, got %v", concurrency, err)
+		}
+		cleanup()
+	}
+}
+
+func TestUnknownOutcomeStopsProvider(t *testing.T) {
+	cfg := testConfig()
+	cfg.DNSProviders = []*models.DNSProviderConfig{{Name: "slow", Type: "TESTSLOW"}}
+	cfg.Domains[0].DNSProviderNames = map[string]int{"slow": 0}
+	creds := map[string]map[string]string{"slow": {"_timeout": "10ms"}}
+
+	result, err := Push(context.Background(), cfg, creds, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.HasErrors() || result.Ran != 1 || result.Skipped != 1 || atomic.LoadInt32(&slowRan) != 0 {
+		t.Errorf("expected the correction after the slow one to be skipped, got %+v", result)
+	}
+	if c := result.Domains[0].Providers[0].Corrections[0]; !c.Unknown || c.Error == nil {
+		t.Errorf("expected the outcome of the slow correction to be unknown, got %+v", c)
+	}
+}
+
+func TestProviderConcurrency(t *testing.T) {
+	cfg := testConfig()
+	cfg.DNSProviders = []*models.DNSProviderConfig{{Name: "busy", Type: "TESTBUSY"}}
+	cfg.Domains = nil
+	for _, name := range []string{"a.com", "b.com", "c.com", "d.com"} {
+		cfg.Domains = append(cfg.Domains, &models.DomainConfig{Name: name, RegistrarName: "none", DNSProviderNames: map[string]int{"busy": -1}})
+	}
+	creds := map[string]map[string]string{"busy": {}}
+
+	result, err := Push(context.Background(), cfg, creds, Options{Concurrency: 4, ProviderConcurrency: map[string]int{"TESTBUSY": 1}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Ran != 4 || result.HasErrors() {
+		t.Errorf("expected 4 corrections to run without errors, got %+v", result)
+	}
+	if n := atomic.LoadInt32(&busyMax); n != 1 {
+		t.Errorf("expected at most 1 call to TESTBUSY at once, got %d", n)
+	}
+}
+
+func TestSafetyRefusesPush(t *testing.T) {
+	creds, cleanup := testCreds(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	if _, err := Push(ctx, testConfig(www()), creds, Options{}); err != nil {
+		t.Fatal(err)
+	}
+	other := &models.RecordConfig{Type: "A", Name: "www", Target: "5.6.7.8", TTL: 300}
+	result, err := Push(ctx, testConfig(other), creds, Options{Safety: Safety{MaxChanges: 1, MaxPercent: 10}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	d := result.Domains[0]
+	if !result.HasErrors() || result.Ran != 0 || len(d.Warnings) == 0 {
+		t.Errorf("expected the push to be refused with a warning, got %+v, %+v", result, d)
+	}
+	if c := d.Providers[0].Corrections[0]; c.Ran || c.Error != errSafetyLimits {
+		t.Errorf("expected the correction to be skipped, got %+v", c)
+	}
+
+	bad := testConfig(other)
+	bad.Domains[0].Metadata = map[string]string{"safety_max_changes": "2O"}
+	if _, err := Push(ctx, bad, creds, Options{Force: true}); err == nil {
+		t.Error("expected invalid safety metadata to be a configuration error, even with -force")
+	}
+}
+
+func TestSafetyCountsAllProviders(t *testing.T) {
+	creds, cleanup := testCreds(t)
+	defer cleanup()
+	creds2, cleanup2 := testCreds(t)
+	defer cleanup2()
+	creds["bind2"] = creds2["bind"]
+	ctx := context.Background()
+	config := func(rec *models.RecordConfig) *models.DNSConfig {
+		cfg := testConfig(rec)
+		cfg.DNSProviders = append(cfg.DNSProviders, &models.DNSProviderConfig{Name: "bind2", Type: "BIND"})
+		cfg.Domains[0].DNSProviderNames["bind2"] = 0
+		return cfg
+	}
+	if _, err := Push(ctx, config(www()), creds, Options{}); err != nil {
+		t.Fatal(err)
+	}
+	// one modification at each provider, two for the domain
+	other := &models.RecordConfig{Type: "A", Name: "www", Target: "5.6.7.8", TTL: 300}
+	result, err := Push(ctx, config(other), creds, Options{Safety: Safety{MaxChanges: 1}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.HasErrors() || result.Ran != 0 || result.Skipped != 2 {
+		t.Errorf("expected the push to be refused, got %+v", result)
+	}
+}
+
+func TestCountChanges(t *testing.T) {
+	corrections := []*models.Correction{
+		// The differ's changes win over the message, which may be in any format.
+		{Msg: "GENERATE_ZONE: 2 changes", Changes: []models.Change{{Action: "CREATE"}, {Action: "DELETE"}}},
+		{Msg: "CREATE record: www A 300 1.2.3.4", Kind: models.CorrectionCreate, Changes: []models.Change{{Action: "CREATE"}}},
+		{Msg: "ACTIVATE PROXY for new record www", Kind: models.CorrectionModify},
+		{Msg: "Change Nameservers", Kind: models.CorrectionNameservers},
+		// Messages are not read: a correction without Changes or Kind counts as one modification.
+		{Msg: "DELETE A old.example.com\nDELETE A older.example.com"},
+		{Msg: "Updating zone"},
+	}
+	creates, modifies, deletes := countChanges(corrections)
+	if creates != 2 || modifies != 3 || deletes != 1 {
+		t.Errorf("expected 2 creates, 3 modifies and 1 delete, got %d, %d and %d", creates, modifies, deletes)
+	}
+}
+
+func TestPurgeOwned(t *testing.T) {
+	creds, cleanup := testCreds(t)
+	defer cleanup()
+	ctx := context.Background()
+	ext := &models.RecordConfig{Type: "A", Name: "ext", Target: "5.6.7.8", TTL: 300}
+
+	// a record created by someone else
+	if _, err := Push(ctx, testConfig(ext), creds, Options{}); err != nil {
+		t.Fatal(err)
+	}
+	owned := func(records ...*models.RecordConfig) *models.DNSConfig {
+		cfg := testConfig(records...)
+		cfg.Domains[0].PurgeOwned = true
+		return cfg
+	}
+	if _, err := Push(ctx, owned(www()), creds, Options{}); err == nil {
+		t.Fatal("expected an error without a state")
+	}
+	state := ownership.New()
+	for _, records := range [][]*models.RecordConfig{{www()}, {}} {
+		result, err := Push(ctx, owned(records...), creds, Options{State: state})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if result.HasErrors() {
+			t.Fatalf("unexpected errors: %+v", result)
+		}
+	}
+	if len(state.Owned("example.com", "bind")) != 0 {
+		t.Errorf("expected nothing to be owned, got %v", state.Owned("example.com", "bind"))
+	}
+	// www was created and then deleted, ext was never touched
+	result, err := Preview(ctx, testConfig(ext), creds, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := result.Corrections(); n != 0 {
+		t.Errorf("expected the zone to hold only ext, got %d corrections", n)
+	}
+
+	// adopting a zone that is already in sync owns its records, so that removing one deletes it
+	adopted := ownership.New()
+	for _, records := range [][]*models.RecordConfig{{ext}, {}} {
+		result, err := Push(ctx, owned(records...), creds, Options{State: adopted})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if result.HasErrors() {
+			t.Fatalf("unexpected errors: %+v", result)
+		}
+	}
+	if result, err = Preview(ctx, testConfig(), creds, Options{}); err != nil {
+		t.Fatal(err)
+	}
+	if n := result.Corrections(); n != 0 {
+		t.Errorf("expected ext to be deleted, got %d corrections", n)
+	}
+}
+
+func TestExplainIsPrinted(t *testing.T) {
+	creds, cleanup := testCreds(t)
+	defer cleanup()
+	ctx := context.Background()
+	if _, err := Push(ctx, testConfig(www()), creds, Options{}); err != nil {
+		t.Fatal(err)
+	}
+
+	rec := www()
+	rec.TTL = 42
+	var events []printer.Event
+	result, err := Preview(ctx, testConfig(rec), creds, Options{Explain: true, OnEvent: func(e printer.Event) { events = append(events, e) }})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := result.Corrections(); n != 0 {
+		t.Errorf("expected the canonicalizer to suppress the correction, got %d", n)
+	}
+	provider := ""
+	found := false
+	for _, e := range events {
+		switch e.Event {
+		case printer.EventProvider:
+			provider = e.Provider
+		case printer.EventProviderEnd:
+			provider = ""
+		case printer.EventDebug:
+			if strings.Contains(e.Message, "EXPLAIN: no change to A www.example.com, suppressed by test-ttl") {
+				found = true
+				if provider != "bind" {
+					t.Errorf("expected the explanation within the events of bind, got it in %q", provider)
+				}
+			}
+		}
+	}
+	if !found {
+		t.Errorf("expected an explanation among the events, got %+v", events)
+	}
+}
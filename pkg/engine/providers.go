@@ -0,0 +1,164 @@
+package engine
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/StackExchange/dnscontrol/models"
+	"github.com/StackExchange/dnscontrol/pkg/retry"
+	"github.com/StackExchange/dnscontrol/providers"
+)
+
+// Prepare sets up the providers of cfg, as read from dnsconfig.js or its JSON form, so it can be passed to Run.
+// creds holds the credentials of every provider, as in creds.json.
+func Prepare(cfg *models.DNSConfig, creds map[string]map[string]string) error {
+	if err := Preload(cfg); err != nil {
+		return err
+	}
+	return InitializeProviders(cfg, creds)
+}
+
+// Preload links every domain of cfg to the configuration of its registrar and DNS providers.
+// It does everything needed before validation, but nothing that requires the credentials.
+// Calling it again has no effect.
+func Preload(cfg *models.DNSConfig) error {
+	if cfg.RegistrarsByName != nil {
+		return nil
+	}
+	//build name to type maps
+	cfg.RegistrarsByName = map[string]*models.RegistrarConfig{}
+	cfg.DNSProvidersByName = map[string]*models.DNSProviderConfig{}
+	for _, reg := range cfg.Registrars {
+		cfg.RegistrarsByName[reg.Name] = reg
+	}
+	for _, p := range cfg.DNSProviders {
+		cfg.DNSProvidersByName[p.Name] = p
+	}
+	// make registrar and dns provider shims. Include name, type, and other metadata, but can't inatantiate
+	// driver until we load creds in later
+	for _, d := range cfg.Domains {
+		reg, ok := cfg.RegistrarsByName[d.RegistrarName]
+		if !ok {
+			return fmt.Errorf("Registrar named %s expected for %s, but never registered", d.RegistrarName, d.Name)
+		}
+		d.RegistrarInstance = &models.RegistrarInstance{
+			ProviderBase: models.ProviderBase{
+				Name:         reg.Name,
+				ProviderType: reg.Type,
+			},
+		}
+		for pName, n := range d.DNSProviderNames {
+			prov, ok := cfg.DNSProvidersByName[pName]
+			if !ok {
+				return fmt.Errorf("DNS Provider named %s expected for %s, but never registered", pName, d.Name)
+			}
+			d.DNSProviderInstances = append(d.DNSProviderInstances, &models.DNSProviderInstance{
+				ProviderBase: models.ProviderBase{
+					Name:         pName,
+					ProviderType: prov.Type,
+				},
+				NumberOfNameservers: n,
+			})
+		}
+		// sort so everything is deterministic
+		sort.Slice(d.DNSProviderInstances, func(i, j int) bool {
+			return d.DNSProviderInstances[i].Name < d.DNSProviderInstances[j].Name
+		})
+	}
+	return nil
+}
+
+// InitializeProviders creates the drivers of all registrars and DNS providers used by cfg,
+// which must have been preloaded. creds holds the credentials of every provider, as in creds.json.
+func InitializeProviders(cfg *models.DNSConfig, creds map[string]map[string]string) error {
+	isNonDefault := map[string]bool{}
+	settings := map[string]providerSettings{}
+	for name, vals := range creds {
+		// add "_exclude_from_defaults":"true" to a provider to exclude it from being run unless
+		// -providers=all or -providers=name
+		if vals["_exclude_from_defaults"] == "true" {
+			isNonDefault[name] = true
+		}
+		s, err := parseProviderSettings(name, vals)
+		if err != nil {
+			return err
+		}
+		settings[name] = s
+	}
+	setting := func(name string) providerSettings {
+		if s, ok := settings[name]; ok {
+			return s
+		}
+		return providerSettings{retry: retry.Default}
+	}
+	registrars := map[string]providers.Registrar{}
+	dnsProviders := map[string]providers.DNSServiceProvider{}
+	for _, d := range cfg.Domains {
+		if registrars[d.RegistrarName] == nil {
+			rCfg := cfg.RegistrarsByName[d.RegistrarName]
+			r, err := providers.CreateRegistrar(rCfg.Type, creds[d.RegistrarName])
+			if err != nil {
+				return err
+			}
+			registrars[d.RegistrarName] = r
+		}
+		d.RegistrarInstance.Driver = registrars[d.RegistrarName]
+		d.RegistrarInstance.IsDefault = !isNonDefault[d.RegistrarName]
+		setting(d.RegistrarName).apply(&d.RegistrarInstance.ProviderBase)
+		for _, pInst := range d.DNSProviderInstances {
+			if dnsProviders[pInst.Name] == nil {
+				dCfg := cfg.DNSProvidersByName[pInst.Name]
+				prov, err := providers.CreateDNSProvider(dCfg.Type, creds[dCfg.Name], dCfg.Metadata)
+				if err != nil {
+					return err
+				}
+				dnsProviders[pInst.Name] = prov
+			}
+			pInst.Driver = dnsProviders[pInst.Name]
+			pInst.IsDefault = !isNonDefault[pInst.Name]
+			setting(pInst.Name).apply(&pInst.ProviderBase)
+		}
+	}
+	return nil
+}
+
+// providerSettings are the settings in creds.json that DNSControl, not the provider, uses.
+type providerSettings struct {
+	timeout time.Duration
+	retry   retry.Policy
+}
+
+// parseProviderSettings reads the settings of a provider from its creds.json entry.
+// "_timeout" limits the duration of every API call. "_retry_max_attempts", "_retry_delay"
+// and "_retry_max_delay" override the retry policy (see docs/timeouts.md).
+func parseProviderSettings(name string, vals map[string]string) (s providerSettings, err error) {
+	s.retry = retry.Default
+	durations := []struct {
+		key string
+		dst *time.Duration
+	}{
+		{"_timeout", &s.timeout},
+		{"_retry_delay", &s.retry.Delay},
+		{"_retry_max_delay", &s.retry.MaxDelay},
+	}
+	for _, d := range durations {
+		if v := vals[d.key]; v != "" {
+			if *d.dst, err = time.ParseDuration(v); err != nil {
+				return s, fmt.Errorf("Invalid %s %q for %s: %s", d.key, v, name, err)
+			}
+		}
+	}
+	if v := vals["_retry_max_attempts"]; v != "" {
+		if s.retry.MaxAttempts, err = strconv.Atoi(v); err != nil {
+			return s, fmt.Errorf("Invalid _retry_max_attempts %q for %s: %s", v, name, err)
+		}
+	}
+	return s, nil
+}
+
+func (s providerSettings) apply(p *models.ProviderBase) {
+	p.Timeout = s.timeout
+	p.Retry = s.retry
+}
@@ -0,0 +1,176 @@
+package engine
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/StackExchange/dnscontrol/models"
+	"github.com/StackExchange/dnscontrol/pkg/printer"
+)
+
+// Result describes what a preview or push found and did.
+type Result struct {
+	Domains []*DomainResult
+
+	Interrupted    bool // the context was cancelled before the run completed
+	Ran            int  // corrections that were run (push only)
+	Skipped        int  // corrections that were not run, because of interruption, failed dependencies or a failed provider
+	SkippedDomains int  // domains that were not processed, because of interruption
+
+	failed bool
+}
+
+// DomainResult is the result for a single domain.
+type DomainResult struct {
+	Name      string
+	Providers []*ProviderResult // the DNS providers, followed by the registrar
+	Warnings  []string          // warnings not about a single provider
+}
+
+// ProviderResult is the result of a DNS provider or registrar of a domain.
+type ProviderResult struct {
+	Name        string
+	Registrar   bool
+	Skipped     bool     // not selected to run
+	Error       error    // getting the corrections failed
+	Warnings    []string // e.g. why a push was refused
+	Corrections []*CorrectionResult
+}
+
+// CorrectionResult is a single correction of a provider.
+type CorrectionResult struct {
+	Message string
+	Kind    models.CorrectionKind
+	Changes []models.Change // the record changes, if the provider knows them
+	Ran     bool            // the correction was run (push only)
+	Error   error           // running the correction failed, or it was skipped
+	Unknown bool            // the correction timed out, and may or may not have been made
+}
+
+// Corrections returns the number of corrections of all domains.
+func (r *Result) Corrections() int {
+	n := 0
+	for _, d := range r.Domains {
+		for _, p := range d.Providers {
+			n += len(p.Corrections)
+		}
+	}
+	return n
+}
+
+// HasErrors reports whether a provider or correction failed, or a push was refused.
+func (r *Result) HasErrors() bool {
+	return r.failed
+}
+
+// collector is a printer.CLI that builds a Result from the output of the run,
+// and passes the output on to other printers.
+// The output of each domain must arrive as a whole, as the recorders of the runner ensure.
+type collector struct {
+	result     *Result
+	outs       []printer.CLI
+	prompt     printer.CLI // answers PromptToRun; nil means never run
+	domain     *DomainResult
+	provider   *ProviderResult
+	correction *CorrectionResult
+}
+
+func (c *collector) StartDomain(domain string) {
+	c.domain = &DomainResult{Name: domain}
+	c.result.Domains = append(c.result.Domains, c.domain)
+	c.provider, c.correction = nil, nil
+	for _, o := range c.outs {
+		o.StartDomain(domain)
+	}
+}
+
+func (c *collector) EndDomain(domain string) {
+	for _, o := range c.outs {
+		o.EndDomain(domain)
+	}
+	c.domain, c.provider, c.correction = nil, nil, nil
+}
+
+func (c *collector) startProvider(name string, registrar, skip bool) {
+	c.provider = &ProviderResult{Name: name, Registrar: registrar, Skipped: skip}
+	c.correction = nil
+	if c.domain != nil {
+		c.domain.Providers = append(c.domain.Providers, c.provider)
+	}
+}
+
+func (c *collector) StartDNSProvider(name string, skip bool) {
+	c.startProvider(name, false, skip)
+	for _, o := range c.outs {
+		o.StartDNSProvider(name, skip)
+	}
+}
+
+func (c *collector) StartRegistrar(name string, skip bool) {
+	c.startProvider(name, true, skip)
+	for _, o := range c.outs {
+		o.StartRegistrar(name, skip)
+	}
+}
+
+func (c *collector) EndProvider(numCorrections int, err error) {
+	if c.provider != nil {
+		c.provider.Error = err
+	}
+	for _, o := range c.outs {
+		o.EndProvider(numCorrections, err)
+	}
+}
+
+func (c *collector) PrintCorrection(n int, corr *models.Correction) {
+	c.correction = &CorrectionResult{Message: corr.Msg, Kind: corr.Kind, Changes: corr.Changes}
+	if c.provider != nil {
+		c.provider.Corrections = append(c.provider.Corrections, c.correction)
+	}
+	for _, o := range c.outs {
+		o.PrintCorrection(n, corr)
+	}
+}
+
+func (c *collector) EndCorrection(err error) {
+	if c.correction != nil {
+		c.correction.Error = err
+		c.correction.Ran = err != errDependencyFailed && err != errProviderFailed && err != errSafetyLimits
+		_, c.correction.Unknown = err.(*models.OutcomeUnknownError)
+	}
+	for _, o := range c.outs {
+		o.EndCorrection(err)
+	}
+}
+
+func (c *collector) PromptToRun() bool {
+	if c.prompt == nil {
+		return false
+	}
+	return c.prompt.PromptToRun()
+}
+
+func (c *collector) Debugf(format string, args ...interface{}) {
+	for _, o := range c.outs {
+		o.Debugf(format, args...)
+	}
+}
+
+func (c *collector) Warnf(format string, args ...interface{}) {
+	msg := strings.TrimSpace(fmt.Sprintf(format, args...))
+	switch {
+	case c.provider != nil:
+		c.provider.Warnings = append(c.provider.Warnings, msg)
+	case c.domain != nil:
+		c.domain.Warnings = append(c.domain.Warnings, msg)
+	}
+	for _, o := range c.outs {
+		o.Warnf(format, args...)
+	}
+}
+
+func (c *collector) Errorf(format string, args ...interface{}) {
+	for _, o := range c.outs {
+		o.Errorf(format, args...)
+	}
+}
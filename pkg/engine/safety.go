@@ -0,0 +1,88 @@
+package engine
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/StackExchange/dnscontrol/models"
+)
+
+// Safety configures the guard against pushing changes that would
+// modify or delete a large part of a zone, e.g. after a typo in dnsconfig.js.
+// Domains may override these limits with the metadata keys
+// "safety_max_changes" and "safety_max_percent".
+type Safety struct {
+	MaxChanges int // 0 means no limit
+	MaxPercent int // 0 means no limit
+}
+
+// limits returns the limits for a domain, taking its metadata into account.
+// The metadata was validated along with the rest of the configuration.
+func (s Safety) limits(dc *models.DomainConfig) (maxChanges, maxPercent int) {
+	maxChanges, maxPercent = s.MaxChanges, s.MaxPercent
+	if v, ok := dc.Metadata["safety_max_changes"]; ok {
+		maxChanges, _ = strconv.Atoi(v)
+	}
+	if v, ok := dc.Metadata["safety_max_percent"]; ok {
+		maxPercent, _ = strconv.Atoi(v)
+	}
+	return maxChanges, maxPercent
+}
+
+// check returns an error if the corrections of all DNS providers of the domain together exceed its limits.
+// The domain of each run must be as its provider saw it, after computing the corrections.
+func (s Safety) check(domain *models.DomainConfig, runs []*providerRun) error {
+	maxChanges, maxPercent := s.limits(domain)
+	if maxChanges <= 0 && maxPercent <= 0 {
+		return nil
+	}
+	changes, existing := 0, 0
+	for _, p := range runs {
+		if p.skipped {
+			continue
+		}
+		creates, modifies, deletes := countChanges(p.corrections)
+		changes += modifies + deletes
+		// The existing zone is what we want, minus what will be created, plus what will be deleted.
+		existing += len(p.dc.Records) - creates + deletes
+	}
+	if maxChanges > 0 && changes > maxChanges {
+		return fmt.Errorf("%d records of %s would be modified or deleted, more than the limit of %d", changes, domain.Name, maxChanges)
+	}
+	if maxPercent > 0 && existing > 0 && changes*100 > existing*maxPercent {
+		return fmt.Errorf("%d of %d records of %s (%d%%) would be modified or deleted, more than the limit of %d%%", changes, existing, domain.Name, changes*100/existing, maxPercent)
+	}
+	return nil
+}
+
+// countChanges counts the record changes of the corrections. It uses the Changes the differ
+// set, or else the Kind of the correction. A correction with neither may change anything, so
+// it counts as one modification: too few changes would let the limits pass unnoticed.
+func countChanges(corrections []*models.Correction) (creates, modifies, deletes int) {
+	count := func(action string) {
+		switch action {
+		case "CREATE":
+			creates++
+		case "DELETE":
+			deletes++
+		default:
+			modifies++
+		}
+	}
+	for _, c := range corrections {
+		switch {
+		case len(c.Changes) > 0:
+			for _, ch := range c.Changes {
+				count(ch.Action)
+			}
+		case c.Kind == models.CorrectionNameservers:
+			// not a record
+		case c.Kind != models.CorrectionUnknown:
+			count(strings.ToUpper(string(c.Kind)))
+		default:
+			count("MODIFY")
+		}
+	}
+	return creates, modifies, deletes
+}
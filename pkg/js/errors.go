@@ -0,0 +1,108 @@
+package js
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/dop251/goja"
+	"github.com/dop251/goja/parser"
+)
+
+// Error is an error in dnsconfig.js, or in a file it requires.
+// The position is in the user's file, even if the error was raised by helpers.js on its behalf.
+type Error struct {
+	File    string // the file, as given to require(); "<eval>" if the script is not a file
+	Line    int
+	Column  int
+	Message string
+	Excerpt string // the line of the error, and a caret below the column
+}
+
+func (e *Error) Error() string {
+	s := fmt.Sprintf("%s:%d:%d: %s", e.File, e.Line, e.Column, e.Message)
+	if e.Excerpt != "" {
+		s += "\n" + e.Excerpt
+	}
+	return s
+}
+
+// frameRE matches a frame of the stack of a goja.Exception, i.e.
+// "\tat D (helpers.js:240:9(45))" or "\tat dnsconfig.js:3:1(12)".
+var frameRE = regexp.MustCompile(`^\tat (?:.* \()?(.+):(\d+):(\d+)\(\d+\)\)?$`)
+
+// locate turns an error of the javascript into an *Error at the innermost position in the user's
+// files. Other errors, and errors without such a position, are returned unchanged.
+func (l *loader) locate(err error) error {
+	switch e := err.(type) {
+	case *Error:
+		return e
+	case parser.ErrorList:
+		if len(e) == 0 {
+			return err
+		}
+		p := e[0].Position
+		return l.newError(p.Filename, p.Line, p.Column, "SyntaxError: "+e[0].Message)
+	case *goja.CompilerSyntaxError:
+		if e.File == nil {
+			return err
+		}
+		p := e.File.Position(e.Offset)
+		return l.newError(p.Filename, p.Line, p.Column, "SyntaxError: "+e.Message)
+	case *goja.Exception:
+		if o, ok := e.Value().(*goja.Object); ok {
+			// An error of a required file, passed on by load.
+			if v := o.Get("value"); v != nil {
+				if located, ok := v.Export().(*Error); ok {
+					return located
+				}
+			}
+		}
+		for _, frame := range strings.Split(e.String(), "\n") {
+			m := frameRE.FindStringSubmatch(frame)
+			if m == nil {
+				continue
+			}
+			file := m[1]
+			if file == "<eval>" {
+				file = ""
+			}
+			if _, ok := l.sources[file]; !ok {
+				continue // helpers.js, underscore.js or native code
+			}
+			line, _ := strconv.Atoi(m[2])
+			col, _ := strconv.Atoi(m[3])
+			return l.newError(file, line, col, e.Value().String())
+		}
+	}
+	return err
+}
+
+// newError returns an *Error with an excerpt of the source of file.
+func (l *loader) newError(file string, line, col int, msg string) *Error {
+	e := &Error{File: file, Line: line, Column: col, Message: msg}
+	if e.File == "" {
+		e.File = "<eval>"
+	}
+	lines := strings.Split(l.sources[file], "\n")
+	if line < 1 || line > len(lines) {
+		return e
+	}
+	src := strings.TrimRight(lines[line-1], "\r")
+	prefix := fmt.Sprintf("%5d | ", line)
+	e.Excerpt = prefix + src
+	if runes := []rune(src); col >= 1 && col <= len(runes)+1 {
+		// Keep the tabs, so that the caret lines up with the source.
+		indent := []rune{}
+		for _, r := range runes[:col-1] {
+			if r == '\t' {
+				indent = append(indent, '\t')
+			} else {
+				indent = append(indent, ' ')
+			}
+		}
+		e.Excerpt += "\n" + strings.Repeat(" ", len(prefix)-2) + "| " + string(indent) + "^"
+	}
+	return e
+}
@@ -0,0 +1,522 @@
+package js
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/StackExchange/dnscontrol/providers/bind"
+	"github.com/dop251/goja/ast"
+	"github.com/dop251/goja/parser"
+)
+
+// recordBuilders are the functions of helpers.js that make a record, and take its label first.
+// Runs of them are sorted by Format.
+var recordBuilders = map[string]bool{
+	"A": true, "AAAA": true, "ALIAS": true, "CAA": true, "CNAME": true, "MX": true,
+	"NS": true, "PTR": true, "R53_ALIAS": true, "SRV": true, "TLSA": true, "TXT": true,
+}
+
+// Format rewrites the source of dnsconfig.js in a canonical style:
+//
+//   - Strings are quoted with double quotes, unless the string holds more double than single quotes.
+//   - The arguments of D() and D_EXTEND() are written one per line, but for the name of the
+//     domain and its registrar.
+//   - Records are sorted by label and type, like in the zonefiles of BIND, and their arguments
+//     are aligned. Only consecutive records are sorted, so that the order of the records and the
+//     other arguments, i.e. DefaultTTL(), does not change. A blank line also stops the sorting.
+//
+// Comments are kept with the argument they precede, or follow on the same line.
+// Calls whose arguments can not be told apart, i.e. because of a spread, keep their layout.
+func Format(filename string, src []byte) ([]byte, error) {
+	prg, err := parser.ParseFile(nil, filename, src, 0)
+	if err != nil {
+		return nil, err
+	}
+	f := &formatter{src: string(src)}
+	var calls []*ast.CallExpression
+	seen := map[int]bool{}
+	walk(reflect.ValueOf(prg), func(n ast.Node) {
+		switch n := n.(type) {
+		case *ast.StringLiteral:
+			start := int(n.Idx) - 1
+			if q := requote(n.Literal, n.Value.String()); q != n.Literal && !seen[start] {
+				seen[start] = true
+				f.quotes = append(f.quotes, edit{start, start + len(n.Literal), q})
+			}
+		case *ast.CallExpression:
+			if id, ok := n.Callee.(*ast.Identifier); ok && (id.Name == "D" || id.Name == "D_EXTEND") {
+				calls = append(calls, n)
+			}
+		}
+	})
+	sort.Slice(f.quotes, func(i, j int) bool { return f.quotes[i].start < f.quotes[j].start })
+
+	edits := []edit{}
+	sort.Slice(calls, func(i, j int) bool { return calls[i].Idx0() < calls[j].Idx0() })
+	end := 0
+	for _, c := range calls {
+		start := int(c.Idx0()) - 1
+		if start < end {
+			continue // within the previous one
+		}
+		if text, ok := f.domain(c); ok {
+			end = int(c.Idx1()) - 1
+			edits = append(edits, edit{start, end, text})
+		}
+	}
+	for _, e := range f.quotes {
+		if !within(e, edits) {
+			edits = append(edits, e)
+		}
+	}
+	sort.Slice(edits, func(i, j int) bool { return edits[i].start < edits[j].start })
+	out := []byte(apply(f.src, 0, len(f.src), edits))
+
+	if _, err := parser.ParseFile(nil, filename, out, 0); err != nil {
+		return nil, fmt.Errorf("formatting %s produced invalid javascript: %s", filename, err)
+	}
+	return out, nil
+}
+
+// edit replaces src[start:end] with text.
+type edit struct {
+	start, end int
+	text       string
+}
+
+// apply returns src[start:end] with the edits within it applied. The edits must be sorted,
+// and not overlap.
+func apply(src string, start, end int, edits []edit) string {
+	b := &strings.Builder{}
+	for _, e := range edits[sort.Search(len(edits), func(i int) bool { return edits[i].start >= start }):] {
+		if e.end > end {
+			break
+		}
+		b.WriteString(src[start:e.start])
+		b.WriteString(e.text)
+		start = e.end
+	}
+	b.WriteString(src[start:end])
+	return b.String()
+}
+
+func within(e edit, edits []edit) bool {
+	for _, o := range edits {
+		if e.start >= o.start && e.end <= o.end {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	nodeType = reflect.TypeOf((*ast.Node)(nil)).Elem()
+	astPkg   = reflect.TypeOf(ast.Program{}).PkgPath()
+)
+
+// walk calls fn for every node of the syntax tree v.
+func walk(v reflect.Value, fn func(ast.Node)) {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			return
+		}
+		if v.Kind() == reflect.Ptr && v.Type().Implements(nodeType) {
+			fn(v.Interface().(ast.Node))
+		}
+		walk(v.Elem(), fn)
+	case reflect.Struct:
+		if v.Type().PkgPath() != astPkg {
+			return // i.e. the file.File of the program
+		}
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).PkgPath == "" {
+				walk(v.Field(i), fn)
+			}
+		}
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			walk(v.Index(i), fn)
+		}
+	}
+}
+
+// requote returns the string literal lit, of the string value, with the preferred quotes.
+func requote(lit, value string) string {
+	if len(lit) < 2 || (lit[0] != '"' && lit[0] != '\'') {
+		return lit // the name of a property
+	}
+	q := byte('"')
+	if strings.Count(value, `"`) > strings.Count(value, `'`) {
+		q = '\''
+	}
+	if lit[0] == q {
+		return lit
+	}
+	b := &strings.Builder{}
+	b.WriteByte(q)
+	body := lit[1 : len(lit)-1]
+	for i := 0; i < len(body); i++ {
+		switch c := body[i]; {
+		case c == '\\' && i+1 < len(body):
+			i++
+			if body[i] != lit[0] {
+				b.WriteByte('\\') // the old quote needs no escape any more
+			}
+			b.WriteByte(body[i])
+		case c == q:
+			b.WriteByte('\\')
+			b.WriteByte(c)
+		default:
+			b.WriteByte(c)
+		}
+	}
+	b.WriteByte(q)
+	return b.String()
+}
+
+type formatter struct {
+	src    string
+	quotes []edit // the requoted strings, in order
+}
+
+// arg is an argument of D(), as it is written by Format.
+type arg struct {
+	text     string   // the source, with the strings requoted
+	leading  []string // the comments on the lines before it
+	trailing string   // the comments after it, on the same line
+	blank    bool     // it follows a blank line
+
+	// For records:
+	builder string
+	label   string
+	args    []string // the arguments of the record builder
+}
+
+// text returns the source of the node, with the strings requoted.
+func (f *formatter) text(n ast.Node) string {
+	return apply(f.src, int(n.Idx0())-1, exprEnd(n), f.quotes)
+}
+
+// exprEnd returns the offset after the expression. Unlike Idx1, it is right for conditionals.
+func exprEnd(n ast.Node) int {
+	if c, ok := n.(*ast.ConditionalExpression); ok {
+		return exprEnd(c.Alternate)
+	}
+	return int(n.Idx1()) - 1
+}
+
+// gapItem is a comment or a comma between two arguments.
+type gapItem struct {
+	comment  string // "" for a comma
+	newlines int    // the newlines between it and the previous item, or the start of the gap
+}
+
+// scanGap splits the source between two arguments into comments and commas.
+// It returns false if there is anything else, i.e. the parenthesis of an argument.
+func scanGap(s string) (items []gapItem, newlines int, ok bool) {
+	for i := 0; i < len(s); {
+		switch {
+		case s[i] == '\n':
+			newlines++
+			i++
+		case s[i] == ' ' || s[i] == '\t' || s[i] == '\r':
+			i++
+		case s[i] == ',':
+			items = append(items, gapItem{"", newlines})
+			newlines = 0
+			i++
+		case strings.HasPrefix(s[i:], "//"):
+			end := strings.IndexByte(s[i:], '\n')
+			if end < 0 {
+				end = len(s) - i
+			}
+			items = append(items, gapItem{strings.TrimRight(s[i:i+end], " \t\r"), newlines})
+			newlines = 0
+			i += end
+		case strings.HasPrefix(s[i:], "/*"):
+			end := strings.Index(s[i+2:], "*/")
+			if end < 0 {
+				return nil, 0, false
+			}
+			items = append(items, gapItem{s[i : i+end+4], newlines})
+			newlines = 0
+			i += end + 4
+		default:
+			return nil, 0, false
+		}
+	}
+	return items, newlines, true
+}
+
+// split divides the items of a gap into the comments that follow the previous argument on its
+// line, and those that precede the next one. blank tells if a blank line precedes the latter.
+func split(items []gapItem, newlines int) (trailing string, leading []string, commas int, blank bool) {
+	onLine := true
+	first := true
+	trail := []string{}
+	for _, it := range items {
+		if it.newlines > 0 {
+			onLine = false
+		}
+		if it.comment == "" {
+			commas++
+			continue
+		}
+		if onLine {
+			trail = append(trail, it.comment)
+			continue
+		}
+		if first {
+			blank = it.newlines > 1
+			first = false
+		}
+		leading = append(leading, it.comment)
+	}
+	if first {
+		blank = newlines > 1
+	}
+	return strings.Join(trail, " "), leading, commas, blank
+}
+
+// args returns the arguments of a call, with their comments, and the comments after the last one.
+// It returns false if the arguments can not be told apart.
+func (f *formatter) args(c *ast.CallExpression) (args []*arg, dangling []string, ok bool) {
+	pos := int(c.LeftParenthesis) // after the parenthesis
+	for i, n := range c.ArgumentList {
+		start := int(n.Idx0()) - 1
+		if start < pos {
+			return nil, nil, false
+		}
+		items, newlines, ok := scanGap(f.src[pos:start])
+		if !ok {
+			return nil, nil, false
+		}
+		a := &arg{text: f.text(n)}
+		if i == 0 {
+			_, a.leading, _, a.blank = split(items, newlines)
+			if len(items) != len(a.leading) {
+				return nil, nil, false // a comma
+			}
+		} else {
+			var commas int
+			args[i-1].trailing, a.leading, commas, a.blank = split(items, newlines)
+			if commas != 1 {
+				return nil, nil, false
+			}
+		}
+		args = append(args, a)
+		pos = exprEnd(n)
+	}
+	rp := int(c.RightParenthesis) - 1
+	if pos > rp {
+		return nil, nil, false
+	}
+	items, newlines, ok := scanGap(f.src[pos:rp])
+	if !ok {
+		return nil, nil, false
+	}
+	trailing, dangling, commas, _ := split(items, newlines)
+	if commas > 1 || (commas == 1 && len(args) == 0) {
+		return nil, nil, false
+	}
+	if len(args) > 0 {
+		args[len(args)-1].trailing = trailing
+	} else if trailing != "" {
+		dangling = append([]string{trailing}, dangling...)
+	}
+	return args, dangling, true
+}
+
+// record fills in the fields of a record, if n is a call of a record builder with a literal
+// label, and arguments without comments.
+func (f *formatter) record(a *arg, n ast.Expression) {
+	c, ok := n.(*ast.CallExpression)
+	if !ok {
+		return
+	}
+	id, ok := c.Callee.(*ast.Identifier)
+	if !ok || !recordBuilders[id.Name.String()] || len(c.ArgumentList) == 0 {
+		return
+	}
+	label, ok := c.ArgumentList[0].(*ast.StringLiteral)
+	if !ok {
+		return
+	}
+	args, dangling, ok := f.args(c)
+	if !ok || len(dangling) > 0 {
+		return
+	}
+	for _, ra := range args {
+		if len(ra.leading) > 0 || ra.trailing != "" {
+			return
+		}
+		a.args = append(a.args, ra.text)
+	}
+	a.builder = id.Name.String()
+	a.label = strings.ToLower(label.Value.String())
+}
+
+// domain returns the call of D() or D_EXTEND() in the canonical style.
+func (f *formatter) domain(c *ast.CallExpression) (string, bool) {
+	args, dangling, ok := f.args(c)
+	if !ok || len(args) == 0 {
+		return "", false
+	}
+	header := 2 // the name and the registrar
+	if c.Callee.(*ast.Identifier).Name == "D_EXTEND" || len(args) < 2 {
+		header = 1
+	}
+	for i, a := range args[:header] {
+		if len(a.leading) > 0 || (a.trailing != "" && i < header-1) {
+			return "", false
+		}
+	}
+	body := args[header:]
+	for i, a := range body {
+		f.record(a, c.ArgumentList[header+i])
+	}
+	sortRecords(body)
+
+	// The indentation of the line of the call.
+	start := int(c.Idx0()) - 1
+	line := f.src[strings.LastIndexByte(f.src[:start], '\n')+1 : start]
+	indent := line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+
+	head := c.Callee.(*ast.Identifier).Name.String() + "("
+	for i, a := range args[:header] {
+		if i > 0 {
+			head += ", "
+		}
+		head += a.text
+	}
+	if len(body) == 0 && len(dangling) == 0 {
+		return head + ")" + comment(args[header-1].trailing), true
+	}
+	lines := []string{head + "," + comment(args[header-1].trailing)}
+	rows := alignRecords(body)
+	for i, a := range body {
+		if a.blank && i > 0 {
+			lines = append(lines, "")
+		}
+		for _, l := range a.leading {
+			lines = append(lines, indent+"\t"+l)
+		}
+		text := rows[i]
+		if i < len(body)-1 {
+			text += ","
+		}
+		lines = append(lines, indent+"\t"+text+comment(a.trailing))
+	}
+	for _, l := range dangling {
+		lines = append(lines, indent+"\t"+l)
+	}
+	lines = append(lines, indent+")")
+	return strings.Join(lines, "\n"), true
+}
+
+func comment(c string) string {
+	if c == "" {
+		return ""
+	}
+	return " " + c
+}
+
+// runs calls fn for every run of consecutive records, which a blank line ends.
+func runs(args []*arg, fn func(run []*arg)) {
+	for i := 0; i < len(args); {
+		j := i
+		for j < len(args) && args[j].builder != "" && (j == i || !args[j].blank) {
+			j++
+		}
+		if j == i {
+			i++
+			continue
+		}
+		fn(args[i:j])
+		i = j
+	}
+}
+
+// sortRecords sorts every run of records by label and type.
+func sortRecords(args []*arg) {
+	runs(args, func(run []*arg) {
+		blank := run[0].blank
+		run[0].blank = false
+		sort.SliceStable(run, func(i, j int) bool {
+			a, b := run[i], run[j]
+			if a.label != b.label {
+				return bind.ZoneLabelLess(a.label, b.label)
+			}
+			return bind.ZoneRrtypeLess(a.builder, b.builder)
+		})
+		run[0].blank = blank
+	})
+}
+
+// alignRecords returns the text of the arguments, with the arguments of every run of
+// records aligned in columns: the labels in all of them, and the other arguments in the
+// records of the same type. Records with arguments of several lines are not aligned.
+func alignRecords(args []*arg) []string {
+	rows := make([]string, len(args))
+	cells := make([][]string, len(args)) // "A(", `"@", `, `"1.2.3.4"`
+	for i, a := range args {
+		rows[i] = a.text
+		if a.builder == "" {
+			continue
+		}
+		rows[i] = a.builder + "(" + strings.Join(a.args, ", ") + ")"
+		if strings.Contains(rows[i], "\n") {
+			continue
+		}
+		cells[i] = []string{a.builder + "("}
+		for j, ra := range a.args {
+			if j < len(a.args)-1 {
+				ra += ", "
+			}
+			cells[i] = append(cells[i], ra)
+		}
+	}
+	for i := 0; i < len(args); {
+		// The run of records starting at i.
+		j := i
+		for j < len(args) && cells[j] != nil && (j == i || !args[j].blank) {
+			j++
+		}
+		if j == i {
+			i++
+			continue
+		}
+		// The widths of the columns: the first two are shared by all the types.
+		widths := map[string][]int{}
+		column := func(builder string, c int) *int {
+			key := builder
+			if c < 2 {
+				key = ""
+			}
+			for len(widths[key]) <= c {
+				widths[key] = append(widths[key], 0)
+			}
+			return &widths[key][c]
+		}
+		for k, row := range cells[i:j] {
+			for c, cell := range row[:len(row)-1] {
+				if w := column(args[i+k].builder, c); utf8.RuneCountInString(cell) > *w {
+					*w = utf8.RuneCountInString(cell)
+				}
+			}
+		}
+		for k, row := range cells[i:j] {
+			text := ""
+			for c, cell := range row[:len(row)-1] {
+				text += cell + strings.Repeat(" ", *column(args[i+k].builder, c)-utf8.RuneCountInString(cell))
+			}
+			rows[i+k] = text + row[len(row)-1] + ")"
+		}
+		i = j
+	}
+	return rows
+}
@@ -0,0 +1,96 @@
+package js
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"testing"
+	"unicode"
+)
+
+func TestFormat(t *testing.T) {
+	tests := []struct{ desc, in, out string }{
+		{"quotes",
+			`var a = 'x', b = "y", c = 'say "hi"', d = 'it\'s', e = {'k': 1, l: 2};`,
+			`var a = "x", b = "y", c = 'say "hi"', d = "it's", e = {"k": 1, l: 2};`},
+		{"layout",
+			"D('foo.com', REG, DnsProvider(DSP), A('@', '1.2.3.4'));",
+			"D(\"foo.com\", REG,\n\tDnsProvider(DSP),\n\tA(\"@\", \"1.2.3.4\")\n);"},
+		{"no records",
+			"  D('foo.com', REG)",
+			"  D(\"foo.com\", REG)"},
+		{"sorted and aligned",
+			"D('foo.com', REG,\n  CNAME('www', '@'),\n  MX('@', 10, 'mx'),\n  A('@', '1.2.3.4', TTL(60)),\n  A('mail', '1.2.3.5'),\n)",
+			"D(\"foo.com\", REG,\n\tA(    \"@\",    \"1.2.3.4\", TTL(60)),\n\tMX(   \"@\",    10, \"mx\"),\n\tA(    \"mail\", \"1.2.3.5\"),\n\tCNAME(\"www\",  \"@\")\n)"},
+		{"runs",
+			"D('foo.com', REG,\n  A('b', '1.2.3.4'),\n  A('a', '1.2.3.4'),\n  DefaultTTL(60),\n  A('d', '1.2.3.4'),\n\n  A('c', '1.2.3.4'))",
+			"D(\"foo.com\", REG,\n\tA(\"a\", \"1.2.3.4\"),\n\tA(\"b\", \"1.2.3.4\"),\n\tDefaultTTL(60),\n\tA(\"d\", \"1.2.3.4\"),\n\n\tA(\"c\", \"1.2.3.4\")\n)"},
+		{"comments",
+			"D('foo.com', REG, // registrar\n  // web\n  A('www', '1.2.3.4'), /* the apex */ A('@', '1.2.3.5') // last\n  // end\n)",
+			"D(\"foo.com\", REG, // registrar\n\tA(\"@\",   \"1.2.3.5\"), // last\n\t// web\n\tA(\"www\", \"1.2.3.4\") /* the apex */\n\t// end\n)"},
+		{"spread",
+			"D('foo.com', REG, ...records, A('b', '1'), A('a', '1'))",
+			"D(\"foo.com\", REG, ...records, A(\"b\", \"1\"), A(\"a\", \"1\"))"},
+	}
+	for _, tst := range tests {
+		t.Run(tst.desc, func(t *testing.T) {
+			out, err := Format("test.js", []byte(tst.in))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(out) != tst.out {
+				t.Errorf("expected:\n%s\ngot:\n%s", tst.out, out)
+			}
+		})
+	}
+}
+
+// TestFormatParsedFiles checks that formatting does not change the configuration, but for
+// the order of the records, and that formatting twice changes nothing.
+func TestFormatParsedFiles(t *testing.T) {
+	files, err := ioutil.ReadDir(testDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ir := func(script string) string {
+		conf, err := ExecuteJavascript(script, true)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, d := range conf.Domains {
+			sort.SliceStable(d.Records, func(i, j int) bool {
+				a, _ := json.Marshal(d.Records[i])
+				b, _ := json.Marshal(d.Records[j])
+				return string(a) < string(b)
+			})
+		}
+		j, _ := json.Marshal(conf)
+		return string(j)
+	}
+	for _, f := range files {
+		if filepath.Ext(f.Name()) != ".js" || !unicode.IsNumber(rune(f.Name()[0])) {
+			continue
+		}
+		t.Run(f.Name(), func(t *testing.T) {
+			content, err := ioutil.ReadFile(filepath.Join(testDir, f.Name()))
+			if err != nil {
+				t.Fatal(err)
+			}
+			out, err := Format(f.Name(), content)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if ir(string(content)) != ir(string(out)) {
+				t.Errorf("the configuration changed:\n%s", out)
+			}
+			again, err := Format(f.Name(), out)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(again) != string(out) {
+				t.Errorf("formatting twice changed:\n%s\ninto:\n%s", out, again)
+			}
+		})
+	}
+}
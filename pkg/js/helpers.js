@@ -45,7 +45,7 @@ function newDomain(name, registrar) {
         dnsProviders: {},
         defaultTTL: 0,
         nameservers: [],
-        ignored_labels: [],
+        ignored: [],
     };
 }
 
@@ -87,6 +87,65 @@ function D(name, registrar) {
     conf.domain_names.push(name);
 }
 
+// D_EXTEND(name): Add records and mods to a domain declared by D() before.
+// If name is a subdomain of the domain, the names of the records, and their
+// relative targets, are relative to the subdomain.
+function D_EXTEND(name) {
+    var domain = null;
+    var lname = name.toLowerCase();
+    for (var i = 0; i < conf.domains.length; i++) {
+        var dname = conf.domains[i].name.toLowerCase();
+        var matches =
+            lname === dname ||
+            lname.slice(-dname.length - 1) === '.' + dname;
+        // The most specific domain wins.
+        if (matches && (!domain || dname.length > domain.name.length)) {
+            domain = conf.domains[i];
+        }
+    }
+    if (!domain) {
+        throw 'D_EXTEND(' +
+            JSON.stringify(name) +
+            '): ' +
+            name +
+            ' is not a domain, nor a subdomain of one, declared with D() before';
+    }
+    var sub = '';
+    if (lname !== domain.name.toLowerCase()) {
+        sub = name.slice(0, name.length - domain.name.length - 1);
+    }
+    var first = domain.records.length;
+    var firstIgnored = domain.ignored.length;
+    for (var i = 1; i < arguments.length; i++) {
+        processDargs(arguments[i], domain);
+    }
+    if (!sub) {
+        return;
+    }
+    // The labels of IGNORE are relative to the subdomain too, unless they are full names.
+    var suffix = '.' + domain.name.toLowerCase();
+    for (var i = firstIgnored; i < domain.ignored.length; i++) {
+        var rule = domain.ignored[i];
+        var label = rule.label.toLowerCase();
+        if (label === '@') {
+            rule.label = sub;
+        } else if (label !== domain.name.toLowerCase() && label.slice(-suffix.length) !== suffix) {
+            rule.label = rule.label + '.' + sub;
+        }
+    }
+    for (var i = first; i < domain.records.length; i++) {
+        var r = domain.records[i];
+        r.name = r.name === '@' ? sub : r.name + '.' + sub;
+        if (_.contains(['ALIAS', 'CNAME', 'MX', 'NS', 'SRV'], r.type)) {
+            if (r.target === '@') {
+                r.target = sub;
+            } else if (r.target.slice(-1) !== '.') {
+                r.target = r.target + '.' + sub;
+            }
+        }
+    }
+}
+
 // DEFAULTS provides a set of default arguments to apply to all future domains.
 // Each call to DEFAULTS will clear any previous values set.
 function DEFAULTS() {
@@ -111,7 +170,7 @@ function stringToDuration(v) {
     if (matches == null) {
         throw v + ' is not a valid duration string';
     }
-    unit = 's';
+    var unit = 's';
     if (matches[2]) {
         unit = matches[2];
     }
@@ -144,6 +203,26 @@ function makeCAAFlag(value) {
 // CAA_CRITICAL: Critical CAA flag
 var CAA_CRITICAL = makeCAAFlag(1 << 0);
 
+// ONLY_PROVIDERS(name...): Send a DNS record only to the named DNS providers of its domain.
+function ONLY_PROVIDERS() {
+    return providersModifier('ONLY_PROVIDERS', 'only_providers', arguments);
+}
+
+// EXCEPT_PROVIDERS(name...): Send a DNS record to all the DNS providers of its domain but the named ones.
+function EXCEPT_PROVIDERS() {
+    return providersModifier('EXCEPT_PROVIDERS', 'except_providers', arguments);
+}
+
+function providersModifier(fn, key, args) {
+    var names = _.flatten(_.toArray(args));
+    if (names.length === 0 || !_.all(names, _.isString)) {
+        throw fn + ' takes the names of DNS providers, i.e. ' + fn + '("bind")';
+    }
+    return function(r) {
+        r.meta[key] = names.join(',');
+    };
+}
+
 // DnsProvider("providerName", 0)
 // nsCount of 0 means don't use or register any nameservers.
 // nsCount not provider means use all.
@@ -347,10 +426,31 @@ function format_tt(transform_table) {
     return lines.join(' ; ');
 }
 
-// IGNORE(name)
-function IGNORE(name) {
+// IGNORE(labelPattern, rTypes, targetPattern)
+// rTypes is a comma separated list or an array of record types. "*" (the default) means all types.
+// targetPattern "*" (the default) means any target.
+function IGNORE(labelPattern, rTypes, targetPattern) {
+    var types = [];
+    if (_.isArray(rTypes)) {
+        types = rTypes;
+    } else if (rTypes !== undefined && rTypes !== '' && rTypes !== '*') {
+        types = rTypes.split(',');
+    }
+    types = _.map(types, function(t) {
+        return t.trim().toUpperCase();
+    });
+    if (targetPattern === undefined || targetPattern === '*') {
+        targetPattern = '';
+    }
     return function (d) {
-        d.ignored_labels.push(name);
+        var rule = { label: labelPattern };
+        if (types.length) {
+            rule.types = types;
+        }
+        if (targetPattern) {
+            rule.target = targetPattern;
+        }
+        d.ignored.push(rule);
     };
 }
 
@@ -368,11 +468,19 @@ var IMPORT_TRANSFORM = recordBuilder('IMPORT_TRANSFORM', {
 // PURGE()
 function PURGE(d) {
     d.KeepUnknown = false;
+    d.PurgeOwned = false;
 }
 
 // NO_PURGE()
 function NO_PURGE(d) {
     d.KeepUnknown = true;
+    d.PurgeOwned = false;
+}
+
+// PURGE_OWNED()
+function PURGE_OWNED(d) {
+    d.KeepUnknown = false;
+    d.PurgeOwned = true;
 }
 
 /**
@@ -621,9 +729,9 @@ function SPF_BUILDER(value) {
         value.raw = '_rawspf';
     }
 
-    r = []; // The list of records to return.
-    p = {}; // The metaparameters to set on the main TXT record.
-    rawspf = value.parts.join(' '); // The unaltered SPF settings.
+    var r = []; // The list of records to return.
+    var p = {}; // The metaparameters to set on the main TXT record.
+    var rawspf = value.parts.join(' '); // The unaltered SPF settings.
 
     // If flattening is requested, generate a TXT record with the raw SPF settings.
     if (value.flatten && value.flatten.length > 0) {
@@ -644,9 +752,90 @@ function SPF_BUILDER(value) {
 
 // Split a DKIM string if it is >254 bytes.
 function DKIM(arr) {
-    chunkSize = 255;
+    var chunkSize = 255;
     var R = [];
     for (var i = 0, len = arr.length; i < len; i += chunkSize)
         R.push(arr.slice(i, i + chunkSize));
     return R;
 }
+
+// GENERATE(range, builder, args...) calls the record builder (i.e. A, PTR)
+// once for every number in range, like $GENERATE in a BIND zonefile.
+// range is "start-stop" or "start-stop/step". In the string args, $ is
+// replaced by the number and ${offset,width,base} by the number plus offset,
+// zero padded to width, in base d, o, x, X, n or N. \$ is a literal $.
+function GENERATE(range, builder) {
+    var m = /^(\d+)-(\d+)(?:\/(\d+))?$/.exec(range);
+    if (!m) {
+        throw 'GENERATE range must be "start-stop" or "start-stop/step", not ' +
+            JSON.stringify(range);
+    }
+    var start = parseInt(m[1], 10);
+    var stop = parseInt(m[2], 10);
+    var step = m[3] === undefined ? 1 : parseInt(m[3], 10);
+    if (stop < start || step < 1) {
+        throw 'GENERATE range ' + range + ' is empty';
+    }
+    if (!_.isFunction(builder)) {
+        throw 'GENERATE needs a record builder, i.e. A or PTR, after the range';
+    }
+    var args = Array.prototype.slice.call(arguments, 2);
+    var r = [];
+    for (var i = start; i <= stop; i += step) {
+        r.push(
+            builder.apply(
+                null,
+                _.map(args, function(a) {
+                    return _.isString(a) ? generateExpand(a, i) : a;
+                })
+            )
+        );
+    }
+    return r;
+}
+
+// generateExpand replaces the $ of a GENERATE pattern with value.
+function generateExpand(pattern, value) {
+    return pattern.replace(/\\\$|\$\{([^}]*)\}|\$/g, function(match, mods) {
+        if (match === '\\$') {
+            return '$';
+        }
+        if (mods === undefined) {
+            return String(value);
+        }
+        var m = /^\s*(-?\d+)\s*(?:,\s*(\d+)\s*(?:,\s*([doxXnN])\s*)?)?$/.exec(mods);
+        if (!m) {
+            throw 'GENERATE modifier ${' + mods + '} must be ${offset,width,base}';
+        }
+        var v = value + parseInt(m[1], 10);
+        var width = m[2] === undefined ? 0 : parseInt(m[2], 10);
+        var base = m[3] || 'd';
+        if (v < 0) {
+            throw 'GENERATE modifier ${' + mods + '} makes ' + value + ' negative';
+        }
+        if (base === 'n' || base === 'N') {
+            // Nibbles, lowest first, separated by dots (for ip6.arpa).
+            // The width includes the dots.
+            var s = '';
+            do {
+                var nibble = (v % 16).toString(16);
+                s += base === 'N' ? nibble.toUpperCase() : nibble;
+                v = Math.floor(v / 16);
+                width--;
+                if (width > 0 || v !== 0) {
+                    s += '.';
+                    width--;
+                }
+            } while (v !== 0 || width > 0);
+            return s;
+        }
+        var s = v.toString({ d: 10, o: 8, x: 16, X: 16 }[base]);
+        if (base === 'X') {
+            s = s.toUpperCase();
+        }
+        while (s.length < width) {
+            s = '0' + s;
+        }
+        return s;
+    });
+}
@@ -0,0 +1,75 @@
+package js
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/dop251/goja"
+)
+
+// env implements ENV(name[, default]). It returns the value of an environment variable that
+// allowEnv allows. If the variable is not set, it returns the default, or fails if there is none.
+func (l *loader) env(call goja.FunctionCall) goja.Value {
+	if len(call.Arguments) < 1 || len(call.Arguments) > 2 {
+		throw(l.vm, "ENV takes one or two arguments")
+	}
+	name := call.Argument(0).String()
+	if !l.envAllowed(name) {
+		throw(l.vm, "ENV: "+name+" is not allowed. Allow it with -allow-env or DNSCONTROL_ALLOW_ENV")
+	}
+	if v, ok := os.LookupEnv(name); ok {
+		return l.vm.ToValue(v)
+	}
+	if len(call.Arguments) < 2 {
+		throw(l.vm, "ENV: "+name+" is required, but not set")
+	}
+	return call.Argument(1)
+}
+
+// envAllowed tells whether allowEnv lists the variable, by name or by a pattern like DNS_*.
+func (l *loader) envAllowed(name string) bool {
+	for _, pattern := range l.allowEnv {
+		if ok, _ := path.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// readFile implements READ_FILE(path). It returns the content of a file, relative to the file
+// that is running like require. The file must be in the directory of the configuration, or below.
+func (l *loader) readFile(call goja.FunctionCall) goja.Value {
+	if len(call.Arguments) != 1 {
+		throw(l.vm, "READ_FILE takes exactly one argument")
+	}
+	file := l.resolve(call.Argument(0).String())
+	// Symbolic links are followed before the check, so that a link can not lead out of the directory.
+	root, err := realPath(l.dirs[0])
+	if err != nil {
+		throw(l.vm, err.Error())
+	}
+	abs, err := realPath(file)
+	if err != nil {
+		throw(l.vm, err.Error())
+	}
+	if rel, err := filepath.Rel(root, abs); err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		throw(l.vm, "READ_FILE: "+file+" is not in the directory of the configuration, "+root)
+	}
+	data, err := ioutil.ReadFile(abs)
+	if err != nil {
+		throw(l.vm, err.Error())
+	}
+	return l.vm.ToValue(string(data))
+}
+
+// realPath returns the absolute path of a file, without symbolic links.
+func realPath(file string) (string, error) {
+	abs, err := filepath.Abs(file)
+	if err != nil {
+		return "", err
+	}
+	return filepath.EvalSymlinks(abs)
+}
@@ -8,41 +8,60 @@ import (
 	"github.com/StackExchange/dnscontrol/models"
 	"github.com/StackExchange/dnscontrol/pkg/transform"
 
-	"github.com/robertkrimen/otto"
-	// load underscore js into vm by default
-
-	_ "github.com/robertkrimen/otto/underscore" // required by otto
+	"github.com/dop251/goja"
 )
 
 // ExecuteJavascript accepts a javascript string and runs it, returning the resulting dnsConfig.
+// The script may use ES2015+ syntax. require() resolves relative paths against the current directory.
+// ENV() can not read any environment variable.
 func ExecuteJavascript(script string, devMode bool) (*models.DNSConfig, error) {
-	vm := otto.New()
+	return execute("", script, devMode, nil)
+}
+
+// ExecuteJavascriptFile is like ExecuteJavascript, but runs the script in file.
+// require() and READ_FILE() resolve relative paths against the directory of the file.
+// ENV() can read the environment variables allowEnv lists, by name or by a pattern like DNS_*.
+func ExecuteJavascriptFile(file string, devMode bool, allowEnv []string) (*models.DNSConfig, error) {
+	script, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	return execute(file, string(script), devMode, allowEnv)
+}
+
+func execute(file, script string, devMode bool, allowEnv []string) (*models.DNSConfig, error) {
+	vm := goja.New()
+	l := newLoader(vm, file)
+	l.allowEnv = allowEnv
 
-	vm.Set("require", require)
-	vm.Set("REV", reverse)
+	vm.Set("require", l.require)
+	vm.Set("require_glob", l.requireGlob)
+	vm.Set("ENV", l.env)
+	vm.Set("READ_FILE", l.readFile)
+	vm.Set("REV", func(call goja.FunctionCall) goja.Value { return reverse(vm, call) })
 
+	// helpers.js uses the underscore library
+	if _, err := vm.RunScript("underscore.js", _escFSMustString(devMode, "/underscore.js")); err != nil {
+		return nil, err
+	}
 	helperJs := GetHelpers(devMode)
 	// run helper script to prime vm and initialize variables
-	if _, err := vm.Run(helperJs); err != nil {
+	if _, err := vm.RunScript("helpers.js", helperJs); err != nil {
 		return nil, err
 	}
 
 	// run user script
-	if _, err := vm.Run(script); err != nil {
-		return nil, err
+	if _, err := l.run(file, script); err != nil {
+		return nil, l.locate(err)
 	}
 
 	// export conf as string and unmarshal
-	value, err := vm.Run(`JSON.stringify(conf)`)
-	if err != nil {
-		return nil, err
-	}
-	str, err := value.ToString()
+	value, err := vm.RunString(`JSON.stringify(conf)`)
 	if err != nil {
 		return nil, err
 	}
 	conf := &models.DNSConfig{}
-	if err = json.Unmarshal([]byte(str), conf); err != nil {
+	if err = json.Unmarshal([]byte(value.String()), conf); err != nil {
 		return nil, err
 	}
 	return conf, nil
@@ -53,36 +72,23 @@ func GetHelpers(devMode bool) string {
 	return _escFSMustString(devMode, "/helpers.js")
 }
 
-func require(call otto.FunctionCall) otto.Value {
-	if len(call.ArgumentList) != 1 {
-		throw(call.Otto, "require takes exactly one argument")
-	}
-	file := call.Argument(0).String()
-	fmt.Printf("requiring: %s\n", file)
-	data, err := ioutil.ReadFile(file)
+// throw raises a javascript Error with the message str.
+func throw(vm *goja.Runtime, str string) {
+	e, err := vm.New(vm.Get("Error"), vm.ToValue(str))
 	if err != nil {
-		throw(call.Otto, err.Error())
+		panic(vm.NewGoError(fmt.Errorf("%s", str)))
 	}
-	_, err = call.Otto.Run(string(data))
-	if err != nil {
-		throw(call.Otto, err.Error())
-	}
-	return otto.TrueValue()
-}
-
-func throw(vm *otto.Otto, str string) {
-	panic(vm.MakeCustomError("Error", str))
+	panic(e)
 }
 
-func reverse(call otto.FunctionCall) otto.Value {
-	if len(call.ArgumentList) != 1 {
-		throw(call.Otto, "REV takes exactly one argument")
+func reverse(vm *goja.Runtime, call goja.FunctionCall) goja.Value {
+	if len(call.Arguments) != 1 {
+		throw(vm, "REV takes exactly one argument")
 	}
 	dom := call.Argument(0).String()
 	rev, err := transform.ReverseDomainName(dom)
 	if err != nil {
-		throw(call.Otto, err.Error())
+		throw(vm, err.Error())
 	}
-	v, _ := otto.ToValue(rev)
-	return v
+	return vm.ToValue(rev)
 }
@@ -1,13 +1,17 @@
 package js
 
 import (
+	"bytes"
 	"encoding/json"
+	"fmt"
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"unicode"
 
+	"github.com/StackExchange/dnscontrol/models"
 	"github.com/tdewolff/minify"
 	minjson "github.com/tdewolff/minify/json"
 )
@@ -56,7 +60,19 @@ func TestParsedFiles(t *testing.T) {
 				t.Fatal(err)
 			}
 
-			expectedJSON, err := m.String("json", string(expectedData))
+			// The expected file is read into the models first, so that it may use older forms,
+			// i.e. "ignored_labels", which must keep reading the same.
+			expected := &models.DNSConfig{}
+			dec := json.NewDecoder(bytes.NewReader(expectedData))
+			dec.DisallowUnknownFields()
+			if err := dec.Decode(expected); err != nil {
+				t.Fatal(err)
+			}
+			expectedData, err = json.MarshalIndent(expected, "", "  ")
+			if err != nil {
+				t.Fatal(err)
+			}
+			expectedJSON, err := m.Bytes("json", expectedData)
 			if err != nil {
 				t.Fatal(err)
 			}
@@ -69,6 +85,30 @@ func TestParsedFiles(t *testing.T) {
 	}
 }
 
+// BenchmarkExecuteJavascript measures how fast configurations are evaluated, running all parse tests.
+func BenchmarkExecuteJavascript(b *testing.B) {
+	files, err := filepath.Glob(filepath.Join(testDir, "[0-9]*.js"))
+	if err != nil {
+		b.Fatal(err)
+	}
+	scripts := make([]string, len(files))
+	for i, f := range files {
+		content, err := ioutil.ReadFile(f)
+		if err != nil {
+			b.Fatal(err)
+		}
+		scripts[i] = string(content)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, script := range scripts {
+			if _, err := ExecuteJavascript(script, false); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
 func TestErrors(t *testing.T) {
 	tests := []struct{ desc, text string }{
 		{"old dsp style", `D("foo.com","reg","dsp")`},
@@ -78,6 +118,14 @@ func TestErrors(t *testing.T) {
 		{"CF_TEMP_REDIRECT With comma", `D("foo.com","reg",CF_TEMP_REDIRECT("foo.com","baa,a"))`},
 		{"Bad cidr", `D(reverse("foo.com"), "reg")`},
 		{"Dup domains", `D("example.org", "reg"); D("example.org", "reg")`},
+		{"D_EXTEND undeclared", `D("foo.com", "reg"); D_EXTEND("bar.com", A("@", "1.2.3.4"))`},
+		{"D_EXTEND suffix only", `D("foo.com", "reg"); D_EXTEND("barfoo.com")`},
+		{"GENERATE bad range", `D("foo.com", "reg", GENERATE("5-1", A, "h$", "1.2.3.$"))`},
+		{"GENERATE bad modifier", `D("foo.com", "reg", GENERATE("1-5", A, "h${1,2,q}", "1.2.3.$"))`},
+		{"GENERATE no builder", `D("foo.com", "reg", GENERATE("1-5", "h$", "1.2.3.$"))`},
+		{"ONLY_PROVIDERS no names", `D("foo.com", "reg", A("@", "1.2.3.4", ONLY_PROVIDERS()))`},
+		{"EXCEPT_PROVIDERS not a name", `D("foo.com", "reg", A("@", "1.2.3.4", EXCEPT_PROVIDERS(5)))`},
+		{"Require missing file", `require("pkg/js/parse_tests/missing.js")`},
 	}
 	for _, tst := range tests {
 		t.Run(tst.desc, func(t *testing.T) {
@@ -88,3 +136,160 @@ func TestErrors(t *testing.T) {
 
 	}
 }
+
+func TestRequire(t *testing.T) {
+	dir, err := ioutil.TempDir("", "require")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	files := map[string]string{
+		"dnsconfig.js": `
+			var hosts = require("data/hosts.json");
+			var records = [];
+			require_glob("teams/");
+			require("./teams/a.js"); // already loaded
+			D("foo.com", "none", records);`,
+		"data/hosts.json":   `{"www": "1.2.3.4"}`,
+		"lib/common.js":     `var loads = (typeof loads === "undefined") ? 1 : loads + 1;`,
+		"teams/a.js":        `require("../lib/common.js"); records.push(A("www", hosts.www));`,
+		"teams/sub/b.js":    `require("../../lib/common.js"); records.push(A("loads", "10.0.0." + loads));`,
+		"teams/sub/skip.md": `not javascript`,
+	}
+	for name, content := range files {
+		fn := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(fn), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(fn, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	conf, err := ExecuteJavascriptFile(filepath.Join(dir, "dnsconfig.js"), true, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	recs := conf.Domains[0].Records
+	if len(recs) != 2 || recs[0].Name != "www" || recs[0].Target != "1.2.3.4" || recs[1].Target != "10.0.0.1" {
+		t.Errorf("expected every file to be loaded exactly once, got %v", recs)
+	}
+
+	// Errors are located in the required file.
+	bad := filepath.Join(dir, "lib", "bad.js")
+	if err := ioutil.WriteFile(bad, []byte("var ok = 1;\nA(\"www\");"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	_, err = ExecuteJavascript(fmt.Sprintf("require(%q);", bad), true)
+	if e, ok := err.(*Error); !ok || e.File != bad || e.Line != 2 {
+		t.Errorf("expected an error at %s:2, got %v", bad, err)
+	}
+
+	// Invalid JSON is reported in the requiring file, with the name of the JSON file.
+	badJSON := filepath.Join(dir, "data", "bad.json")
+	if err := ioutil.WriteFile(badJSON, []byte(`{"www": }`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	_, err = ExecuteJavascript(fmt.Sprintf("var ok = 1;\nrequire(%q);", badJSON), true)
+	e, ok := err.(*Error)
+	if !ok || e.Line != 2 || !strings.Contains(e.Message, badJSON+": SyntaxError: ") || strings.Contains(e.Message, "native") {
+		t.Errorf("expected a plain error about %s at line 2, got %v", badJSON, err)
+	}
+}
+
+func TestHostAccess(t *testing.T) {
+	dir, err := ioutil.TempDir("", "dnsconfig")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	os.Setenv("DNSCONTROL_TEST_IP", "1.2.3.4")
+	defer os.Unsetenv("DNSCONTROL_TEST_IP")
+	os.Unsetenv("DNSCONTROL_TEST_UNSET")
+	files := map[string]string{
+		"keys/dkim.txt": "v=DKIM1; p=abc\n",
+		"secret.txt":    "outside",
+	}
+	for name, content := range files {
+		fn := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(fn), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(fn, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	links := map[string]string{
+		"dkim-link.txt": "keys/dkim.txt",
+		"keys/parent":   "..",
+	}
+	for name, target := range links {
+		if err := os.Symlink(target, filepath.Join(dir, name)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	allow := []string{"DNSCONTROL_TEST_*"}
+	tests := []struct {
+		desc, text string
+		configDir  string // where dnsconfig.js is, in dir
+		target     string // of the record; "" if an error is expected
+	}{
+		{"ENV", `ENV("DNSCONTROL_TEST_IP")`, "", "1.2.3.4"},
+		{"ENV default", `ENV("DNSCONTROL_TEST_UNSET", "5.6.7.8")`, "", "5.6.7.8"},
+		{"ENV required", `ENV("DNSCONTROL_TEST_UNSET")`, "", ""},
+		{"ENV not allowed", `ENV("HOME", "5.6.7.8")`, "", ""},
+		{"READ_FILE", `READ_FILE("keys/dkim.txt").trim()`, "", "v=DKIM1; p=abc"},
+		{"READ_FILE outside", `READ_FILE("../secret.txt")`, "keys", ""},
+		{"READ_FILE missing", `READ_FILE("missing.txt")`, "keys", ""},
+		{"READ_FILE link", `READ_FILE("dkim-link.txt").trim()`, "", "v=DKIM1; p=abc"},
+		{"READ_FILE link outside", `READ_FILE("parent/secret.txt")`, "keys", ""},
+	}
+	for _, tst := range tests {
+		t.Run(tst.desc, func(t *testing.T) {
+			main := filepath.Join(dir, tst.configDir, "dnsconfig.js")
+			script := fmt.Sprintf(`D("foo.com", "reg", TXT("@", %s));`, tst.text)
+			if err := ioutil.WriteFile(main, []byte(script), 0644); err != nil {
+				t.Fatal(err)
+			}
+			conf, err := ExecuteJavascriptFile(main, true, allow)
+			if tst.target == "" {
+				if _, ok := err.(*Error); !ok {
+					t.Errorf("expected a located error, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := conf.Domains[0].Records[0].Target; got != tst.target {
+				t.Errorf("expected %q, got %q", tst.target, got)
+			}
+		})
+	}
+}
+
+func TestErrorLocation(t *testing.T) {
+	tests := []struct {
+		desc, text string
+		line, col  int
+		msg        string
+	}{
+		{"helper validation", "D(\"foo.com\", \"reg\",\n  MX(\"@\", \"test.\")\n);", 2, 5, "MX record requires 3 arguments"},
+		{"thrown by user", "var a = 1;\nthrow 'oops';", 2, 1, "oops"},
+		{"syntax error", "var a = 1;\nvar b = ;", 2, 9, "SyntaxError: Unexpected token ;"},
+	}
+	for _, tst := range tests {
+		t.Run(tst.desc, func(t *testing.T) {
+			_, err := ExecuteJavascript(tst.text, true)
+			e, ok := err.(*Error)
+			if !ok {
+				t.Fatalf("expected a located error, got %v", err)
+			}
+			if e.File != "<eval>" || e.Line != tst.line || e.Column != tst.col || !strings.HasPrefix(e.Message, tst.msg) {
+				t.Errorf("expected %q at %d:%d, got %s", tst.msg, tst.line, tst.col, e)
+			}
+			if !strings.Contains(e.Excerpt, strings.Split(tst.text, "\n")[tst.line-1]) {
+				t.Errorf("expected the excerpt to show line %d, got:\n%s", tst.line, e.Excerpt)
+			}
+		})
+	}
+}
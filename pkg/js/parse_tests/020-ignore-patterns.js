@@ -0,0 +1,6 @@
+D("foo.com", "none"
+  , IGNORE("*.k8s")
+  , IGNORE("_acme-challenge.**", "TXT")
+  , IGNORE("@", "a, aaaa", "10.0.0.*")
+  , IGNORE("www", ["CNAME"], "**.cdn.example.net.")
+);
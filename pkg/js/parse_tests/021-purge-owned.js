@@ -0,0 +1,4 @@
+D("foo.com", "none", PURGE_OWNED
+  , A("@", "1.2.3.4")
+);
+D("bar.com", "none", PURGE_OWNED, NO_PURGE);
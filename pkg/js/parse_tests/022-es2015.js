@@ -0,0 +1,7 @@
+const hosts = { www: 10, mail: 20 };
+const [first, ...rest] = ["foo.com", "bar.com"];
+let records = Object.keys(hosts).map(h => A(h, `10.0.0.${hosts[h]}`));
+if (["www", "mail"].includes("mail")) {
+  records.push(TXT("@", `${first} and ${rest.length} more`));
+}
+D(first, "none", ...records);
@@ -0,0 +1,13 @@
+D("foo.com", "none", A("@", "1.2.3.4"));
+D("dev.foo.com", "none");
+D_EXTEND("FOO.com", A("www", "1.2.3.5"));
+D_EXTEND("team.foo.com",
+  A("@", "1.2.3.6"),
+  CNAME("alias", "www"),
+  CNAME("apex", "@"),
+  MX("@", 10, "mail.bar.com."),
+  IGNORE("@", "TXT"),
+  IGNORE("**.k8s"),
+  IGNORE("legacy.team.foo.com")
+);
+D_EXTEND("api.dev.foo.com", A("v1", "1.2.3.7"));
@@ -0,0 +1,9 @@
+D("foo.com", "none",
+  SPF_BUILDER({
+    label: "@",
+    overflow: "_spf%d",
+    raw: "_rawspf",
+    parts: ["v=spf1", "include:_spf.google.com", "~all"],
+    flatten: ["_spf.google.com"]
+  })
+);
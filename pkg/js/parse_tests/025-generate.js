@@ -0,0 +1,9 @@
+D("foo.com", "none",
+  GENERATE("1-3", A, "host-$", "10.0.0.$", TTL(300)),
+  GENERATE("0-4/2", CNAME, "c${0,3,d}", "host-${1}"),
+  GENERATE("10-11", MX, "mx$", 10, "mail${0,0,x}.bar.com."),
+  GENERATE("254-255", TXT, "t${0,2,X}", "cost \\$$")
+);
+D("0.0.0.0.8.b.d.0.1.0.0.2.ip6.arpa", "none",
+  GENERATE("1-2", PTR, "${0,7,n}", "host-$.foo.com.")
+);
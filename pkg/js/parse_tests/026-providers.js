@@ -0,0 +1,8 @@
+var CF = NewDnsProvider("Cloudflare", "CLOUDFLAREAPI");
+var BIND = NewDnsProvider("bind", "BIND");
+D("foo.com", "none", DnsProvider(CF), DnsProvider(BIND),
+  A("@", "1.2.3.4"),
+  CF_REDIRECT("foo.com/*", "https://www.foo.com/$1", ONLY_PROVIDERS(CF)),
+  TXT("_test", "secondary only", ONLY_PROVIDERS("bind")),
+  A("www", "1.2.3.5", EXCEPT_PROVIDERS([BIND], CF), TTL(300))
+);
@@ -0,0 +1,139 @@
+package js
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/dop251/goja"
+	"github.com/dop251/goja/parser"
+)
+
+// loader implements require and require_glob, and ENV and READ_FILE (see host.go).
+// Relative paths are resolved against the directory of the file that is running.
+// Every file is loaded at most once.
+type loader struct {
+	vm      *goja.Runtime
+	dirs    []string              // the directories of the files being run, innermost last
+	loaded  map[string]goja.Value // the result of every file loaded, by absolute path
+	sources map[string]string     // the source of every javascript file run, by name
+
+	allowEnv []string // the environment variables ENV may read, or patterns like DNS_*
+}
+
+func newLoader(vm *goja.Runtime, mainFile string) *loader {
+	l := &loader{vm: vm, dirs: []string{"."}, loaded: map[string]goja.Value{}, sources: map[string]string{}}
+	if mainFile != "" {
+		l.dirs[0] = filepath.Dir(mainFile)
+		if abs, err := filepath.Abs(mainFile); err == nil {
+			l.loaded[abs] = vm.ToValue(true)
+		}
+	}
+	return l
+}
+
+// resolve returns the path of file, relative to the file that is running.
+func (l *loader) resolve(file string) string {
+	if filepath.IsAbs(file) {
+		return file
+	}
+	return filepath.Join(l.dirs[len(l.dirs)-1], file)
+}
+
+// require runs a javascript file in the global scope, and returns true.
+// For a .json file, it returns the parsed data instead.
+func (l *loader) require(call goja.FunctionCall) goja.Value {
+	if len(call.Arguments) != 1 {
+		throw(l.vm, "require takes exactly one argument")
+	}
+	return l.load(l.resolve(call.Argument(0).String()))
+}
+
+// requireGlob requires every .js file in a directory, in lexical order.
+// Subdirectories are included, unless the optional second argument is false.
+func (l *loader) requireGlob(call goja.FunctionCall) goja.Value {
+	if len(call.Arguments) < 1 || len(call.Arguments) > 2 {
+		throw(l.vm, "require_glob takes one or two arguments")
+	}
+	dir := l.resolve(call.Argument(0).String())
+	recursive := len(call.Arguments) < 2 || call.Argument(1).ToBoolean()
+	files := []string{}
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			if path != dir && !recursive {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if strings.ToLower(filepath.Ext(path)) == ".js" {
+			files = append(files, path)
+		}
+		return nil
+	})
+	if err != nil {
+		throw(l.vm, err.Error())
+	}
+	for _, f := range files {
+		l.load(f)
+	}
+	return goja.Undefined()
+}
+
+func (l *loader) load(file string) goja.Value {
+	abs, err := filepath.Abs(file)
+	if err != nil {
+		throw(l.vm, err.Error())
+	}
+	if v, ok := l.loaded[abs]; ok {
+		return v
+	}
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		throw(l.vm, err.Error())
+	}
+	if strings.ToLower(filepath.Ext(file)) == ".json" {
+		parse, _ := goja.AssertFunction(l.vm.Get("JSON").ToObject(l.vm).Get("parse"))
+		v, err := parse(goja.Undefined(), l.vm.ToValue(string(data)))
+		if err != nil {
+			msg := err.Error()
+			if ex, ok := err.(*goja.Exception); ok {
+				msg = ex.Value().String() // without the stack, which is inside JSON.parse
+			}
+			throw(l.vm, fmt.Sprintf("%s: %s", file, msg))
+		}
+		l.loaded[abs] = v
+		return v
+	}
+
+	// Mark the file before running it, so that files requiring each other do not loop.
+	l.loaded[abs] = l.vm.ToValue(true)
+	l.dirs = append(l.dirs, filepath.Dir(file))
+	defer func() { l.dirs = l.dirs[:len(l.dirs)-1] }()
+	if _, err = l.run(file, string(data)); err != nil {
+		if ex, ok := err.(*goja.Exception); ok {
+			panic(ex) // keeps the stack, so that the error is located in file
+		}
+		panic(l.vm.NewGoError(l.locate(err)))
+	}
+	return l.loaded[abs]
+}
+
+// run runs a script of the user in the global scope.
+func (l *loader) run(name, src string) (goja.Value, error) {
+	l.sources[name] = src
+	// Parse it first, since the syntax errors of RunScript do not have a position.
+	ast, err := parser.ParseFile(nil, name, src, 0)
+	if err != nil {
+		return nil, err
+	}
+	prg, err := goja.CompileAST(ast, false)
+	if err != nil {
+		return nil, err
+	}
+	return l.vm.RunProgram(prg)
+}
@@ -192,93 +192,323 @@ var _escData = map[string]*_escFile{
 
 	"/helpers.js": {
 		local:   "pkg/js/helpers.js",
-		size:    18525,
+		size:    25289,
 		modtime: 0,
 		compressed: `
-H4sIAAAAAAAC/+x863PjNpL4d/8VnanfhuKYQ7/i2S052t8qfuRc8atkTXb2dDoVTEISxhTJA0BpnMTz
-t1/hRQJ8yE4qyX65fBiLQKPR3Wh0NxqNeAXDwDglEfdOdnbWiEKUpXMYwM87AAAULwjjFFHWh8k0kG1x
-ymY5zdYkxk5ztkIkbTTMUrTCuvVZTxHjOSoSPqQLBgOYTE92duZFGnGSpUBSwglKyE+452siHIq6qNpC
-WSt1zyeKyAYpzxYxN3gzMnP1BCMB8KccB7DCHBnyyBx6otW3KBTfMBiAdz28+TC88tRkz/JfIQGKF4Ij
-EDj7UGHuW/j78l9DqBBCWDEe5gVb9ihe+Cd6oXhBU4mpwcJZyu60VF5kIpurWQeC+OzhE464B19/DR7J
-Z1GWrjFlJEuZByR1xov/xHfowsEA5hldIT7jvNfS79cFE7P8twjGWXklm5jlL8kmxZszqRdaLKV4/VL9
-5ciKRYuspjb2q5+BI5Q+/Pxsw0cZjZuqe1dprg2uNXQ8vurDfuBQwjBdNzSdLNKM4niWoAecuApv857T
-LMKMnSG6YL1VoDeIYXxvT6wbYBQtYZXFZE4wDYSSEA6EAQrDsITTGPsQoSQRABvClxqfAUKUoqe+mVSI
-oKCMrHHyZCCUromlpQssp0l5JqUXI45KHZ2FhF3oGXsr31G/nuZB6xTghOFy0FBQUBshWOwJrfsk1dnu
-Ev+5Ipp8mpZSOinhntvmupW81Cabhfgzx2msqQwFawGsXGotC7Kk2Qa8fw5HN5c33/f1zOViKAtTpKzI
-84xyHPfBg12HfLOda80eKJ1vDtCEqX2imHve2dnbgzO1P6rt0YdTihHHgODs5l4jDOEDw8CXGHJE0Qpz
-TBkgZvQdUBoL8llYKeFZ18aTpkBxPNiyTRWZ5TISGMD+CRD41rbrYYLTBV+eANndtRfEWV4LfkLqC/3c
-nOZQTYPooljhlHdOIuBXMKgAJ2R60k7CqnVWoVPKxFnuNCRpjD/fzqVAfPhqMIB3B35De0Qv7IIntmyM
-owRRLJaAilVCKWRphB3PZM1jjKhNUJMMCSNpODGqcn4x/HA1vgdtjRkgYJhDNjdLUokCeAYoz5Mn+SNJ
-YF7wgmLjq0OB71xYIGlYeFYh35AkgSjBiAJKnyCneE2ygsEaJQVmYkJbyfSoMp5o+vwuLXpxeW01k8Kw
-19l3d9F4fNVb+324x1zukvH4Sk6q9pDaJRbZCtxyz8Ky3HNK0kVv7ViWNQxkDJcuxtlZQZG0jWtHi7Qj
-M8h71B5PQ84TGMD6pM1RtGC2NukK8WiJhRzXofzd2/vv3n/Fu35vwlbLeJM+Tf+////2NDGCjXLEANIi
-SZpauzYqm2YckFhTEkOsZ9fkOGpbpITDADzmNWaZHE7tCTRk1emEHzAQlovhy5SX4w/MKgpmCxmasD4c
-BLDqw/v9AJZ9OHq/v2+CkWLixd4UBlCES3gLh9+UzRvdHMNb+GvZmlqtR/tl85Pd/P5YUwBvB1BMBA9T
-J7BZl5uvDBUcRTMbzyicbFMm29ol9tg/SOtiZ+uEVWTTqXwr9IhPh8OLBC16cnPXIrNKoeX2cbRabagI
-oXmCFvDLQFkHe5q9PTgdDmeno8vx5enwSng1wkmEEtEMYpg8rtgwUnsqmg7g229h3z9R4rfi7DcmGr1B
-K/wmgH1fQKTsNCtSaQ33YYVRyiDOUo+DOIZlVHs2rKyaFeGF9mCxLQx2jUQMR0liL2cj5tfDWwJ+g1jG
-/EUa4zlJcezZwixB4N3Br1lhK6qdCDKEWmtctYUYKjJJHuiVu9aRDgvD0JfrMISB7vuuIIngzBt6WvbD
-4fA1GIbDNiTDYYXn6nJ4rxBxRBeYb0EmQFuwiWaDbnR8NLNQgsGpDjNdmMtRTexllxdoSYvYoQ+TiSdm
-8AKoNuw0gIknZvICZUURx6Pjo2FCEBs/5Vj1S4rccfrEwClKmTi+9csFBr3RAjltUIajrGXnyehDRj7M
-iiktADW1AVFfFVAtmNZj6PHRDAkG/Hq0XgfQrE9L/E+5RUIj3m5DIc29QtOvkBhbb4X/wc6zteD/eXtz
-3vspS/GMxH61JRtd7aYMXOdcF8M2CdjM60kk//r3S9zXGTco+gaBZtdi3LXWbUrmmm3BzVe2S5GdrvIo
-aaCE4RZLM/GGXgBqywbgnd4Mr8/lD/V9/VH8O/44Fn/uxiPx5/7uQv4Z/Sj+3AxF87SMoDV5XynLVjoF
-YwIWgQTo3qunbRZFUVMepce3Z7c9npCV34dLDmyZFUkMDxhQCpjSjAq5yHlM2LMvvMHB4d/CV21xtGg2
-SnSv3da/566OEOJoUe3qxQv73vbKikAz/U2xesC0hUpHpZq+ntWdfbU9pb68zrxL0JallRqn0d2NR69D
-djceNVEJRdSI7kc/KkQ5JRkl/CnYYLJY8kAc7l/Efj/6sYld6bvjI0p5tWqS1Wuo0BBqIRwIRV53v6C7
-u7fN6aj+P0dHGV0bFg2c+W6DVcwaSPXVijOjJZT4/Ss8nqWjKlIoGFrgABhOcMQzGqhDC0kXKnSIMOVk
-TiLEsVSB8dV9ix0Srb9ZCSQF3WtoKOuGsCn+lbogrKbDC6QYxwwQvFHwb8qz+Z+oNjxhSErFQMmPVjAj
-HQNpvluBbUGZAXbbb9Cj6j5Fy/SWqgzo51rYYTnjzz788gtUydLPZVZn/HH8Ojs3/jhu0ULpjl8XrRpl
-qJH9R/suYYK5SoxhfaplwDckwn0bBsCInjAJOieUcT2gDviZG0QamKQxWZO4QImZInTH3NyOz/twORfQ
-FAOi2MrWHehBQXn4YyaSyNLkCVAUYcY6iQiALwsGhEOcYSbOnCvExVFzs0QcNoJrMRVJDYs12v4j2+A1
-pgE8PElQki4aElB0BzJ7vxJUYgYPKHrcIBrXKIuyVY44eSCJsMGbJU4ltgSnPXlX4MNgAAcyZ9wjKcep
-WGqUJE8+PFCMHmvoHmj2iFNLMhjR5ElwowTP8ULnjzhm3JJ7LcVh7aeuA8b2U4sNWCnAACYW9PR1x5C2
-iSb705fnaiWscVK5/liLOF7a29cfm1tbxtt/VIzx744SVp9ziueY4jTCL4YJv8IkR0scPQ7pgvXkL2aI
-jTGL7IMSqi4v4Fs1ynw3s6ZicOdthU5nOygauWx5NlMgEzKVs0/ItLENqulknvZd6YjBg10gdvI2yijF
-EZfZDq+hitq33Lwy3XLTkg25KRMtIiq/Px/9eO4E5Nbpuw6gUzFd+cRaIsvOxck8f+2GWuLq67/w7Lcm
-M6ub8FJxZxw9JNi6dR3LQ+4kyTYyy7wki2UfDgNI8eY7xHAfjoSflN3fmO5j2X1514f306lBJK9P3xzA
-FziEL3AEX07gG/gCx/AF4Au8f1MmtROS4pfuQWr0brvsIjkM6vDOnZcAkuTCAEgeyp9u1kc21dXOvcdV
-IHUYmanUqGfhCuUKLqiWlbQNsWsEitVhnPEe8U8aYM9++Ckjac8LvFpvqxW3iTFoFdm1wTvNX1pGYsVL
-KYmPhpxE44uSkkAdstJTlNIS3/9WeWmCLIlJ8l8nM2GZBjApqcrDJNv4AVgNYsv45X7SO8dST7kddHVN
-ttEcwBfw/LarDQWtgU7AKyPmy+9vbkcq02AZIbu1K/tXszxuOYdz4+rkzy+v725H49l4NLy5v7gdXSsb
-k8igR+3C8npZmtM6fNO41iGaMXxjCk8G8Woa9ZvzxHXwv6fr9v7hveCHFSlNz4450uRXVkqmSisbrfx4
-nUO/OaG8O1XQPGmc5u8+jL4/71k6oBrKVY7DHzDOP6SPabZJBQEq8al94+2sMb5s60TBaaExvH27A2/h
-HzHOKY4Qx/EOvN2rUC0wL/1sT0mdcUS5c8GbxZ3eQQKXN+WdgYUs+jC3487FuLUBBJBN9EhKV5W5PCiV
-lLzI2hL4WUW7z6rfgm2DyXLOQjn1dLI/haGJV4QW2fBGLgN3yMEUbnN1/DAZ7oxuG1fqFZhKparSwSl+
-MHf+8NaIaowecdcdiw+IWRUJMEyfqk2iSiIesIVLTEhwDA94rg6RhJV7LbTy0KuCI65OvguyxqlNVqdo
-BDNGd1rYrOjimcSscLrq59obldcS2I3uiN/SN+mLYtb7+VlBBJZ2vS6jIOxOFTH/NuOjIysFqQS+RGts
-MYsSilH8ZERfHylwm4UClOqaN7mnrJIpff/adszrPrLYjl9Z2q1n2TaDaZykPe6VfvvVR2PLcVvr4WhT
-y5p0rkZbrFoCd5kjpzQri2FQDZGBagOwWXeYxX5XYLTKYlOM0BIStdcJbkG3tweqXJZXWis3lT7utw6S
-BTBZbBmir7+28npOV+fMmhkLiVPL6+A4acXw3Npa1kFavlgucbe82gnUFZLno9HtqA/G/TkFkl4Lym59
-VEGrVoD6gbB+zpGVQrGuIfv52T3fVBZBl7fbK1MvKoNvK3fTcrw3OMthV4SJPVaOabAoY/kqhOd49UIU
-L0AamSUljSZyHdNDPahXyyH98W5jlGesJsX/UxCKWaP41Bh8WwytiCoP2mvD4YqpBYEfwm2aPMHWwdsI
-2GCKgRXKxHv1dJwQqJ3q2HF2cpIIg19Os7PNkNWl0WrItGacCZ9BpFe1NMM5dxtodc/cVZFqKWmF00jj
-73DQpknCJxZpFRsJBEY+rcb0Kwf75GDaUgfwatVqqJi3BcideH+6FV+Z2NKcyRwOIklj1bfZFVnmW9qK
-SZ0Aceawrqq7daY0Ke0606Isr6lfta/buytYa1RtzZVVL1zkYgxaltR6z9Hoaz6XKEfxpO8UDbogzzXH
-3QxTW8KJk+aQ0qmV4NXquUPd2vlQl7mbhzktEYCWm+qzJOuc5F84sqE4VqedXmyqyNzKMnGOsvKJZA7V
-jVUqA8MAEGPFCgPJBTqKGQvLIIPoe59aLNkSRjbiRidktJ86RY4WtK1+27MaN6dqtXfrgUnOOw9lXI3S
-wm5/3xLjiMQYHhDDMYjjjCDVwL8rjznmpQtTL12q4404oIkv52paDr1tfd0iYJ0XLhLWlL1cXsD1xwqz
-WjK5jobPHSvYY60PW9y4+EVPslLBcLtL2PL0pnqCQ3HUfmjY+jbmN0e7kvnOOPcVUe6qK77dGt02I1s7
-qq097fmVYJ0xb5SlLEtwmGSLXisv1WOh685XQl7Q7mH1W6H2Xq93/0jynKSLr3yvAfFCbvZ5p90+uo/z
-KI5M0ovkUL0QLL0MgznNVrDkPO/v7TGOosdsjek8yTZhlK320N7fDvaP//rN/t7B4cH79/sC05ogM+AT
-WiMWUZLzED1kBZdjEvJAEX3ae0hIrvUuXPKVla+968WZkw4THi3OeMjyhPCeF5ooeG8Pcoo5J5i+Uylb
-m7ue/G83nuxPfXgLh8fvfdgF0XAw9Wsth42Wo6lfe7dokuPFyr4vTIuVrOEuS7hb6io9r/64yLr9Fvha
-xqTFqvFMU9l9+IugsyUzeCRszt+l6Xn3zikkFzTCNeLLcJ5kGZVE70luKzVysMMueKEHuxC3ZA3jsmQz
-yYp4niCKQVawYtZXt9yYywdIXN6NCxqtKgyjkqre72J2N7r9+K/Z7cWFrH+NSpSznGafn/rgZfO5B88n
-YrXvRBPEhKGHBMd1FDedGFIXAU7bxl98uLrqwjAvksTBsTtCJFkUaYVL9GD6zjwZtEXQ36lo189Csvlc
-OcOUk/L1FfSslyN+3yVPv6jqlNRMj6sk1jJr2py0a5qbF2eRUlWK8OF+fHsdwN3o9sfLs/MR3N+dn15e
-XJ7C6Pz0dnQG43/dnd9bm2lmqpalCl0I/CMcEyq81O9buywHlIXHXuD5crvqumPN+uj87HJ0ftpSRmV1
-bim6YFlBI5kH7ebLqbKIMeMklaebV436cy9wFDvCBgTCBqhLnYpi97pFi3B8fn23XY4OxP8Js1OYH0ZX
-Tfl9GF0Jr6f7j/YPWkGO9g8M1MWotZJaNpcF0HcXs+8+XF6JHcvRI2ZVflyarBxRzvowVq+LOYNMVsmJ
-cSZE7vEMHjB8yoTrU6G5B54vzaG8PVXDz27u1Wf5Fi6nZIXok4UrhF5lXP7hybdbFG368E9ZmNfbLEm0
-VFh8FZ5mVGb0ixQlHFMcg4lfLDqNDZYUyQBCUcTxKk8Qx+o1aBwTfdlkHk4rviL54jq2KZuxfP6XWJE3
-TxDnOO3DEBLC1INb9Y5Wj9cAwj9Uxs8Se4uxUwZLyfuXX8D6rFKXh81SJM9ezDLhhzgkGDEOh4ATLDMM
-jVhEz6gFaydcy2Zb0RsDKdo0h1G0EYNmFG1YPi+HKsusErSy8maJS8lZkle2Wx2Kc5XqNdDCsVr3NkIP
-sHRs8lwnnOj447i6TRPTSRJMykeLUlcPeH6JuNIiV21MpHk5N6tJ0oU4EAohY8ZxHMACp5iqp/nV7NZB
-FW1qSI0IFUkarzhIOQ1VCnDfeUNfDhjU4FtKP6iK/ccfx71yZQItk6q6wmLSBPiCRZbjSFjAONBxjtpB
-gok6D2aYS6gEL8k0MPVZv98uPnfJ9aLW2ZJ6ahgLIPdrdwrUBK33kiQEZz9cXpsS2vL/sfH3w+Nv4OGJ
-Y+d/mPDD5XUP0fKFWLQs0sd78pOw/4fHx9VT5VFnRVcAiVwuRKmTK0xwKn7sDiqkVfZ/ZHKDNGQJiXCP
-BLLUrwJ1j3MjweL/BgAA//+VzTYAXUgAAA==
+H4sIAAAAAAAC/+x8W3PbONLou39FJ5UdiglDWc4kuyWPNqPxZY7POrZLVmazR9G6IBGSMKFIHgCU7Ik9
+v/0r3EiAF9mZ2svL54dEBBqN7kaj0Wg04OUMA+OUzLl3uLe3QRTmabKAAXzdAwCgeEkYp4iyPkymgSyL
+EnaT0XRDIuwUp2tEklrBTYLWWJc+6C4ivEB5zId0yWAAk+nh3t4iT+acpAmQhHCCYvIb7viaCIeiNqp2
+UNZI3cOh/K9OyoNFzAXejkxfHcFIAPwuwwGsMUeGPLKAjij1LQrFNwwG4H0YXnwcnnuqswf5r5AAxUvB
+EQicfSgx9y38ffmvIVQIISwZD7OcrToUL/1DPVA8p4nEVGPhOGFXWiqPMpEuZDEMBPHp7Fc85x589x14
+JLuZp8kGU0bShHlAEqe9+BPfoQsHA1ikdI34Deedhnq/KpiIZX9EMM7IK9lELHtMNgneHku90GIpxOvD
+V7tlyaJFVl0b++XPwBFKH74+2PDzlEZ11b0qNdcG1xo6Hp/3YT9wKGGYbmqaTpZJSnHkaLrNdEbTOWbs
+GNEl66wDPTMMx92uGDDAaL6CdRqRBcE0ALIAwoEwQGEYFnAaYx/mKI4FwJbwlcZngBCl6K5vOhW855SR
+DY7vDIRSMjGmdIllNwlPpdgixFGhnDchYae6x87ad/Suo3nQygQ4ZrhoNBQUVFoIFjtC3X6VemxXiT9X
+RJNfpwE4PZQqW+nrUvJS6ewmxLccJ5GmMhSsBbB2qS3B+YqmW/D+PhxdnF383Nc9F4OhTEuesDzLUsrF
+QHvwyiHfzONKsQdK2esNNGFqgijmHvb2ul04VhOjnBd9OKIYcQwIji+uNcIQPjIMfIUhQxStMceUAWJG
+0QElkSCfhaUSHrfNOGkDFMeDHfPzcM8ZRgID2D8EAj/YBj2McbLkq0Mgr17ZA+IMrwU/IdWBfqh3c6C6
+QXSZr3HCWzsR8GsYlIATMj1sJmHd2KvQKWXbrHU0JEmEby8XUiA+PBsM4HXPr2mPqIVX4AFhEOF5jCgW
+Q0DFKKEE0mSOnSXJ6sdYT5ugOhkSRtJwaFTl5uTT+ORCDazfh2EU1RQAeArIDG9B1+wOjjs+zPAipTgU
+uM4WigNhcIDlM90iXUglU1+B/C2pMRW6u0D2x1eYUIGM4hhxssHAEV1iLqoptkpT2bboxVZSh6VGBc3j
++LAojgWcKEVrHPL0PN1ieoQY7uxQV0fyO1Qp0rht+AmZhm19FSqI+HyFGQycCa8pHQw03vv7enXIYjLH
+ndcSQpMGr6Hny3Ze6MEr1brssNuF8QrDOmUcWIbnZEHmRlhbwWABKZTbkPbdd9B5pqHu78Hp76+2bdKF
+ftVcF8NREU7NWBfz6pm76Flmtxjzqo38v9eXF6HwlJMlWdxplagYWL9uWtVMdMGAMEhSXkyFAJKUVjU9
+TXBQzhG5spazpOZPsnwGA/C8w4JFNcTCQNgSdDTFZl8hsMZ9PwB34OsDIbWhSsmCUMah6FXPSaPaLtiZ
+clVKaO27ONDOrOk90fo69tU2wa2m9hnLZ76zxxCunw2ltTtGMxxLm3P288Xl6GS3OQGepgHkSYwZEzV3
+EnyRx7GyXaE1hIsFuYViarWNWoNUbGHqVbBRnk2GheYxrg2AM3mkaRNMw0BCh/KjzehI3VPQwkz86FVn
+a4kCBkJO1iwtnSlVv1N7hd1QlGhDpQRojIRsrIp2UmB9vNKid4lqcwSk0B1pV3S9Udq1meGImobazJsf
+SoTwXtAEfVPcSKjyQedpwoX560y84fnZ8NoLwDu6GH44ET8+fBL/XsjC69Ev3jQAGsqta1VEAhkN1YrZ
+NpCKYAPjElMZTgNmhqqnhscLH0Na/GxkuRwZe6S0P3JyOvx4Pr4GvS2UrgTmkC6Mi1haEemXZFl8J3/E
+MSxynlPjaTDpk5yIHZHc6PC0RL4lcQzzGCMKKLmDjOINSXMGGxTnmIkObX9CtyoCG/XgQ5ub8KjBs91e
+6ZzZRs93vfrx+Lyz8ftwjbk0VuPxuexU+fRKLy2yFbgVJxA7nWu5EnY2juZsYABqiRynxzlFonln45ha
+vaM2yDvUbk9DzsWc3Bw2bVwbMFs+WeHowCaUvzvdf3Y+R6/8zoStV9E2uZu+9190/XKFLFooP67uDGzg
+lb1Yb1BMIoh075qc2kqcJ4TDADzm1XqaHEztTjRkWemgggFkiDJ8lvCifc+MpOxIxklYH3oBrPvwbj+A
+VR/evNvfN5GRfOJF3hQGkIcreAkH3xfFW10cwUv4c1GaWKVv9oviO7v43VtNAbwcQD4RPEydKMum2BAU
+cQtH2czkM0pXevT2TLHb/ps0L3KmT1iGWVoVcI2+4KPh8DRGy46c4JUwUanUcgq53oScVHOEFjFawv1A
+WQi7m24XjobDm6PR2fjsaHgudtqEkzmKRTGIZjJ2asPAwKGpBz/8APv+ocR1eXH+j5ur0eUvZ8cno2vp
+r4ZhKAciiZypDmmiLJ/ZUEWysginQboAwll9lCpdVKRRtP+gIxcdz20gViHRdRm484LS0BUby5NPRydX
+4yeyou234GQHDzDLucVtmmA7NlHr8HHGqk0Ea/h2jjO+izk7JFfBuEgC+ILvZBNmmzm14R3ATbiIEec4
+6dyEPFUxLglrGTgJa3x1sYjvi/3Vs5sQxbGqDKCcUX7dAC4SaQE5+oKZu912hBsACXEotj66Ref5jCTR
+c9/7BssvomKTL/huqrchLPw1JUnHCzy/MkvscPZzQ8QFWuPnAez7AiJhR2meyLV+H9YYJWLgE49DzjCk
+VMeRsFqzrUBqaDcWRt9g10hEcxTHtqGqhdZ184a4uq5R7lSeRHhBEhw5HlABAq9732K7SirYRJAhhahw
+VYQ3VGSSLNBTxqgcE1NKWpghDHTdTzmJBWfe0NNWZTgcPgXDcNiEZDgs8QjfVCFSDt4OZAK0AZsoNuhG
+b9/cWCjB4FRnBm2Yi1Z17EWVF2hJi8nVh8nEEz149sSZBjDxRE9eoHwExPHo7ZthTBAb32VY1UuK3HY6
+Ps8pSpg4JekXAwx6CVHzPyiCv6xhTdE7BgEYuuEYDVA40xJEfVW3DUXoWrehb9/cIMFAbWdQBdCsTwv8
+dxmubel2o5COjELTL5EYL8Zy7YO9B2vA/9/lxUnntzTBNyTyyylZq2pepME1QFUx7JKAzbzuRPKvfz/G
+fZVxg6JvENT3NO5y0aRkrkMiAxq2syQr6+ENWKCY4QZLM/GGYg2TU9beQR4Nh+VGcvxpLP67Go/Ef9dX
+p2ZfKTaZQ1E8LeLVmrxnyrIV7o4xActAArTP1aMmi6KoKQ6uxpfHlx0ek7XfhzMObJXmcQQzDCgBTGlK
+hVxkP8ap34eUQu/gL+GTpjha1gsluqdO63/lrJ4jxNGynNXLR+a97W8qAk33F/l6hmkDlY5K1b1YVnVj
+y+kp9eVp5l2CNgyt1DiN7mo8ehqyq/GojkoookZ0PfpFIcooSSnhd8EWk+WKB+Io7VHs16Nf6tiVvjtr
+RCGvRk2yag0VGkINhAOhyGuvF3S31zYtOqr+P6OjjG4MiwbOfDfBKmYNpPpqxJnSAkr8/oYVz9JRqQeQ
+M7TEATAc4zlPaaC24yRZKtdhjikXpxiIY6kC4/PrBjskSv+wEkgK2sfQUNYOYVP8jboA3a7LCyQYRwwQ
+PFfwz4s9y39QbXjMkJSKgZIfjWBGOgbSfDcC24IyDeyyP6BHZdqSluklVXux24rbYS3Gt77YhpWpCbfF
+Vnf8afw0Ozf+NG7QQrkcP81bNcpQIfvfvXYJE8zVMTTW8RoGfEvmuG/DABjRE7XvVIdKqkEV8JYbRBqY
+JBHZkChHsekidNtcXI5P+nAmD40pBkSxdTbe042Ccn9rPAkZJ0FzcbDUSoQ4ls4ZEA5RipnYc67FNp3C
+doU4bAXXoiuSGBYrtP2fdIs3mAbiVFyAkmRZk4CiOxCdkLWgEjOYofmXLaJRhbJ5us4QJzMSCxu8XeFE
+Yotx0pGZOeIwF3rywLxDEo4TMdQoju98mFGMvlTQzWj6BVvxCsCIxndAFFaBYKmjoxwzXjnvtaaANZ/a
+Nhi7dy02YKkAA5hY0NOnbUOaOprsTx/vq5Gw2k7lw6eKx/HY3P7wqT61pb/97/Ix/ttewvo2o3iBKU7m
++FE34RtM8nyF51/EgUhH/mKG2Aizub1RQmWqkEjIkLD6ux4SE41bc4NMToeNonZSI/dmCmRCprJ3cURT
+nQZldzIG97pYiGWcjdhHE/OUUjznMtrhHbach108Mdxy0RANuSgCLcIrvz4Z/XLiOOTW7rsKoEMxbZHy
+SiDLjsXJU6xKIqjE1df/w4PfGKYvE04Lxb3haBZjK8dxLDe5kzjdyvOTFVmu+nAQQIK3PyGG+/BGrJOy
++ntT/VZWn1314d10ahDJZMXnPfgdDuB3eAO/H8L38Du8hd8Bfod3z8vkIJLgx075KvTuOkgmGQyq8LVT
+e0kuDIBkofzpRn1kUdPBb+maKJAqjPgzqG/CNcoUXFAOK2lqYqfi5uuDKOUd4h/WwB78auR3pxW3iTFo
+Fdm7z4ktGYkRL6QkPmpyEoWPSkoCtchKd1FIS3z/V+WlCbIkJsl/msyEZRrApKAqC+N06wdgFYgp4xfz
+Sc8cSz3ldFBznKZbzQH8Dp7fdGinoDXQIXiFx6xScFS6yJV0tJIAqAiIMWMudLE8GFA1Kq9wnq7XCBgW
+OascRxATxiGlgBKVtAzpojhVEq1CeP7yOXSsM0xfHwvIEycJIvpwem1vk9yBcQALy/UN3FhHQrJry7K4
+iqma+9XLCaKBqqolTatimaBRnFKIZBur3POqBS+99h5ClsWE20c5ezaQmhRccVlMCd4QreQhp2Td8UOe
+fswyN/fowTr4codg4DByfw/16ir5LkCRV/fQHEiOWnKqvqr8pD7YA2qHtc3hUJO7USQqGTHxcrDcGVnj
+uBmNcZwc0CZ8UZE2pqZnHuPqEdzZh6vL0fhmPBpeXJ9ejj6olTaWrr9ai4o8O+lUVOHrLkYVor6TrXXh
+ya2s6kb95jx23dx/pQPr/eg94o0qUmpA8mTTq6zV8sCg9FRk+xqHfr1DmR+joHlci2ldfRz9fNKx3DFV
+UChoFP4N4+xj8iVJtwkM7PB/FF7ldIkvt2KSFDXad7y8qWEuylqRc5o/AbdEcnP594uT4xrhuvgPkK/6
+Fj28fLkHL+HHCGcUizBetAcvu2U/S8wLH7ijdIFxRLmTWpRGrZ6bBC5ytFqdfoGiyMtyUrIsiyKAbKJH
+5eoDMzVRJC/ylgV8VTvRB1VvwTbBpBlnoex6OtmfwtDsJYRu2/BGLgO3SW8Kl5kKDZjTp5TualdoO5g7
+O2WOnZN2Z7LN4KUR1Rh9wW3nnz4gVrYPYZjcFXVMJePNsIVLdEhwpLOmga8IKyxAaJ0RrXOOOJZBjCXZ
+4MQmq1U0ghmjOw1slnTp1BqF01U/1wqqmLPAbnRH/JZLpHYfWOfrg4IILO16WrRPWMOiyR80iXrXoyCV
+wFdog0tgQDHFKLozoq+2FLjNQAFK9O0vOaesy0N6sW4KwbSHE2zfR9n/nXGmJjNuHFi73RN96ieHrSyn
+2hoPR5saxqR1NJr2kQVwmzmynZV1GsGgbCI3kTXA+g28NPLbNi3rNNJ0N21Xmm/M7UDX7YK6McpLrZWT
+SofiGhsJ/Os0sgzRd99ZMXenqrVnzUwJ6V5ndXAcNmJ4aCwtbgRaHoIc4nZ5NROoL62cjEaXoz6YhdG5
+Kug1oGzXR+NJNwZrqm6uzE+NdPby14fKxaPCIugb3vbIVNOZ4YdyuWnxhQXOotk5kXdMijY1FuWWotxe
+c7x+ZIctQGpRXyWNOnK934bqhlsNh5B65cqP+POM1aT4/+eEYgZeA1RVDI2ICjlApwmHK6YGBH4Il+Is
+Y2fjXQRsMcXAcmXivcO9ukDtjcWeM5NjcUJXdrO3y5BVpdFoyLRmHIs1g4jxtjXDiYkZaJUD0nY301LS
+Emd5Ia3XpEliTcyT0jcSCIx8Go3pMwf7pDdtyNF5smrVVMzbAeR2vD/dic9IyHAm46uIxLVR32VXxF9p
+KyZVAsROyEojadeZwqQ060yDsjzl5gRYqTDtdycqVO2MYxdhADUYg4YhtZ40qNXVXwwoWvG476SquyAP
+lYW77qY2uBOH9SbFolaAl6PnNnXaRsUFKP02RYMHoOWm6izJOvGFR7ZsKIrUbqcTmQxPN+tT7KOsWD9Z
+QHmanEjHMADEWL7GQDKBjmLGwsLJIPpMtuJLNriRNb/RcRnt1z7mjhY0jX7TyxLueUew9wQ9MAdnzlsR
+rkY9HBYvONRfeojwnEQYZojJxHhFqoF/DaeVNx+Yuplabm8AqUN4J21ENr1sfOdBwDpvPUhYk5J2diqO
+QwvMasjkOBo+9yxnjzU+8eD6xY+uJGvlDDcvCTseoTB/ctL4rTfhWl+J+MPermS+1c99gpe7bvNvd3q3
+D3u7vNrKIxffCNbq887ThKXiYCxddhp5KZ/N+ND6XoYXNDY1r2Y013qd6y8ky0iyfOZ7NQj/sTuJzfbR
+fZ+G4rkJipEMykdyilWGwYKma1hxnvW7XcbR/Eu6wXQRp9twnq67qPuX3v7bP3+/3+0d9N692xeYNgSZ
+Br+iDWJzSjIeolmac9kmJjOK6F13FpNM61244mvrTOKqE6VOOEzd1+Ymqh8aL7jbhYxizgmmr1UE2eau
+I/9eRZP9qS8uo71958MrEAW9qV8pOaiVvJlWrswUB1f52j7LT/K1G+9vOEVwo/mVzBSBr6FNkq9rLxUp
+uw9/EnQ2RAbfHAKBv0rT8/q1jVLSCB8QX4WLOE2pJLoruS3VyMFe3H6NGqKGUZFOHad5tIgRxSCzyzHr
+y/IPmMurr1yYD0mjlSFlVFLl4p6KK0yf/nFzeXoqFiyYFyjFPabbuz546WLhwcOhGO0rUQQRYSJWHVVR
+XLRiSFwEOGlqf/rx/LwNg7g+7+B4NUIkXuZJiUvUYPraPJ5ji6C/V9KuVlBIFwu1GCacFPd+3XO7vkue
+vsvbKqkb3a6UWEOvSb3Ttm4uHu1FSlUpwsfr8eWHAMx1NLi+Ojk6Oz07gtHJ0eXoGMb/uDq5tibTjblR
+IFXoVOAf4YhQsUr9a+8VyAbFpQBxIiinq74ToFkfnRyfjU6OGlIcrcodCVEszelcxkHb+XIyoCLMOEnk
+7uZJrf6zx0qKHWEDAmEDZJlFsXsIpEU4PvlwtVuODsT/CrNVmB9H53X5fRydi1VP17/Z7zWCvNnvGajT
+UeMtB1lcXE64Or356ePZuZix6splER+XJitDlLO+fINE/jTPHl1fnWq80OEpzDCI+JR5NMYT4R7RXJ9F
+j/XVWPlZ3MDOKFkjemfhCqFTGpcfPZU+gbZ9+LtMmu1sV2S+Ulh85Z6mFAuK8wTFHFMcgfFfLDqNDZYU
+SQdCUcTxOosRx5IgFEVEHzbp5QkUX3P59lhkU3bDssWfIkWevhHbh6FO5liYFxx0ew0g1ofS+FlibzB2
+siRU8r6/B+uzDF0eNLwjZGEtA36IQ4wR43AAOMYywlDzRXSPWrB2wLUothW91pCibb0ZRVvR6IaiLcsW
+RdNyg6qCtMUTN1p6lvSV/S5fq8lUyNe0EAusdX7DU/XWhsoLFkMgU9aLUzXTrSQHBo5YdZaP5xfIS41y
+Vch4nWcLM7IkWQJhUuCYcRwFsMQJpurBupICa9OKthWkRpyKJI1XbKqcgjIcuG9LOysaDCrwDSlaVO0D
+RNJ/MUqBlkmZBWUxaZx9waJ+XkuwqHweNZsEE1UeTDOXUAlekGlgqr3+vFt87rCHe41sSZ01jAWQ+ZXz
+BWoc2GtJEoLjv5190Nvd8uXJvx68/R5md9y5qi8gO4g6rwfOV3ny5Zr8hmEAB2/flg9mjFqzLwOI5ZAh
+Sp3YYYwT8ePVoERangaMTKyQ6ldtSCBgLVB3ezcybP58cnEyGo5POhQlSxyYo321zIk8XBlbYXZQRYNA
+R160Hwbiops0eWkyVzYTbzC909EtIAlo3DH5guGF6VFUIPjp7OJYXjVdkFi9tyeBgTB4LtMZXjOeZs8h
+pfZ3l3GcPQ/hLLEub+iV+QUQJvHgLEZz9aSfANLkoCSCF1/TxYJhHmxJxFeBiC89VMCyOGegoQS23zBN
+IUNRhCPgKaiGggXRGKIA0gBuA/gUQAIphYsQPr9QqX0x4ZiiGF5YqtIidSfPAwagH415Lf/tvO9/7spf
+/vsX3RDf4rlqbuWaPVs3WH/TmZbsOmccZvhx8QaQpBweeXrOpsB6AU6gch6NmfSmAfT2rSdjREcuyEEd
+BAuQ9eTN1N1Iw3voQd9u+8ZuK0Qh0f+gKbm/V7h+gN7jAhKumPql09rxOuN39SXOib+ZEdyF3lxnc+eR
+frBiCKm8MhoAWnBM9YKQLOvP6yF1xiljamFGU56KaJGa96GYreWRQQAH/mF1Yd2VNzSQw6LtjBCZ+y6G
+NDKOOmgmVGS/U0/uzeM4aIi6iRNRNV0L/xo9cihq39jx4X2xmJ7cZiiJOigA4kMf0KOnpuVXU95TsQC4
++I05UZbwBaQLQMU0hkynU8q1SC00dlaXQ6iGrdxo1r3rylD31ul+/vz5xf3nF5+/dib/fJi+9D8/3H9+
+0V1acpOXBt1zBrCfelI5pZ8/v6g/fqf69F54bZmcKtmsLYRl4XC24IctmdrKpH1mLzuv3ws7Jn697wfi
+v8rnJEpvPyUXU1Hmvy/tnWTSTVl9tm6+rFJOO7NVgxdfxdSWPL0C76GwhE3LgdfGxMa4KfCq1bwZWIlP
+GrCDugHbh36r8TMIZioxXhrA+3vwIs9lfgM/uD7ft/Ev93WizDDkQYKX8u3IVpVQJAmVSjxBU/l94TUk
+Z12Q2SzGLIA43WLG1dXBwMp0n91BlHIGHRkLzN6FiGbID6t4hOutxEmSeZxHeiKKpmHt2IRZadLmL0pb
+Uo4SSSEMhDT/BL13IqFbq3PvXcORAROm0eYa3mscbiY49HVxHcXGDbluoAuNXUmGX78+bDxmkZXC55db
+QZn4vt9mQiXNXugdNta2dlM5Q4ftisQYOroz0W9BRPPRKmubReppvELQXyHqQ28/gLQPfwngtg+9dwF8
+Ev/Bw0QIe1qZ9uUAfKqpnUDOmtLyXTo0N9beWXLThM3bF7OkkRuX0wd5MvA/AwAaFRGCyWIAAA==
+`,
+	},
+
+	"/underscore.js": {
+		local:   "pkg/js/underscore.js",
+		size:    41417,
+		modtime: 0,
+		compressed: `
+H4sIAAAAAAAC/8x9f3cbOY7g//kUiPc2kmxZTnq2d3fsVvv1ZNI7mZ1O+k1mbt87x2dRVZTETqmoJll2
+NEm++z0AJIsslWS758dN/ohLRRIEQQAEQRB1dgb47891KY0ttJGTnyy8mPzb5N+e+KKVc5vzs7Mm1vjJ
+TrRZhuJhMYKvnj//9elXz1/8Cn4vjVxv4Tu7+iBrYcfwW100a1m7l5VuSnhdF5MnO13CWmxhLmFhpKy2
+UCrrjJo3TpZAvYJbSfjh9Z+gUoWsrZw8eTJcNHXhlK6HI/j05AnA2Rn8RlhZqVqCla7Z8LvT7J+v+Mo6
+Ma+UXRFgo7UDPf9JFm4MsztVl/puBqqmwrnRd1aaMWgDs2Wl56KageYyK82tNJMnALfCMJgpuJWyF76f
+d+JWUs2NkbdKNxZuRdVI0At6O7uZYUsl5pUMUELNhDhTAj25uejFnhEHtxIOltJZMNI1ppYlOA1zI8UH
+0I3DLgVUWm9AOWkEUi50SZWkgSl8+pJhPt86aQMh1qpWCyVLGM4bB7V2sPyL2mxkOYJbaazS9bmH950x
+Yvuj0U7DlH9MNvjLbTdyDG/nP4Wyt4R6Wvh9Uxeh9Hs/wW15QO6lkcJJ+LlRxQcwciGNrAsZSWlhoQ3Y
+jZQliKKQ1oLTQKSMoGykd2NXkP6bJvhPsHT8hAssMt/emlQaqha6LoTbV5VLQ12n3zmj6mVSN5BoEspC
+3ZWwb+/qH43eSOO2ed28LJDqu6qC4+NXL3/47l1h1MbB18fHUAunbiUECQK13lQSZZTYwjIv3UlY6Y0E
+p6GxkqEJI6GURSWMLGElTeDaJwDgoX6vzStRrHqGveCScVL5B7HZQ6O12KQV/yjLppB9FQ2V7Nb9o1qu
+XG9dKkkbfK8qJ00f8AWVpHVf3Uqz7cVYYkla9Z1eyz28otcZxq/rUn58u+ipqbgkrfwHYV1skFWu2pIM
+uqU6GfSJ4pdpvf+WW5szIQrnB7m1aa3fqLpMakWBncxVXXYEVIAVC5lIqNOkShLd5rUXCmxjJcxlpe+C
+aN7ANPLoUM9/QkUPAKAWgD9B1daJupB6ATcjr/YQ4EWs9XSI2jivGGvW8g5uCC43wKqTmzsjUKnBNECK
+KvHVx4027sAAjo/f6BKXz+PjMdwpt+J2c1F8uBOmtKeFXm+EU3NVKbelJqTAqxJmRv7cKCOHoxl89+Pr
+CbxewJ0cGAmqZijZWiTKkhYPYUEAL0oBkVslQIBllaJKWTvU2sTDZ2fU58tK28ZIeKnXG1VJA0eivEXy
+lEewxgE8YeKhmtQLkDRsC0+nUxjgarxQtSwH6WT4mmtdNpXsVoRnz3zJxIMKTSHCnnZqwBRueFK+PEnq
+TW5CwReQlZUeDq+OsSjwYGOMrF1YnHBUN5P//eqP716/fQNTGJCJM4gcq6tKsjYM647tMyAyM+JPK4nL
+Si2NdbqWYxA1zKQoVrOOSh2D+CBg5jXgbMLNfyfqspLWz5wlluF5blTlTlXdthiDQHG12EUJRtyFRh7U
+b2Ull8JJWum6+n5gg8aP8HDaxK1QVWp6IOZIxUl88NU7cjj2JoQ2Y1zpnPzouqI5nULdVFWQtVYi80Xi
+2TMcR9sNNksrtJySVBvu9u55hZnC4zCpZL10DPSk/d2CRFkY4rgVTOH5GCqWeV/vAhR8A9UFqJOTtg0P
+IiAwKURVDT0SY2x8pa7HoOhxRD172yonRGDrjIsThD7ILagaEpUXer7BZZ7n4IPcjtLie1H7ILfX1Ox+
+9AKCEdFUD/6RqrLlLG1TOUvG5WZTbVHn4PuABTjNXCVZGB7JrWux2eXUG7QNiDsLltmHcydZ6R7lKVxd
+Xxzi2VCzy7tos3i+JUQiz/4gNukqhKV7WRWJwshG1GlnMAZa8cdQKeva2fWoXPm/nkWvYQp75rsHmJcR
+/3d3jDy/TCI0k14Zow0qSm974VKw3rgtayJWVrUGVSunRMU9RnV6fMytjo9JmZUWmg0IsKpeVoFtYGH0
+GgRhh9AJgvWqUtWo3mZ+2cK910JXZTWbPJh5eBR9/MMlXsFVZUVP3OEBXlrLte7lqECBKQizpK1umCD4
+Fr7aw2P0I2PBzND1DBYwjTzGxS1jYMOIUsCVxoPm2F72i/MfcL9MuhvmYx7BeV/hKFufH8fOZJr5rtu3
+QN3BlBmhVUUtfZ1p33d0Z2y8RyB49vaKRVR0o8R4jBi6ldF3ZC/+abthwRgmQpKLFPaU2Y1oJRjcc5wK
+a3WhmD+9WQJ64eVtDKKyGj7U+q4GYZnhzeyRKjPZ4uxnfSqO/G/+SZiesco43yPaYX96+/eVAerioCDk
+NTJpQPoE4yO1KOKofSEayiddq8Sv/2QC0+Yr2aFACxZLMri/TBDxpYcGl/Tn6vQ0rC7ncHqadnGv4JIB
+hCCv/y7S24L/R4twYvIslLGOFQncrVSxgo2wVloQOEC3Aietm8B3lRJWliTIpXS4lrEELnD/jHPLb3+J
+8cK4inr7SJX7cFuhbRdsj1217GmVzep+E6OPnKKqiKTeOPR+JyRnTsxHbnHIZ7Or/M7OskmxskonBdvQ
+tPD7f7BN6T1QYTvksWl3Q/QiMy65zt/Kvnwsd+y3RxMmuc/UPMAIC228cKWMAAuhKhsWsp8eNU0eiTDV
+j7K9qeXTR1va+ZzEMf9WOmnWqkb1Id1KGhq+XuQUWAvXGf0jxYDckfdKgagqLwJUnyQA0Xn4ht8XwOfP
+LQsRGPY9ue3ooiMn2VJwWFC6csLeVy8mHuMoJVSYCQnV+FvKyNNhHIJ/ePbsEVuw1vfo9929gvL0aY/K
+bLkGZ9RBJYV1oOvIMuGYxrsBiX/kX6VI0Ut9PwPV21nwH4maGQgb0gO/+Lty0kJU9hexEnnnPScxwpGR
+sCjjIyz/W7KRx/7z5/g4/f/ARcgtvJfXJrAN9itUTW5ldStr7h+GDe7cYTadTmejHi5QdVE1pfSqJALh
+fTUV7fCBE2Yp7/Mbdqc3Py7xs+fPSJIJ9BWyOfS1hqHfp1M4fZFRbI9BtbMS0FvqjWGl5I+Ufl3f6g8S
+BKylW+kShuQviXulEegaWIEpJ9egahBQRB8001ExjC7lGGJqfwizRGLTESTzT+xoDF8lYqMsOrd5Xmzw
+cw89wIt8oUT31UPIMfRAL8NQz5lCV/zzejQh12AQCsR11Euyl7q+lbXio9x2d4x0Wa91DY2VUAhLnihy
+DZ7DQrpihZwpYOMPPpl0m6opPuxQDl2m8Olhw/yUTTc5Ty/+Koy9XXoObGEi1rqutsGRzxC96GCh3chC
+LVQBsw9ye05YzGAjlPEW0N1Kml3mEM4ZO+ZdSipcOOGv0H03pBqtDqGacEliB+fRgA30uQrlA9y4DOAc
+BjyQwfVh7ug6s7nbjjubXhJtaTPckrpPAbRO6Z2tx183L3U5O4eldC64r3nMPDGPnheE9z/752aHAWke
+s8nDcY327T/X4qNaN+u47COZ/fPpnBQynjI2fO4UdPVLUQ8c6I1Ta/UXr/XJa69qJ5fSWKh0vaQ4G1HD
+v389/vpXX4cewhHTOynPKRTInp+dzZulndzJ+QflMBTozK703c28WU6KpbpU5fQ/n//Hr//DO+s/Pu74
+KBraqN9vwkE1n/+yxr96fh1PdfCZ3wb30zfw719//auvd/TUD8KtEBuvjvAnH4Sk/pNeBFhusGpky9PX
+9QJ9C9sek+QTT4BETRjr+ZU8efPlF5kP2FOE31oNcPkIAyJo6IsYreLhfRvM2kl89exZYqN8omah+RiS
+gYbHL4fOGSax217OVnXG2UNt4DBn30zWqv5n4i5V/02464HMtcNb/8Ss9c0/nLPerZrFopIgatZ3zDDW
+v+2PLCFSC4xjaf3c7CF93r7yIMrE3bOP0gmbCO/2wwe9HhLck5NRIFQAyg5OOIUX1zBt32Kr65269Pag
+0yVUzSjzXU1639SiiviC07CUNc6zhErrD80mTnuMleP3r9u9Wf9o49KW2Ji+wiWEic7I/ymx1K+oBhpb
++XSG2Btelgc+lNLCfAsCCoPI4jg2RqNXt6T3dcu6PPvauN/s7kkj0+0ec7RjzQfvB9SxmcnsHO41Kg97
+mMJPgFwYElc6ssa5BxJf+7ELLHmwoAaLKnANkaaNqa3kwo351CpXEAIpIRduEnq9SArnMOU2O6Vk7JGN
+N9+xAeFbmONeWJDavdWqhOdRFb646FT+hivP+yqfvthjKRLGTL5vPIb86xJOX8B56OXLaAwDPsge3S8x
+DS5JC21ALJeGPCpwtDS62cB8ewS4G+FwyiA9XHaY98Ywlytxq7TZ9S/7+NwHsiYSacd1cs+KsHMK9VC/
+RHpEFQbglbuPOEnF5f4Dgv9CUtkHiPsEfhTWglTsSA1xb8L5+HEGh7rNT8wYcMIStbcSziPC/bWgSWNQ
+uwerDD8iatQ/vRFKD3Ui9X2YT1IFNSf/5N3SOQzTn7gSjUYUrTzcpXSyL2pq14ZDctxOnUeQezohkaXB
+PU+X2AxqL8U51Lqp3QOI7fdWOcWp8d+Z4h2v7i65R5CT9/lFFolDr09Oeon8Zyt5p7kRLJ/pErtQy8ZI
+0A2vZXYtqkpa51W7cHzqwYDambEr3VQlzCVOnTROlmA1CIsQ10LR1hS0KaWZYPcW5qoWZgtWClOs2qVP
+lq+9OROJQqbRGO4xnFtV0z6ypw4uEy0D511V1DkfR7Xi3WewP1ItqVzpOw7OW6nlCqbekksPpe9WqpIw
+xIrfUK1cia1VCVMuPvHF3377bbus7MGC+rlaq/J6BN94lC89NgjyBHDZ8EitVZla+WHN0Xe5ASMWstoi
+aW+lcehtdOS6IgzmFc6t0yDASFHhIn0rU7vVadqoHIiIfkq/fefpOWS+y4lVEm9hS/P7Yid33WY7a0ys
+wgo7Au/b8tXNei4N6EV78KXqlvE956LL4nAkeNdt/DxD5eCILtPd3Xkac5GwWWsI0CQcjhAOa5h0iTMp
+umsW7YaE1CmywPFxfXwMd6qqAsptwzcMjYkZDnl8+9QFv5KinFF08MyJD3I2obij4+NlI0x5fAzFShYf
+vGKpKn1nQTlwGu60+cDBfDOa1Xgijp3jhgUB0wOC7VEd9Rioj3ROBPNqZ1bYWsunpoanodazZ/CUIMFl
+7sqmbp6PocbtJUvm8+selrLsUWexmnsVWwmkfu3MNpyxeuK9IkeeqKotNFYumgp0EmofHeiBFQ9MVji+
+jnOUgiHk5Uc8BAnuRULpTe8E7ZkbBphNUBtXc9+E7Mp7S9JUn8IpDId1O2ufP0OYDlR29SgX48Dflfhl
+7M1UOMDdDydPThkC/LfiU6zVy6Q727dd+gaP47BD5XoMz0d5zHoHFjN5p9mL64t9wdi9nO8VTw/rp3rD
+CVV5vVEavQnxhn3ScY9giHp3pltJMBKn+wEzzU0ePt0EmPWT4lBeHMdfIxcHhSAP7TRqTcYcqgA8qtiG
+AXJsc+Z6IpOw2GXNfSEqHpvOApucMPoNaX7VhEQQjCwaYzkeqhLOyXoWew37UV+Q4qPqTePGYFdE+DEO
+bdNEM5B2j77KPj9XbnBwYVsKATRc0sVL7y3lbuI26DygFjanXXz2RhFy+e4+aE9oGlfvjUgiA76SDk02
+j40ss8OkbG57aOmnz+PemeQwwLzSGLdx/egkPUfB4f1UqaWFWsezeyr3h1SIMpJhaL2/HMUH+fVeHizV
+wl/aizj2ni6/6KwKP7L7DQSUzaZShXDydGGk7B0AXXOLv2AlLIjKSFFuGdhcyhp44zKGrW5gFe5T41EW
+A+PIBAELYR3FUi21UW617olSaGr1cxOCFPAHTP1D02fYKPvOd33wRCFzc4Y2Cc/7JsmOp7v3wCLfLhb5
+33mEy5ee8OvETc9WecB+F2dvPF0ciFvEt1bKOnnlpZ76u9dvhASJuF9SAC5uOD9/JqhX+F+6oLWnvojd
+0zZ0ZIg1g0LI9AdC6JHveEeGC3kB5WDh0QPCU20/E9epoAXciAGbGi+8E3EoXYGqi9YKYuVPUX3tAkhh
+wuWp8jBtZEOdqY0dSUT+HPKVca8u2yu/4ySiZPSYISShJ3ZFt7nn0t3hxHtjlqH040yuUOtvS+7TJJ6/
+3N64lBeji95lz4+YgSUMh8juGF0+bhF9N+mipN1KmjyI2VcPYUBUg6RkPcJzz+jg+dLvOvsTbn5wGlvF
+GEmm66DB0IwSya5Ws5Ms0u7sDN7W1TYP9dwYaX0A30+NTW23cNOKzCl09DD9ExQeQP29rHNQoe81R/aF
+yaTCy7PhBbRLyf+jNuD0kkNf103l1KaSdChhgwvEXxPj4Z+edgLEiWGji4wmFJY6wmQa/UVt+qTqnmip
+1PMUblpQCMMwnO1g4zEMuHQwGvUp01resaPAX/TwlfL7pv5+abhrml4yDdHV6pq6Tzs+OoITUKMed1Of
+HnvJviabUjfcG+46zlOSY1jMVeuYvp4FbaCMJZ+uu9OA7s2qklUSTUKWh1jLQLzTU5INvaCbJXx3mV+0
+arHQxki70TVtj6k/r2YY03QWcRgeJ5suwfh+ZxeXnpXsHKD03f1FKAcv/2JXeeeJRxhbX6nreByL07fX
+Ts3bXD2nZuHXi+uLncu3hw5LXi/S5ABkD2K0j21Q4qHx98q94oPh68GavLTstUezagzHxz+8e/3q+Hjk
+71veSailLCklQrtvSD13G22VS+w5HzJVFHTrnq+LCr/9ixGOdfCHaIPnbj4GlYvZhvXRomW+QXxc3LIf
+aW/ocmZvKgsVRnCy2mbDExRbnOxOH9MKCDNnGjlrMygcHwcb5/jY50fpc7nHwNRd09LJdWtg3u+RCAeb
+HY7d9Yan9lfOuD5HQ2tYTmHAC9UgO5GFaQsBvoHncNn6MajbkwTv8/Z5L68rHxMejx9SIox27hN5y41j
+cLAKXIKC80iCXCp6g4KZLPeHBWf1hp05SfRErg3iLKVIRpgqWzxPX2SS+lAOThK59F3iTIr38haaoI/j
+q5Ww8XQI7Vfv8uoGX6fpZyKtM4wivZOqO4Zb7O1yF8QwHcL5nvKdG5fEuQlYbA7nmYyMsiMjdXr6V83m
+f4VwGVGHOEpe9tNoUdJ5uB+VThWwMXpppKV8JPAdUD6ZdDH0DPDj1q10DTMj6iWlhGlV8DvpTZ8rt4oV
+S59ijRxA10Ofr63UhZ1sqAbFaFZqboTZngVgdrJy6+pfqBPvHqDnlKesE8aNwTq9wf/lJuepzs3fb6bw
+op1pbARTIBC4A4wmNr+JNjdPIQJPbxNffXWNjV5cPIkzXNG+NGojeiikqoZD6uqUAY/gjDFFL2uiNstO
+GFcYa2atjS6etCwyxDZko2VxXEt5pcqPJyfXYXD5uE6m1H0YW7Z4Y+OMh4LjAIZihXx3b+6ZboKlMFMw
+101dgtPx5oY3n4bonV0SL85wOZ+xGdZa/AyQHSro7x09PMVCwJYuWqOmYlgddTXnG6+Rp/Ch15OCBb72
+dJqmm3r2LPkVhbN95Tc3DPiebc2D70v4Xna2EFmJ7zk5N15an99t2LvBiH7/fLcujGNnO8HL5nULBU42
+KZPoRaON0EpYWIkS6PoSru3hFkHsDjZGni5UVclyDMH3V6xEveSTZwvlthZrVXjeCLPiL1EwVt25e/DN
+kxe/gJKIxi8mIzGLv00ZD5EH1l9MsZx+TITIfoqVWDQVLfKyto3hAzrh4jEpYLeYv8uCT2cFOjmfpnRl
+2EaDci2zf7dzgTIlGb6/n2ZBHmx6cP18FFvfTKIaT47vyVdHVZKNOi6+FFi5uG6zIvBvH26x4xfvZD77
+Qa413RuoMROXrG2WPXC+Beu0CRwV/HAcn+1b7oj/StjERUORGpwBIOzQuALdkfOP072xBj2c1Qawcesu
+e2X7/cTZgYFAnG3AR13hjxhz1f6Y9jJt6orrMudvZYXb5ES0QwI41tet12itqkpZWei69Jtmt5I18aIN
+O6o2WVjslnd8SpbeS4T97RL+Tij36Ltj4ahMuj+ptdRNEinaeoASgqDhGK56wRffaYcaC2lSaozBFitZ
+NhXzETgNpqlBLJxP/1r4fG6II1gnig84tQytqKQw7cAX0uxTWu0BB5LHo3szhiumzovrQ2qHlpFRb+RB
+OhBUIWO83V0DX+Ej5YtqCX1/cwnOqOVSGlmCcLDWdJG38HZdyWoorOKcjZZ22WrtF1S3Mtq5St4/udm6
+NCYQunHj7EpsmnQ2t5Aq4XJCtuKVNED76bfCxZB/3wmk24cki8S+VTOVmfsEu9Z3PR3fCuPdpEjAKRED
+TmGItU8jxqOLnXMZztjLb704xCm/yK7tBuDfkC7+1IZgI/8F0fAEiB3tI0lORY4WexyxspR3T0O/CV5t
+x4no0rSOW1KN9gRNp0zy5YFML/gmGf5VfNyg6obtyLnsSEOtXTjmayWCgwECWK44lyT0svTaQDnaW1iY
+S5wMX7bQhsG9yRQonTHO1HotSyWcnIGy/lhjHHoFl3bJcQ4MqpKC3JKyXNJZl3VSlMHl5YxQqK2C1kEL
+vNgjlGOIGKTy2SuQh3k/Z9vxXoa9R4D3MyVfUmqxfQQzpn3jrLwhOY2wKHjGd3vx5AGSc4h945IScPYd
+PgbhX8DoPjeyZ0v5URaNy/S4JKzHUGtYC+ekgZW+Y2h64WRN59iIK6jcAq3EX7bhXFf9RbTXwHUPV3Uu
+LIVj4nFMXXSIjUiXiXrUTXfk97edVE3eLjtg7oSaC75bnqv9Tg/99G09yJHOLKMgLLtQuK+QQJhle5yE
+M6KUImWdBlHSMVqyapumhkKXEuZyoY339y6IjURdxhu+pQp74DCx1Jk2aqnSmx8+lMKITY+wU/pg0w33
+6JdlL7tke0SnfxIXw6v2XmvV97Wzcxo9hpWVv/ag16lLv83KGGrbMe0uIrFssw7xjFlehHAekHWy0DhH
+to2D0lbuO54LO5yORnsQJTttOuc82X7qFF5cgOKjXyBXYIAH2bzgOc4uha/3rJlY6INTH6VLgmXYKhRa
+6bIF7g1pFk9ELk9ISGW868tSM+Nrb68kJByOHqIkTk9989Std3DX3mHULz104Lzi93m6WqIZJX3QTy3W
+nTsrAxvyPij52FTISX7zGZH0A2fdS5Khf/58MOocg1cYCpX2J5kbvK5vRaXKgHHihvIdhkCb3vS/vUl/
+seFVkgHwmlP4ZVOKxV3+a0npoxQP0jJE8B/wZcQKjx8DN+VonZCdOGdKrtF3oN0iHSIGgrbKj63TtAz0
+eGAoofzxI+FOaCBXIbsyDaczHqrXTRIj/a1W4gVcl3pmhm1iX7BurIO5BCsNGwrRz6oY2v4h7j8Fv3eM
+/sA6jqyH4Q7k+fMxfHGWsgWCpTr6jL39ndwA6UTxeWcey2xcnThQg4sOkIA7u2eWu7Gs3yfAmE9HDIgn
+HSnUE5DBNXDkw1HnOwJO1mXc4fNA2Z8TbhK0gggqmEKnKtSN0ZySIfUPl3yB+7wYiXvQ6sZ0Uwp33yWE
+QtT4kLxbhZK0X2E5nYxQOf/cm1b8sNsxicXdxBB2Ty5aMJNzNiy6WyknkcdkuaPKNmonH1DuKNlsO/dr
+vXb+peFTRH4Oe4md7tw8TLkOUQi+xcBovfdlsWJLp/sIFbz++GpeieLDHgLptXL/UALdK3ppRBkTknXR
+AULtJxOetamqwo76BK+UC9FUbocq/r3955Exb3wEOUtSK/8tRS+eLw59aPppUelalqM2rDtfotiyxzqH
+riiiNs0sprz7LHVEe2XR39EjOg9HcB413/DTl/bCaCf1muUb+4XcOG1abznnWQoudeNtcgTfJuveGLUW
+ZgubxtBGhQRKhWMk2jFpOHIC58jpozbJW7ESqh7HC2AUQoR1N9IstFknOQFA+4wCwTUSogcRUR/7RNCY
+sE7sJg5Ihhdp3L5KDoX2THG8P9LeFeFLy8rqOj+kmCn76udGVDHTo/w5RUeMYT4G8Q598mOY09+AEnZE
+ZzZF/ESNBWEkSAQ4gdlzOtk6fT4bh4tLWyzHCDYVGk4CqHeSzdffCbPW9RZmcimqGcmLtqI6D5+qu1Mf
+1EQWa2HJ8qfwh1J/aCab1QbTU624/blcBuCcXGLKCSrCZo4s/OcUhgBnlJ+CHuYXAZ/v6Pp74VLKKQu1
+LKS1yEJzWYjGSpihlGL7+F2cWd4viXFIbJEH6Xi0Yqd/rsn1IOothI8VJR+DCSDzjyMJmIKIHzdqjxjn
+nXpzmMK8U4/sbhyehNnV1ctKWHt9PfP2TbtA4Ps3gjJ/hs+WeaWYHGm2tZ52q81HfUnf7J1yxSpp2GpD
+5Adqb8f+8MyOocSdH0v4XOtKiprZjSeI08KQJT3xUCgP3OCKKegBXg/OfamPpVdrhZtCGzSHMp241bCW
+EN1M5HB1KypZuwtwq8aOYXb09RE6oFPgbS1wGma4eWQkhkdfH41mk534OOQPX2M+uugdxRuiRmcUszfi
+zayLGQtdretTIxeV/Khu5QS+q4Ng5Wzt1Vj0tUfQHOZeN2tpVJHE8HYQfzqFEwGXMKenOR6o0miew2VH
+wOCcf5zsGyGe+7Tjy8t+w/PeGf5LjaqR+SNnD6cj6psw02EQ1NEuBxELpNCTEwcw0kf0+/Qv8EY76Z1t
+3hfAWNCidKBlRuIFTyChUmuXzOEOpU887S5aQfmjXL76uNkzEG8wwIb81TWTZ1GJpe0XEgaW0jdM8cRD
+Qn0Vnp89i9X8PzHxnyyjav65rxrdDqAPelLN9mdfZbWstZEvhfW129/dFGw+FJYYcuCdH6h6/ft5+r5P
+J6Hit7ZZ+0UsfMut2BaVKsA60xSuMcg9aE3EG2lUibP+k1ePqgeAbStQFkQpNk6WHMP46h18PXkB4cLN
+i68nL76a/GoMYm6dEYVrzQqY/f7tbLJ7hYGX5t60GfQq9X6encEfVC2FCaHM8COLPS4SQB/TwyAkWW15
+2TXsMw+e+RjF0ILzF+xqSfuOhDxpbijC8Cq6s6YgIuXnO2XzdEZxNspyJ29nQIgjBfQCnBGEeGe19LTh
+W2Ne28yTd/PEYeczUTwf92UyJynzllS1DVLW2jx16W9KTHpWw+m0FS8yfK8H2ZSEBZgg+IklzVVmecGh
+lHKzzxgJ9PajEBk/JIfOXI4itPvxD67UouY9rrFT6Q8Tase7IBJC5HZcYlotm26yABJ+xL5zX/xO4nX5
+81BcYb3rMczDQ8f4HI04N/be3U8epB690l4nhwtWdILNDKuN7VG9vHx6V/LMtuBIcFswC8NOLhPNDjq0
+eMmXP8Uk6WYMc/96nr5OJ4HbUTK1lz6R5NPcUUU1KFkBP6ZWXizrqtDuvwzivIU470LkslHfEUFf9t4u
+x2Ti2JM4uP8beKLvC3ghxxVxIYaqFU6WrU7q5TwWiJDMqR9F3OfjIeacr5ft4UxGbM6IIamIVfmze3P/
+95GMyg7D2jbGWxBz7VaBYvmNb1Qjh0baL75EbU/p+a7c7QzpaRDRvYgnmuQp1u27tkG6cq1v5a7aJsl5
+jOLWm2FHbbdv9rum/aLWozKd9skn1ILuue1uWtnbzlvi7j64c/Arf/a746vr3cv9r9s0++GOPX/tOUnI
+T5/cu4xZCI/o91EoXQkLtQZJmpW953f1adeJ5XPMPjKHUv6Njo5HhpMLWr8P6fpyspjVxx81dFVHB6E+
++vHZs4Dfvv0h3Ba9DGPnn3tGH7+WgC9Drt9alxJP8GgAL0b3dOpn77Ln+BEPH79uzxyzTy979OgZpp1v
+NX/+fAjbfOPsP+a5Y0DsR5s/k9567wKp3s57PqrT6ZtZJTv4zDNklT4gXVkioT+TwZti37Xe0XZlwed3
+nu2V5Z0rPuHGC//yZmPyxDtbrwYRymAMgwAFnxkKPjEUfEIo+JehDK4Tn2ydOBNurgbKDuCEnBrXewjw
+UPIHOHACA56FcAQ+SqJfVS1BwMLHl3fTYwRXYx2udVq+ITKG169GFFsarkDj1huJjQ4zdAmpGnN/OJrg
+o0irI9rcTJ4kfthYlobVB3qk5feRo5WdRJYHSBspB3ksdAgBCInoZy0f8EW3Deouo4TrfoV6eDY5G/Gn
+pQMygxTbAObeuWNwgYtbYD1oJjLjp1YAJdgOC22Qm+/55UG5CbViqvGnyr4Rb4YbYaz8vtLCu8S7UtsG
+iLO6IS/AJQzfiDchdofOwhgj/9WsmBOGFixQzspqMQqrATY9iCtVInhpYnTy2ux4kXd1sHesBOJ4d8zD
+tArqeFR+4TetAvjiHpmLPp/DyDE5yOFTVQHBN01VPQy7ENl2UKtGD2+A/+fw4mGdtNnHklTm2riicblX
+nqwUipZPDmI4iY7HKXwTBUplZOEqn1ZH154jYIgrMWoQuNOmtGPiGV3z51ScRmHxXINg7/+cykrYt3f1
+j77bOFdcNc+S6vgj+PddeAtHwU2dfHR/8hOdix/X+qWuF5Uq3DF9uX7s8QgHwviJ/DgvdEnHdxMDr/Vd
+Lc0kic0yMqTW8P6DnU/9Mz3arnuTt4Qv0oeOWii5SeMjzyNZ/lvKDXUbU6oG2CAM3fBjJxKfVmYJ2tvL
+MfclZu/5CkNTp3FpnMYtiTajxxRsfc8XEUVRNGuY+ouV9YEMGLW/Vk0tOOXFnpysKjfqqUF/vADn2I+X
+ckOeljXlICjxEyrI+UXVoK+mvfqKbZIxrlU9xrqpoYxNo6H8KUTI4kuEHkNmVd293hoCYVUNJ3yFdVFp
+bfgSK/c9HMEx93Dq63WTa/3Bx8/87k8//AForpXkjyVKW4iNj0VHAjMj/EDHhowm1ZDn/hfA4NngHAbP
+xHpzMQi55Aff0LvKJa++pVfL9NURvfq50cnLo8ERvvyXj1/9R1LzbOBffn8xSHMXQovgpKkZNZjGGKZh
+W8plo3aKl/KjtN3Ij270lI9xiJHn1RbEXN/KnDwBWJdEQCF8bDIOB1doz/m0sDt4TX7Sqh4OBiM29sYw
+WA5GPPwwrvMM2rAXWlPn8D4zwJEH2HdjOJ93GnxTx5+8kbTg9BltqIlj6Hh4o6skttzb1Nw79hYwyUzl
+/ItjN+HLXvlFceywE+FA73Y2loNBJ455OECieAgTIzeVKOQwm6PQZYoUfuGwx+cUqRraXFHN650ET7lJ
+7jODZEHMaMWX7SqqOsG78X4XKHfhl1ZcTO+UjUtRvBia3UoIa6gs3DjC72zIaSfWIV0bWp9m0ubKVwHO
+9cUDv8IR12dZ5F9uyTZzW90YXCehaKzTa2hD7g6vkGv1UdWH4ma6V1i7e7M0vttfLbi5Chs0jHeh5zCt
+N5Noslzt7OJG2cc12sB7XH/jafd1cjHr/hj87gdoiJYcB50ERt/4ePHuHZkvoz2pJMJpSVi7VAlD/yoJ
+DUEeNxKKSsnageWMEuFjX8mdEifXG22E2ZJjRJXxWxSqJD+pNGGdCnkQX2dW6sbIhfqYfRAFy09O2vYn
+UZ5DmCu1gcvwcIJtzkHl36D5zTZYMeOUgRorLbz6429OrdtWkvCvkC6lrPBklo746f66bC9p8eUCWgpC
+dcsfc7MhOY+oKNaFnDAtKG/b+EbvQpu4HKA8IDQAOIezb/51ePXevn93fXI5+tdvz5bjNuSGdKrkStO+
+Wn6FgwjqNK+VUuZ/VjLImvoLp23oYDgbg1qgXEKpcdt/Jzh4oPRehZB2KVX34zAccjIkC4dBBTuOGZ90
+7R3NypvKmP1W1A7Lau34iwOOMxzxVcwf8CdM4Ww4Gf3fsxhAFj4esRJ4RimNZfh8EZDpUYL1F/Ip6qcQ
+NZZtmhDV/SScjCKeFVmFVeiXIbSTRfZHsESY6IP37wfnEJ7COxPemfiqDq/q+MqFVy6+ar56/tV/oj3D
+D+nrX4fXvx7EmWyRNEic9+8/Dz6/N5/f15/fu88MjP/8+mwZiPZ7cSv8XYW1Kow+9TNPzjGr1qoSBpyG
+3+tVDX+UVi0HtpP+N+iAVqRaGLASdVnROdRcOVILqVxR7IG5lZYDae1GFDJcsapLDnjBTWSkPVqAMoas
+JZJQ0m2rXLyyuypkz5fCiXEU1TxQHdH3AUCtWLbhmBT4F0rGPULMy3o4Op2jWLRDZfZCTjdy2SBN5ceN
+T8sDt0q06oLpyUgR29NkJvbcFRUDDAMy3jBEl4UXjZEPhBjvVE0o9qD6USHtVqa617n1+Pl/efMxo8VG
+VZKvr0Zlyd1Bx3gMEmdTr1y1nRz8NJoP/4Cjm5vNyXRwxEU439Gu83TsWnK+fzlOGSlqLTkGvVhYmZxd
++b5Opgyeo0PD94i4rq8JEDv3EtljRkbr9P17tEY9jwfbMRiKwT7j4iwxbUTnaHDyvh4Ob27cdHgUQcEJ
+HI1G0yl5ngaD8xvPKVhvNDp5XwditSdpihw0kRgP6y1psNPljdvfUSD0vl4u3tc0GF8NYb+vs1lO4HnO
+4GmAE79kdOMNeEeMRXmce7fbln9fL0C0Hh2fMzDmuh4DTTIpliTRfKULUYEt9Ea2oRdPo1QFcKOWeweo
+0tAm/fz505fRp/c17U88VjD48h7dxSmqUzhC9r+5ceObm810MBjf3Pw05eOeaJaSdI6P4CRsmTdG1W6a
+ZNIgct7c/NQNJh8MRhdfeAI6zA9Hwcy/2SSkcmabOL7rMqqtuBPYGT4qFQx6Qp1xMxj7HsJeCQpBYZgJ
+e8gY7OXrhonlW3AyS4CFJMdpST7KjlgNeQ24SWJs+tYLbvmpe2sbIaR2dw7sS8s2Pxp9q0oZL7oqyhfg
+oQdakt+0SL4cvNCUGIMbJItBwLAdfzxFoN39Adryrn6XoQI7+aGFHnb2YgKOKCr8KGI/9h5/ukVa+rPH
+sDMLF4Q5OB8b3uP3p3dcs3Np6e3bH/s/dhPkMrE4lI35GvIcEar9+pfoxC4neZe8HUif13v7lncCGEqn
+bBgQrHRVWl6m26z8fGkuTV/dtDi1Se/gf7KOLawFXb2lUWMOGW77O1lhT+nHu0I6C65KnveeEP5gofZu
++DsE5/0ngcP87Rn54XwnZJ9YgNNbdTbeO5vyQKmdHfnwZtQFt26ccDokL9wHKjmC3egNqgncJeNfI3EG
+yH1kV2rh6EEb/ou3RbxniQv3ncFyDig69vR+Y7pHlO7yH7jHR0h0mgLZ/r5NaICdhE9P0H2Qnd09f/Yl
+ROYx4mR2xVc8rlHn88A+SxcKopP+a8K92SZSvZV8Jjj3SYUJEgWG8D1mhvhSFpIdFx38S/bRP4D4e0eZ
+kT2bmL2ps5gQ8bZPgsEYPkXt/s4J41qZbDVLr2MKuOZ5H+6pOOZJMDrnNQBfxrH/Vx8pDNeGz9yg1PMX
+ACImtH9i/ZKh4r/bvJ+MPQz85UkgzZdRS9/RxZP/NwAnCSPxyaEAAA==
 `,
 	},
 
@@ -2,19 +2,24 @@
 package nameservers
 
 import (
-	"fmt"
+	"context"
+	"log"
 	"strings"
 
 	"strconv"
 
 	"github.com/StackExchange/dnscontrol/models"
+	"github.com/StackExchange/dnscontrol/pkg/printer"
 	"github.com/miekg/dns/dnsutil"
 )
 
 // DetermineNameservers will find all nameservers we should use for a domain. It follows the following rules:
 // 1. All explicitly defined NAMESERVER records will be used.
 // 2. Each DSP declares how many nameservers to use. Default is all. 0 indicates to use none.
-func DetermineNameservers(dc *models.DomainConfig) ([]*models.Nameserver, error) {
+// Progress is reported to out. Providers stop waiting for their API when ctx is done.
+// If acquire is not nil, it is called with the provider type before each provider is asked,
+// and the function it returns when the provider answered.
+func DetermineNameservers(ctx context.Context, dc *models.DomainConfig, out printer.Printer, acquire func(pType string) (release func())) ([]*models.Nameserver, error) {
 	// always take explicit
 	ns := dc.Nameservers
 	for _, dnsProvider := range dc.DNSProviderInstances {
@@ -22,8 +27,13 @@ func DetermineNameservers(dc *models.DomainConfig) ([]*models.Nameserver, error)
 		if n == 0 {
 			continue
 		}
-		fmt.Printf("----- Getting nameservers from: %s\n", dnsProvider.Name)
-		nss, err := dnsProvider.Driver.GetNameservers(dc.Name)
+		out.Debugf("----- Getting nameservers from: %s\n", dnsProvider.Name)
+		release := func() {}
+		if acquire != nil {
+			release = acquire(dnsProvider.ProviderType)
+		}
+		nss, err := dnsProvider.GetNameservers(ctx, dc.Name)
+		release()
 		if err != nil {
 			return nil, err
 		}
@@ -44,7 +54,7 @@ func AddNSRecords(dc *models.DomainConfig) {
 	if ttls, ok := dc.Metadata["ns_ttl"]; ok {
 		t, err := strconv.ParseUint(ttls, 10, 32)
 		if err != nil {
-			fmt.Printf("WARNING: ns_ttl fpr %s (%s) is not a valid int", dc.Name, ttls)
+			log.Printf("WARNING: ns_ttl fpr %s (%s) is not a valid int", dc.Name, ttls)
 		} else {
 			ttl = uint32(t)
 		}
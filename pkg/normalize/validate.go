@@ -3,6 +3,7 @@ package normalize
 import (
 	"fmt"
 	"net"
+	"strconv"
 	"strings"
 
 	"github.com/StackExchange/dnscontrol/models"
@@ -195,6 +196,9 @@ func importTransform(srcDomain, dstDomain *models.DomainConfig, transforms []tra
 		}
 		newRec := func() *models.RecordConfig {
 			rec2, _ := rec.Copy()
+			// The DNS providers of the source domain are not those of dstDomain.
+			delete(rec2.Metadata, models.MetaOnlyProviders)
+			delete(rec2.Metadata, models.MetaExceptProviders)
 			rec2.Name = rec2.NameFQDN
 			rec2.NameFQDN = dnsutil.AddOrigin(rec2.Name, dstDomain.Name)
 			if ttl != 0 {
@@ -247,7 +251,6 @@ type Warning struct {
 // NormalizeAndValidateConfig performs and normalization and/or validation of the IR.
 func NormalizeAndValidateConfig(config *models.DNSConfig) (errs []error) {
 	for _, domain := range config.Domains {
-		pTypes := []string{}
 		txtMultiDissenters := []string{}
 		for _, provider := range domain.DNSProviderInstances {
 			pType := provider.ProviderType
@@ -274,7 +277,10 @@ func NormalizeAndValidateConfig(config *models.DNSConfig) (errs []error) {
 				rec.TTL = models.DefaultTTL
 			}
 			// Validate the unmodified inputs:
-			if err := validateRecordTypes(rec, domain.Name, pTypes); err != nil {
+			if err := checkProviderNames(rec, domain); err != nil {
+				errs = append(errs, err)
+			}
+			if err := validateRecordTypes(rec, domain.Name, recordProviderTypes(rec, domain)); err != nil {
 				errs = append(errs, err)
 			}
 			if err := checkLabel(rec.Name, rec.Type, domain.Name, rec.Metadata); err != nil {
@@ -311,12 +317,20 @@ func NormalizeAndValidateConfig(config *models.DNSConfig) (errs []error) {
 					errs = append(errs, fmt.Errorf("TLSA MatchingType %d is invalid in record %s (domain %s)",
 						rec.TlsaMatchingType, rec.Name, domain.Name))
 				}
-			} else if rec.Type == "TXT" && len(txtMultiDissenters) != 0 && len(rec.TxtStrings) > 1 {
+			} else if rec.Type == "TXT" && len(rec.TxtStrings) > 1 {
 				// There are providers that  don't support TXTMulti yet there is
 				// a TXT record with multiple strings:
-				errs = append(errs,
-					fmt.Errorf("TXT records with multiple strings (label %v domain: %v) not supported by %s",
-						rec.Name, domain.Name, strings.Join(txtMultiDissenters, ",")))
+				dissenters := []string{}
+				for _, name := range txtMultiDissenters {
+					if rec.ForProvider(name) {
+						dissenters = append(dissenters, name)
+					}
+				}
+				if len(dissenters) != 0 {
+					errs = append(errs,
+						fmt.Errorf("TXT records with multiple strings (label %v domain: %v) not supported by %s",
+							rec.Name, domain.Name, strings.Join(dissenters, ",")))
+				}
 			}
 
 			// Populate FQDN:
@@ -361,6 +375,16 @@ func NormalizeAndValidateConfig(config *models.DNSConfig) (errs []error) {
 		errs = append(errs, checkCNAMEs(d)...)
 	}
 
+	// Check that IGNORE rules are valid, and don't match records that are managed here
+	for _, d := range config.Domains {
+		errs = append(errs, checkIgnored(d)...)
+	}
+
+	// Check that the safety limits of the domains are numbers, so that a typo can not disable them
+	for _, d := range config.Domains {
+		errs = append(errs, checkSafetyLimits(d)...)
+	}
+
 	// Check that if any aliases / ptr / etc.. are used in a domain, every provider for that domain supports them
 	for _, d := range config.Domains {
 		err := checkProviderCapabilities(d)
@@ -372,6 +396,34 @@ func NormalizeAndValidateConfig(config *models.DNSConfig) (errs []error) {
 	return errs
 }
 
+func checkIgnored(dc *models.DomainConfig) (errs []error) {
+	for _, rule := range dc.Ignored {
+		if err := rule.Validate(); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %s", dc.Name, err))
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	for _, rec := range dc.Records {
+		if rule := dc.IgnoredBy(rec); rule != nil {
+			errs = append(errs, fmt.Errorf("%s %s is declared, but matches IGNORE(%s). Records can not be both managed and ignored", rec.Type, rec.NameFQDN, rule))
+		}
+	}
+	return errs
+}
+
+func checkSafetyLimits(dc *models.DomainConfig) (errs []error) {
+	for _, key := range []string{"safety_max_changes", "safety_max_percent"} {
+		if v, ok := dc.Metadata[key]; ok {
+			if n, err := strconv.Atoi(v); err != nil || n < 0 {
+				errs = append(errs, fmt.Errorf("%s for %s (%s) is not a valid number", key, dc.Name, v))
+			}
+		}
+	}
+	return errs
+}
+
 func checkCNAMEs(dc *models.DomainConfig) (errs []error) {
 	cnames := map[string]bool{}
 	for _, r := range dc.Records {
@@ -390,6 +442,31 @@ func checkCNAMEs(dc *models.DomainConfig) (errs []error) {
 	return
 }
 
+// checkProviderNames checks that the DNS providers a record is restricted to, or excluded from, are
+// DNS providers of its domain.
+func checkProviderNames(rec *models.RecordConfig, dc *models.DomainConfig) error {
+	for _, key := range []string{models.MetaOnlyProviders, models.MetaExceptProviders} {
+		for _, name := range rec.ProviderNames(key) {
+			if _, ok := dc.DNSProviderNames[name]; !ok {
+				return fmt.Errorf("%s record %s in %s has %s %s, which is not a DNS provider of the domain",
+					rec.Type, rec.Name, dc.Name, key, name)
+			}
+		}
+	}
+	return nil
+}
+
+// recordProviderTypes returns the types of the DNS providers the record is sent to.
+func recordProviderTypes(rec *models.RecordConfig, dc *models.DomainConfig) []string {
+	pTypes := []string{}
+	for _, provider := range dc.DNSProviderInstances {
+		if rec.ForProvider(provider.Name) {
+			pTypes = append(pTypes, provider.ProviderType)
+		}
+	}
+	return pTypes
+}
+
 func checkProviderCapabilities(dc *models.DomainConfig) error {
 	types := []struct {
 		rType string
@@ -402,18 +479,16 @@ func checkProviderCapabilities(dc *models.DomainConfig) error {
 		{"TLSA", providers.CanUseTLSA},
 	}
 	for _, ty := range types {
-		hasAny := false
-		for _, r := range dc.Records {
-			if r.Type == ty.rType {
-				hasAny = true
-				break
-			}
-		}
-		if !hasAny {
-			continue
-		}
 		for _, provider := range dc.DNSProviderInstances {
-			if !providers.ProviderHasCabability(provider.ProviderType, ty.cap) {
+			// Only the records sent to the provider matter.
+			hasAny := false
+			for _, r := range dc.Records {
+				if r.Type == ty.rType && r.ForProvider(provider.Name) {
+					hasAny = true
+					break
+				}
+			}
+			if hasAny && !providers.ProviderHasCabability(provider.ProviderType, ty.cap) {
 				return fmt.Errorf("Domain %s uses %s records, but DNS provider type %s does not support them", dc.Name, ty.rType, provider.ProviderType)
 			}
 		}
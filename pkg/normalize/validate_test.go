@@ -6,6 +6,7 @@ import (
 	"fmt"
 
 	"github.com/StackExchange/dnscontrol/models"
+	"github.com/StackExchange/dnscontrol/providers"
 )
 
 func TestCheckLabel(t *testing.T) {
@@ -189,6 +190,38 @@ func TestCNAMEMutex(t *testing.T) {
 	}
 }
 
+func TestIgnoredValidation(t *testing.T) {
+	tests := []struct {
+		rule *models.IgnoreRule
+		fail bool
+	}{
+		{&models.IgnoreRule{Label: "bar"}, false},
+		{&models.IgnoreRule{Label: "foo"}, true},
+		{&models.IgnoreRule{Label: "f*"}, true},
+		{&models.IgnoreRule{Label: "foo", Types: []string{"MX"}}, false},
+		{&models.IgnoreRule{Label: "foo", Target: "10.*"}, false},
+		{&models.IgnoreRule{Label: "foo", Target: "1.2.3.*"}, true},
+		{&models.IgnoreRule{Label: ""}, true},
+		{&models.IgnoreRule{Label: "bar", Types: []string{"a"}}, true},
+	}
+	for _, tst := range tests {
+		t.Run(tst.rule.String(), func(t *testing.T) {
+			dc := &models.DomainConfig{
+				Name:    "example.com",
+				Records: []*models.RecordConfig{{Type: "A", Name: "foo", NameFQDN: "foo.example.com", Target: "1.2.3.4"}},
+				Ignored: []*models.IgnoreRule{tst.rule},
+			}
+			errs := checkIgnored(dc)
+			if errs != nil && !tst.fail {
+				t.Errorf("Got error but expected none: %v", errs)
+			}
+			if errs == nil && tst.fail {
+				t.Error("Expected error but got none")
+			}
+		})
+	}
+}
+
 func TestCAAValidation(t *testing.T) {
 	config := &models.DNSConfig{
 		Domains: []*models.DomainConfig{
@@ -224,3 +257,35 @@ func TestTLSAValidation(t *testing.T) {
 		t.Error("Expect error on invalid TLSA but got none")
 	}
 }
+
+func TestProviderTargeting(t *testing.T) {
+	providers.RegisterDomainServiceProviderType("TESTPTR", nil, providers.CanUsePTR)
+	tests := []struct {
+		meta map[string]string
+		fail bool
+	}{
+		{nil, true},
+		{map[string]string{models.MetaOnlyProviders: "withptr"}, false},
+		{map[string]string{models.MetaExceptProviders: "noptr"}, false},
+		{map[string]string{models.MetaOnlyProviders: "withptr,noptr"}, true},
+		{map[string]string{models.MetaOnlyProviders: "unknown"}, true},
+	}
+	for _, tst := range tests {
+		t.Run(fmt.Sprint(tst.meta), func(t *testing.T) {
+			dc := &models.DomainConfig{
+				Name:             "2.1.in-addr.arpa",
+				DNSProviderNames: map[string]int{"withptr": 0, "noptr": 0},
+				DNSProviderInstances: []*models.DNSProviderInstance{
+					{ProviderBase: models.ProviderBase{Name: "noptr", ProviderType: "TESTNOPTR"}},
+					{ProviderBase: models.ProviderBase{Name: "withptr", ProviderType: "TESTPTR"}},
+				},
+				Records: []*models.RecordConfig{{Type: "PTR", Name: "4.3", Target: "foo.example.com.", Metadata: tst.meta}},
+			}
+			err := checkProviderCapabilities(dc)
+			if err == nil {
+				err = checkProviderNames(dc.Records[0], dc)
+			}
+			checkError(t, err, tst.fail, fmt.Sprint(tst.meta))
+		})
+	}
+}
@@ -0,0 +1,133 @@
+// Package ownership stores which records DNSControl created, for domains that use PURGE_OWNED.
+//
+// Such domains only delete the records listed in the state file. Records created by other
+// tools (cert-manager, external-dns, ...) are never listed, so they are left alone.
+// A record becomes owned once a push of its domain succeeds while it is declared, and stops
+// being owned once it is no longer declared and a push succeeds.
+package ownership
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sort"
+	"sync"
+
+	"github.com/StackExchange/dnscontrol/models"
+)
+
+// Version is the version of the state file format.
+const Version = 1
+
+// State lists the records DNSControl owns, per domain and DNS provider.
+//
+// Ownership is tracked per provider, as providers may alter the records (for example
+// quote TXT records) before they are compared to the existing ones.
+type State struct {
+	Version int                            `json:"version"`
+	Domains map[string]map[string][]string `json:"domains"` // sorted OwnershipKey of the records, by domain and provider
+
+	mu      sync.Mutex
+	changed bool
+}
+
+// New returns an empty state.
+func New() *State {
+	return &State{Version: Version, Domains: map[string]map[string][]string{}}
+}
+
+// Read loads a state file. A missing file is an empty state, as nothing is owned yet.
+func Read(filename string) (*State, error) {
+	dat, err := ioutil.ReadFile(filename)
+	if os.IsNotExist(err) {
+		return New(), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	s := New()
+	if err = json.Unmarshal(dat, s); err != nil {
+		return nil, fmt.Errorf("Parsing state %s: %s", filename, err)
+	}
+	if s.Version != Version {
+		return nil, fmt.Errorf("State %s has version %d, expected %d", filename, s.Version, Version)
+	}
+	if s.Domains == nil {
+		s.Domains = map[string]map[string][]string{}
+	}
+	return s, nil
+}
+
+// Owned returns the OwnershipKey of the records owned at a provider in a domain.
+func (s *State) Owned(domain, provider string) map[string]bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	keys := s.Domains[domain][provider]
+	owned := make(map[string]bool, len(keys))
+	for _, k := range keys {
+		owned[k] = true
+	}
+	return owned
+}
+
+// SetOwned replaces the records owned at a provider in a domain by the records DNSControl
+// manages there, as the provider saw them. Records matched by IGNORE are not managed, so they are not owned.
+// SOA records belong to the zone itself, and their serial changes with every push, so they are never listed.
+// It is safe to call SetOwned from several goroutines.
+func (s *State) SetOwned(provider string, dc *models.DomainConfig) {
+	keys := []string{}
+	seen := map[string]bool{}
+	for _, r := range dc.Records {
+		k := r.OwnershipKey()
+		if r.Type == "SOA" || dc.IgnoredBy(r) != nil || seen[k] {
+			continue
+		}
+		seen[k] = true
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.Domains[dc.Name] == nil {
+		s.Domains[dc.Name] = map[string][]string{}
+	}
+	if !equal(s.Domains[dc.Name][provider], keys) {
+		s.Domains[dc.Name][provider] = keys
+		s.changed = true
+	}
+}
+
+// Changed reports whether SetOwned changed the state since it was read.
+func (s *State) Changed() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.changed
+}
+
+// Write stores the state in a file.
+func (s *State) Write(filename string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	dat, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err = ioutil.WriteFile(filename, append(dat, '\n'), 0600); err != nil {
+		return err
+	}
+	s.changed = false
+	return nil
+}
+
+func equal(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
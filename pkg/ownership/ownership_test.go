@@ -0,0 +1,55 @@
+package ownership
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/StackExchange/dnscontrol/models"
+)
+
+func TestRoundTrip(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ownership")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	fn := filepath.Join(dir, "state.json")
+
+	s, err := Read(fn)
+	if err != nil {
+		t.Fatalf("expected a missing file to be an empty state: %s", err)
+	}
+	www := &models.RecordConfig{Type: "A", Name: "www", NameFQDN: "www.example.com", Target: "1.2.3.4"}
+	acme := &models.RecordConfig{Type: "TXT", Name: "_acme-challenge", NameFQDN: "_acme-challenge.example.com", Target: "token", TxtStrings: []string{"token"}}
+	dc := &models.DomainConfig{
+		Name:    "example.com",
+		Records: models.Records{www, acme},
+		Ignored: []*models.IgnoreRule{{Label: "_acme-challenge"}},
+	}
+	s.SetOwned("bind", dc)
+	if !s.Changed() {
+		t.Fatal("expected the state to change")
+	}
+	if err := s.Write(fn); err != nil {
+		t.Fatal(err)
+	}
+
+	s, err = Read(fn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	owned := s.Owned("example.com", "bind")
+	if len(owned) != 1 || !owned[www.OwnershipKey()] {
+		t.Errorf("expected only www to be owned, got %v", owned)
+	}
+	if len(s.Owned("example.com", "other")) != 0 {
+		t.Errorf("expected nothing to be owned at another provider")
+	}
+	www.TTL = 600
+	s.SetOwned("bind", dc)
+	if s.Changed() {
+		t.Errorf("a TTL change must not change the ownership")
+	}
+}
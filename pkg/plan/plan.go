@@ -0,0 +1,206 @@
+// Package plan stores the corrections computed by "preview" in a file, so that
+// "push" can later verify it is about to perform exactly the changes that were reviewed.
+//
+// Corrections are closures and can not be serialized. Instead the plan records the
+// message of every correction, grouped per domain and provider. At push time the
+// corrections are computed again and compared to the plan. Any difference (the live
+// zone or the configuration changed in the meantime) means the plan is stale.
+package plan
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/StackExchange/dnscontrol/models"
+)
+
+// Version is the version of the plan file format.
+const Version = 1
+
+// KeyEnvVar is the environment variable holding an optional secret.
+// If set, the plan is signed with HMAC-SHA256 instead of a plain SHA256 hash.
+const KeyEnvVar = "DNSCONTROL_PLAN_KEY"
+
+// Plan is the set of corrections preview found for every domain.
+type Plan struct {
+	Version int       `json:"version"`
+	Created time.Time `json:"created"`
+	Domains []*Domain `json:"domains"`
+	Signed  bool      `json:"signed,omitempty"`
+	Hash    string    `json:"hash"`
+
+	mu sync.Mutex
+}
+
+// Domain holds the planned corrections for a single domain.
+type Domain struct {
+	Name      string      `json:"name"`
+	Providers []*Provider `json:"providers"`
+}
+
+// Provider holds the planned corrections of one DNS provider or registrar for a domain.
+type Provider struct {
+	Name        string   `json:"name"`
+	Registrar   bool     `json:"registrar,omitempty"`
+	Corrections []string `json:"corrections"`
+}
+
+// New returns an empty plan.
+func New() *Plan {
+	return &Plan{
+		Version: Version,
+		Created: time.Now().UTC(),
+		Domains: []*Domain{},
+	}
+}
+
+// Add records the corrections of a provider (or registrar) for a domain.
+// It is safe to call Add from several goroutines.
+func (p *Plan) Add(domain, provider string, registrar bool, corrections []*models.Correction) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	d := p.findDomain(domain)
+	if d == nil {
+		d = &Domain{Name: domain}
+		p.Domains = append(p.Domains, d)
+	}
+	msgs := make([]string, 0, len(corrections))
+	for _, c := range corrections {
+		msgs = append(msgs, c.Msg)
+	}
+	d.Providers = append(d.Providers, &Provider{Name: provider, Registrar: registrar, Corrections: msgs})
+}
+
+// Find returns the planned entry for a provider (or registrar) of a domain, or nil if there is none.
+func (p *Plan) Find(domain, provider string, registrar bool) *Provider {
+	d := p.findDomain(domain)
+	if d == nil {
+		return nil
+	}
+	for _, pr := range d.Providers {
+		if pr.Name == provider && pr.Registrar == registrar {
+			return pr
+		}
+	}
+	return nil
+}
+
+func (p *Plan) findDomain(name string) *Domain {
+	for _, d := range p.Domains {
+		if d.Name == name {
+			return d
+		}
+	}
+	return nil
+}
+
+// Verify checks that freshly computed corrections are exactly the ones in the plan.
+// A nil error means the corrections may be executed.
+func (p *Plan) Verify(domain, provider string, registrar bool, corrections []*models.Correction) error {
+	planned := p.Find(domain, provider, registrar)
+	if planned == nil {
+		if len(corrections) == 0 {
+			return nil
+		}
+		return fmt.Errorf("plan has no entry for %s on %s, but %d corrections are needed", domain, provider, len(corrections))
+	}
+	if len(planned.Corrections) != len(corrections) {
+		return fmt.Errorf("plan for %s on %s has %d corrections, but %d are needed now", domain, provider, len(planned.Corrections), len(corrections))
+	}
+	for i, c := range corrections {
+		if planned.Corrections[i] != c.Msg {
+			return fmt.Errorf("correction #%d for %s on %s differs from plan:\n  planned: %s\n  current: %s", i+1, domain, provider, planned.Corrections[i], c.Msg)
+		}
+	}
+	return nil
+}
+
+// digest computes the hash (or signature, if a key is given) of the plan contents.
+func (p *Plan) digest(key string) (string, error) {
+	content := struct {
+		Version int       `json:"version"`
+		Created time.Time `json:"created"`
+		Domains []*Domain `json:"domains"`
+	}{p.Version, p.Created, p.Domains}
+	dat, err := json.Marshal(content)
+	if err != nil {
+		return "", err
+	}
+	if key != "" {
+		mac := hmac.New(sha256.New, []byte(key))
+		mac.Write(dat)
+		return hex.EncodeToString(mac.Sum(nil)), nil
+	}
+	sum := sha256.Sum256(dat)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// Write stores the plan in a file, along with its hash.
+// Domains are sorted by name, and the registrar is listed after the DNS providers.
+func (p *Plan) Write(filename string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	sort.Slice(p.Domains, func(i, j int) bool {
+		return p.Domains[i].Name < p.Domains[j].Name
+	})
+	for _, d := range p.Domains {
+		provs := d.Providers
+		sort.Slice(provs, func(i, j int) bool {
+			if provs[i].Registrar != provs[j].Registrar {
+				return !provs[i].Registrar
+			}
+			return provs[i].Name < provs[j].Name
+		})
+	}
+	key := os.Getenv(KeyEnvVar)
+	hash, err := p.digest(key)
+	if err != nil {
+		return err
+	}
+	p.Signed = key != ""
+	p.Hash = hash
+	dat, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filename, dat, 0600)
+}
+
+// Read loads a plan from a file, and verifies it has not been altered since it was written.
+func Read(filename string) (*Plan, error) {
+	dat, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	p := &Plan{}
+	if err = json.Unmarshal(dat, p); err != nil {
+		return nil, fmt.Errorf("Parsing plan %s: %s", filename, err)
+	}
+	if p.Version != Version {
+		return nil, fmt.Errorf("Plan %s has version %d, expected %d", filename, p.Version, Version)
+	}
+	key := os.Getenv(KeyEnvVar)
+	if p.Signed && key == "" {
+		return nil, fmt.Errorf("Plan %s is signed, but %s is not set", filename, KeyEnvVar)
+	}
+	if !p.Signed && key != "" {
+		return nil, fmt.Errorf("Plan %s is not signed, but %s is set", filename, KeyEnvVar)
+	}
+	expected, err := p.digest(key)
+	if err != nil {
+		return nil, err
+	}
+	if !hmac.Equal([]byte(strings.ToLower(p.Hash)), []byte(expected)) {
+		return nil, fmt.Errorf("Plan %s has been modified or has an invalid signature", filename)
+	}
+	return p, nil
+}
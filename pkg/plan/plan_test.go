@@ -0,0 +1,114 @@
+package plan
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/StackExchange/dnscontrol/models"
+)
+
+func corrections(msgs ...string) []*models.Correction {
+	cs := []*models.Correction{}
+	for _, m := range msgs {
+		cs = append(cs, &models.Correction{Msg: m})
+	}
+	return cs
+}
+
+func writeTemp(t *testing.T, p *Plan) string {
+	dir, err := ioutil.TempDir("", "plan")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fn := filepath.Join(dir, "test.plan")
+	if err := p.Write(fn); err != nil {
+		t.Fatal(err)
+	}
+	return fn
+}
+
+func TestRoundTrip(t *testing.T) {
+	p := New()
+	p.Add("example.com", "bind", false, corrections("CREATE A www", "DELETE A old"))
+	p.Add("example.com", "reg", true, nil)
+	fn := writeTemp(t, p)
+	defer os.RemoveAll(filepath.Dir(fn))
+
+	p2, err := Read(fn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := p2.Verify("example.com", "bind", false, corrections("CREATE A www", "DELETE A old")); err != nil {
+		t.Errorf("Expected plan to verify: %s", err)
+	}
+	if err := p2.Verify("example.com", "reg", true, nil); err != nil {
+		t.Errorf("Expected empty registrar entry to verify: %s", err)
+	}
+	if err := p2.Verify("other.com", "bind", false, nil); err != nil {
+		t.Errorf("Expected missing entry without corrections to verify: %s", err)
+	}
+}
+
+func TestVerifyDetectsChanges(t *testing.T) {
+	p := New()
+	p.Add("example.com", "bind", false, corrections("CREATE A www"))
+	tests := []struct {
+		desc        string
+		domain      string
+		corrections []*models.Correction
+	}{
+		{"different message", "example.com", corrections("CREATE A www2")},
+		{"additional correction", "example.com", corrections("CREATE A www", "DELETE A old")},
+		{"no corrections", "example.com", nil},
+		{"unplanned domain", "other.com", corrections("CREATE A www")},
+	}
+	for _, tst := range tests {
+		t.Run(tst.desc, func(t *testing.T) {
+			if err := p.Verify(tst.domain, "bind", false, tst.corrections); err == nil {
+				t.Fatal("Expected error but found none")
+			}
+		})
+	}
+}
+
+func TestTamperedPlan(t *testing.T) {
+	p := New()
+	p.Add("example.com", "bind", false, corrections("CREATE A www 1.2.3.4"))
+	fn := writeTemp(t, p)
+	defer os.RemoveAll(filepath.Dir(fn))
+
+	dat, err := ioutil.ReadFile(fn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dat = []byte(strings.Replace(string(dat), "1.2.3.4", "6.6.6.6", 1))
+	if err := ioutil.WriteFile(fn, dat, 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Read(fn); err == nil {
+		t.Fatal("Expected modified plan to be rejected")
+	}
+}
+
+func TestSignedPlan(t *testing.T) {
+	os.Setenv(KeyEnvVar, "secret")
+	p := New()
+	p.Add("example.com", "bind", false, corrections("CREATE A www"))
+	fn := writeTemp(t, p)
+	defer os.RemoveAll(filepath.Dir(fn))
+
+	if _, err := Read(fn); err != nil {
+		t.Fatal(err)
+	}
+	os.Setenv(KeyEnvVar, "other")
+	if _, err := Read(fn); err == nil {
+		t.Error("Expected plan with wrong key to be rejected")
+	}
+	os.Unsetenv(KeyEnvVar)
+	if _, err := Read(fn); err == nil {
+		t.Error("Expected signed plan without key to be rejected")
+	}
+}
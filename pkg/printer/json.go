@@ -0,0 +1,142 @@
+package printer
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/StackExchange/dnscontrol/models"
+)
+
+// Event is a single line of output of the JSONPrinter.
+type Event struct {
+	Event       string          `json:"event"`
+	Domain      string          `json:"domain,omitempty"`
+	Provider    string          `json:"provider,omitempty"`
+	Registrar   bool            `json:"registrar,omitempty"`
+	Skip        bool            `json:"skip,omitempty"`
+	Index       int             `json:"index,omitempty"`
+	Corrections *int            `json:"corrections,omitempty"`
+	Message     string          `json:"message,omitempty"`
+	Kind        string          `json:"kind,omitempty"`
+	Changes     []models.Change `json:"changes,omitempty"`
+	Success     *bool           `json:"success,omitempty"`
+	Error       string          `json:"error,omitempty"`
+	Unknown     bool            `json:"unknown,omitempty"`
+}
+
+// Event types emitted by the JSONPrinter.
+const (
+	EventDomain           = "domain_start"
+	EventDomainEnd        = "domain_end"
+	EventProvider         = "provider_start"
+	EventProviderEnd      = "provider_end"
+	EventCorrection       = "correction"
+	EventCorrectionResult = "correction_result"
+	EventDebug            = "debug"
+	EventWarning          = "warning"
+	EventError            = "error"
+)
+
+// JSONPrinter is a CLI that writes every event as one line of JSON (NDJSON),
+// for consumption by other programs.
+type JSONPrinter struct {
+	mu         sync.Mutex
+	send       func(Event)
+	domain     string
+	provider   string
+	registrar  bool
+	correction int
+}
+
+// NewJSONPrinter returns a JSONPrinter writing to w.
+func NewJSONPrinter(w io.Writer) *JSONPrinter {
+	enc := json.NewEncoder(w)
+	return &JSONPrinter{send: func(e Event) { enc.Encode(e) }}
+}
+
+// NewEventPrinter returns a JSONPrinter that passes every event to send instead of writing it,
+// for programs that embed DNSControl. send is never called concurrently.
+func NewEventPrinter(send func(Event)) *JSONPrinter {
+	return &JSONPrinter{send: send}
+}
+
+func (j *JSONPrinter) emit(e Event) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if e.Domain == "" {
+		e.Domain = j.domain
+	}
+	j.send(e)
+}
+
+func errString(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
+
+// StartDomain is called at the start of each domain.
+func (j *JSONPrinter) StartDomain(domain string) {
+	j.domain, j.provider = domain, ""
+	j.emit(Event{Event: EventDomain})
+}
+
+// EndDomain is called at the end of each domain.
+func (j *JSONPrinter) EndDomain(domain string) {
+	j.emit(Event{Event: EventDomainEnd})
+	j.domain, j.provider = "", ""
+}
+
+// StartDNSProvider is called at the start of each new provider.
+func (j *JSONPrinter) StartDNSProvider(name string, skip bool) {
+	j.provider, j.registrar = name, false
+	j.emit(Event{Event: EventProvider, Provider: name, Skip: skip})
+}
+
+// StartRegistrar is called at the start of each new registrar.
+func (j *JSONPrinter) StartRegistrar(name string, skip bool) {
+	j.provider, j.registrar = name, true
+	j.emit(Event{Event: EventProvider, Provider: name, Registrar: true, Skip: skip})
+}
+
+// EndProvider is called at the end of each provider.
+func (j *JSONPrinter) EndProvider(numCorrections int, err error) {
+	j.emit(Event{Event: EventProviderEnd, Provider: j.provider, Registrar: j.registrar, Corrections: &numCorrections, Error: errString(err)})
+}
+
+// PrintCorrection is called to print/format each correction.
+func (j *JSONPrinter) PrintCorrection(n int, c *models.Correction) {
+	j.correction = n + 1
+	j.emit(Event{Event: EventCorrection, Provider: j.provider, Registrar: j.registrar, Index: j.correction, Message: c.Msg, Kind: string(c.Kind), Changes: c.Changes})
+}
+
+// EndCorrection is called at the end of each correction.
+func (j *JSONPrinter) EndCorrection(err error) {
+	success := err == nil
+	_, unknown := err.(*models.OutcomeUnknownError)
+	j.emit(Event{Event: EventCorrectionResult, Provider: j.provider, Registrar: j.registrar, Index: j.correction, Success: &success, Error: errString(err), Unknown: unknown})
+}
+
+// PromptToRun is not supported, as stdin and stdout are meant for other programs.
+func (j *JSONPrinter) PromptToRun() bool {
+	panic("printer.JSONPrinter can not prompt the user")
+}
+
+// Debugf is called to print/format debug information.
+func (j *JSONPrinter) Debugf(format string, args ...interface{}) {
+	j.emit(Event{Event: EventDebug, Message: strings.TrimSpace(fmt.Sprintf(format, args...))})
+}
+
+// Warnf is called to print/format a warning.
+func (j *JSONPrinter) Warnf(format string, args ...interface{}) {
+	j.emit(Event{Event: EventWarning, Message: strings.TrimSpace(fmt.Sprintf(format, args...))})
+}
+
+// Errorf is called to print/format an error.
+func (j *JSONPrinter) Errorf(format string, args ...interface{}) {
+	j.emit(Event{Event: EventError, Message: strings.TrimSpace(fmt.Sprintf(format, args...))})
+}
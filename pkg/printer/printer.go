@@ -13,6 +13,7 @@ import (
 type CLI interface {
 	Printer
 	StartDomain(domain string)
+	EndDomain(domain string)
 	StartDNSProvider(name string, skip bool)
 	EndProvider(numCorrections int, err error)
 	StartRegistrar(name string, skip bool)
@@ -20,6 +21,8 @@ type CLI interface {
 	PrintCorrection(n int, c *models.Correction)
 	EndCorrection(err error)
 	PromptToRun() bool
+
+	Errorf(fmt string, args ...interface{})
 }
 
 // Printer is a simple abstraction for printing data. Can be passed to providers to give simple output capabilities.
@@ -38,6 +41,9 @@ func (c ConsolePrinter) StartDomain(domain string) {
 	fmt.Printf("******************** Domain: %s\n", domain)
 }
 
+// EndDomain is called at the end of each domain.
+func (c ConsolePrinter) EndDomain(domain string) {}
+
 // PrintCorrection is called to print/format each correction.
 func (c ConsolePrinter) PrintCorrection(i int, correction *models.Correction) {
 	fmt.Printf("#%d: %s\n", i+1, correction.Msg)
@@ -111,3 +117,8 @@ func (c ConsolePrinter) Debugf(format string, args ...interface{}) {
 func (c ConsolePrinter) Warnf(format string, args ...interface{}) {
 	fmt.Printf("WARNING: "+format, args...)
 }
+
+// Errorf is called to print/format an error.
+func (c ConsolePrinter) Errorf(format string, args ...interface{}) {
+	fmt.Printf("ERROR: "+format, args...)
+}
@@ -0,0 +1,86 @@
+package printer
+
+import (
+	"sync"
+
+	"github.com/StackExchange/dnscontrol/models"
+)
+
+// Recorder is a CLI that remembers everything printed to it, so it can be replayed
+// on another CLI later. Domains and providers that are processed in parallel each print
+// to their own Recorder, which keeps their output together.
+type Recorder struct {
+	mu    sync.Mutex
+	calls []func(CLI)
+}
+
+func (r *Recorder) record(f func(CLI)) {
+	r.mu.Lock()
+	r.calls = append(r.calls, f)
+	r.mu.Unlock()
+}
+
+// Replay prints everything recorded so far to out.
+func (r *Recorder) Replay(out CLI) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, f := range r.calls {
+		f(out)
+	}
+	r.calls = nil
+}
+
+// StartDomain is called at the start of each domain.
+func (r *Recorder) StartDomain(domain string) {
+	r.record(func(out CLI) { out.StartDomain(domain) })
+}
+
+// EndDomain is called at the end of each domain.
+func (r *Recorder) EndDomain(domain string) {
+	r.record(func(out CLI) { out.EndDomain(domain) })
+}
+
+// StartDNSProvider is called at the start of each new provider.
+func (r *Recorder) StartDNSProvider(name string, skip bool) {
+	r.record(func(out CLI) { out.StartDNSProvider(name, skip) })
+}
+
+// EndProvider is called at the end of each provider.
+func (r *Recorder) EndProvider(numCorrections int, err error) {
+	r.record(func(out CLI) { out.EndProvider(numCorrections, err) })
+}
+
+// StartRegistrar is called at the start of each new registrar.
+func (r *Recorder) StartRegistrar(name string, skip bool) {
+	r.record(func(out CLI) { out.StartRegistrar(name, skip) })
+}
+
+// PrintCorrection is called to print/format each correction.
+func (r *Recorder) PrintCorrection(n int, c *models.Correction) {
+	r.record(func(out CLI) { out.PrintCorrection(n, c) })
+}
+
+// EndCorrection is called at the end of each correction.
+func (r *Recorder) EndCorrection(err error) {
+	r.record(func(out CLI) { out.EndCorrection(err) })
+}
+
+// PromptToRun can not be recorded. Interactive runs must use the real CLI.
+func (r *Recorder) PromptToRun() bool {
+	panic("printer.Recorder can not prompt the user")
+}
+
+// Debugf is called to print/format debug information.
+func (r *Recorder) Debugf(format string, args ...interface{}) {
+	r.record(func(out CLI) { out.Debugf(format, args...) })
+}
+
+// Warnf is called to print/format a warning.
+func (r *Recorder) Warnf(format string, args ...interface{}) {
+	r.record(func(out CLI) { out.Warnf(format, args...) })
+}
+
+// Errorf is called to print/format an error.
+func (r *Recorder) Errorf(format string, args ...interface{}) {
+	r.record(func(out CLI) { out.Errorf(format, args...) })
+}
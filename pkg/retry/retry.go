@@ -0,0 +1,144 @@
+// Package retry repeats provider API calls that failed for temporary reasons,
+// such as rate limits (HTTP 429) or an overloaded server (HTTP 5xx).
+//
+// Attempts are spaced by exponential backoff with jitter. Reads may be retried
+// after any temporary error, but corrections only when the provider clearly
+// rejected the request (see RateLimited), as a correction that failed with, say,
+// a 502 may have been made anyway.
+package retry
+
+import (
+	"context"
+	"math/rand"
+	"net"
+	"strings"
+	"time"
+)
+
+// Policy says how often and how long to retry.
+type Policy struct {
+	MaxAttempts int           // including the first; 1 or less disables retries
+	Delay       time.Duration // before the second attempt; doubles after every attempt
+	MaxDelay    time.Duration // upper limit of the delay; 0 means no limit
+}
+
+// Default is used for providers that do not configure a policy in creds.json.
+var Default = Policy{MaxAttempts: 3, Delay: time.Second, MaxDelay: 30 * time.Second}
+
+// Classifier decides whether an error is worth another attempt.
+type Classifier func(err error) bool
+
+// RetryAfter may be implemented by errors that know how long to wait, for
+// example from a Retry-After HTTP header.
+type RetryAfter interface {
+	RetryAfter() time.Duration
+}
+
+// NotifyFunc is called before waiting for the next attempt.
+type NotifyFunc func(err error, attempt, maxAttempts int, wait time.Duration)
+
+type notifyKey struct{}
+
+// WithNotify returns a context that makes Do report retries to notify.
+func WithNotify(ctx context.Context, notify NotifyFunc) context.Context {
+	return context.WithValue(ctx, notifyKey{}, notify)
+}
+
+// Do calls f until it succeeds, returns an error that retryable rejects, or the
+// policy runs out of attempts. It returns the error of the last attempt, or
+// ctx.Err() if ctx is done while waiting.
+func (p Policy) Do(ctx context.Context, retryable Classifier, f func() error) error {
+	notify, _ := ctx.Value(notifyKey{}).(NotifyFunc)
+	for attempt := 1; ; attempt++ {
+		err := f()
+		if err == nil || attempt >= p.MaxAttempts || !retryable(err) || ctx.Err() != nil {
+			return err
+		}
+		wait := p.backoff(attempt)
+		if ra, ok := err.(RetryAfter); ok && ra.RetryAfter() > wait {
+			wait = ra.RetryAfter()
+		}
+		if notify != nil {
+			notify(err, attempt, p.MaxAttempts, wait)
+		}
+		t := time.NewTimer(wait)
+		select {
+		case <-t.C:
+		case <-ctx.Done():
+			t.Stop()
+			return ctx.Err()
+		}
+	}
+}
+
+// backoff returns the delay after the given attempt: Delay * 2^(attempt-1),
+// limited to MaxDelay, of which a random part of up to one half is taken off
+// so that parallel callers do not retry in lockstep.
+func (p Policy) backoff(attempt int) time.Duration {
+	d := p.Delay
+	for i := 1; i < attempt && (p.MaxDelay <= 0 || d < p.MaxDelay); i++ {
+		d *= 2
+	}
+	if p.MaxDelay > 0 && d > p.MaxDelay {
+		d = p.MaxDelay
+	}
+	if half := int64(d / 2); half > 0 {
+		d -= time.Duration(rand.Int63n(half))
+	}
+	return d
+}
+
+// rateLimitMessages are (lowercase) parts of error messages that mean the
+// request was rejected without being processed.
+var rateLimitMessages = []string{
+	"too many requests", // HTTP 429
+	"rate limit",
+	"ratelimit",
+	"rate exceeded",
+	"throttl",
+	"service unavailable", // HTTP 503
+}
+
+// temporaryMessages are (lowercase) parts of error messages that mean
+// the request failed, but may succeed if tried again.
+var temporaryMessages = []string{
+	"internal server error", // HTTP 500
+	"bad gateway",           // HTTP 502
+	"gateway timeout",       // HTTP 504
+	"connection reset",
+	"connection refused",
+	"unexpected eof",
+	"i/o timeout",
+}
+
+// RateLimited reports whether err says the provider refused the request because
+// of rate limits or overload, so that retrying it can not make a change twice.
+func RateLimited(err error) bool {
+	if err == nil {
+		return false
+	}
+	return containsAny(strings.ToLower(err.Error()), rateLimitMessages)
+}
+
+// Temporary reports whether err may go away when the request is tried again.
+func Temporary(err error) bool {
+	if err == nil || err == context.Canceled || err == context.DeadlineExceeded {
+		return false
+	}
+	if RateLimited(err) {
+		return true
+	}
+	if ne, ok := err.(net.Error); ok && (ne.Timeout() || ne.Temporary()) {
+		return true
+	}
+	return containsAny(strings.ToLower(err.Error()), temporaryMessages)
+}
+
+func containsAny(s string, parts []string) bool {
+	for _, p := range parts {
+		if strings.Contains(s, p) {
+			return true
+		}
+	}
+	return false
+}
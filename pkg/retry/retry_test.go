@@ -0,0 +1,102 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+var fast = Policy{MaxAttempts: 4, Delay: time.Millisecond, MaxDelay: 4 * time.Millisecond}
+
+func TestDo(t *testing.T) {
+	tests := []struct {
+		name     string
+		errs     []error // returned by the attempts; nil after the last one
+		attempts int
+		err      bool
+	}{
+		{"success", nil, 1, false},
+		{"retried", []error{errors.New("429 Too Many Requests")}, 2, false},
+		{"permanent", []error{errors.New("record not found")}, 1, true},
+		{"exhausted", []error{
+			errors.New("rate limit"), errors.New("rate limit"), errors.New("rate limit"), errors.New("rate limit"), errors.New("rate limit"),
+		}, 4, true},
+	}
+	for _, tst := range tests {
+		t.Run(tst.name, func(t *testing.T) {
+			attempts, notified := 0, 0
+			ctx := WithNotify(context.Background(), func(err error, attempt, max int, wait time.Duration) {
+				notified++
+				if attempt != attempts || max != fast.MaxAttempts {
+					t.Errorf("notified attempt %d of %d after %d attempts", attempt, max, attempts)
+				}
+			})
+			err := fast.Do(ctx, Temporary, func() error {
+				attempts++
+				if attempts <= len(tst.errs) {
+					return tst.errs[attempts-1]
+				}
+				return nil
+			})
+			if (err != nil) != tst.err {
+				t.Errorf("expected error %v, got %v", tst.err, err)
+			}
+			if attempts != tst.attempts {
+				t.Errorf("expected %d attempts, got %d", tst.attempts, attempts)
+			}
+			if notified != attempts-1 {
+				t.Errorf("expected %d notifications, got %d", attempts-1, notified)
+			}
+		})
+	}
+}
+
+func TestDoCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	p := Policy{MaxAttempts: 3, Delay: time.Hour}
+	ctx = WithNotify(ctx, func(error, int, int, time.Duration) { cancel() })
+	err := p.Do(ctx, Temporary, func() error { return errors.New("service unavailable") })
+	if err != context.Canceled {
+		t.Errorf("expected %v, got %v", context.Canceled, err)
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	p := Policy{Delay: time.Second, MaxDelay: 10 * time.Second}
+	for i, max := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second, 10 * time.Second, 10 * time.Second} {
+		attempt := i + 1
+		d := p.backoff(attempt)
+		if d > max || d < max/2 {
+			t.Errorf("attempt %d: expected between %s and %s, got %s", attempt, max/2, max, d)
+		}
+	}
+}
+
+func TestClassifiers(t *testing.T) {
+	tests := []struct {
+		err         string
+		rateLimited bool
+		temporary   bool
+	}{
+		{"Error 500000: Too many requests", true, true},
+		{"Throttling: Rate exceeded", true, true},
+		{"503 Service Unavailable", true, true},
+		{"502 Bad Gateway", false, true},
+		{"read tcp 10.0.0.1:443: connection reset by peer", false, true},
+		{"record 14290 not found", false, false},
+		{"invalid credentials", false, false},
+	}
+	for _, tst := range tests {
+		err := errors.New(tst.err)
+		if RateLimited(err) != tst.rateLimited {
+			t.Errorf("RateLimited(%q): expected %v", tst.err, tst.rateLimited)
+		}
+		if Temporary(err) != tst.temporary {
+			t.Errorf("Temporary(%q): expected %v", tst.err, tst.temporary)
+		}
+	}
+	if Temporary(context.DeadlineExceeded) {
+		t.Errorf("Temporary(DeadlineExceeded): expected false")
+	}
+}
@@ -0,0 +1,189 @@
+package zoneformat
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/StackExchange/dnscontrol/models"
+	"github.com/StackExchange/dnscontrol/providers/bind"
+	"github.com/miekg/dns"
+	"github.com/miekg/dns/dnsutil"
+)
+
+const (
+	colorRemoved = "\x1b[31m"
+	colorAdded   = "\x1b[32m"
+	colorHunk    = "\x1b[36m"
+	colorReset   = "\x1b[0m"
+)
+
+// WriteDiff renders the change from the before to the after records as a unified diff of
+// their zonefiles, in the order bind.WriteZoneFile writes them. Every label that changes gets
+// its own hunk, with the unchanged records of the label as context. Labels that do not change
+// are omitted. If color is set, removed and added lines are colored for a terminal.
+func WriteDiff(w io.Writer, before, after models.Records, origin string, color bool) error {
+	origin = dnsutil.AddOrigin(origin, ".")
+	b, a := linesByLabel(before, after, origin)
+	labels := []string{}
+	for l := range b {
+		labels = append(labels, l)
+	}
+	for l := range a {
+		if _, ok := b[l]; !ok {
+			labels = append(labels, l)
+		}
+	}
+	sort.Slice(labels, func(i, j int) bool { return bind.ZoneLabelLess(labels[i], labels[j]) })
+
+	paint := func(c, s string) string {
+		if !color {
+			return s
+		}
+		return c + s + colorReset
+	}
+	header := false
+	for _, l := range labels {
+		ops := diffLines(b[l], a[l])
+		if !changed(ops) {
+			continue
+		}
+		if !header {
+			header = true
+			if _, err := fmt.Fprintf(w, "%s\n%s\n", paint(colorRemoved, "--- "+origin+" (existing)"), paint(colorAdded, "+++ "+origin+" (desired)")); err != nil {
+				return err
+			}
+		}
+		if _, err := fmt.Fprintln(w, paint(colorHunk, "@@ "+l+" @@")); err != nil {
+			return err
+		}
+		for _, op := range ops {
+			line := string(op.kind) + op.line
+			switch op.kind {
+			case '-':
+				line = paint(colorRemoved, line)
+			case '+':
+				line = paint(colorAdded, line)
+			}
+			if _, err := fmt.Fprintln(w, line); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// linesByLabel formats the records of both sides as zonefile lines, grouped by label.
+// Both sides use the same $TTL, the most common TTL of the records before the change,
+// so that the TTL column only differs for records whose TTL changes.
+func linesByLabel(before, after models.Records, origin string) (b, a map[string][]string) {
+	type side struct {
+		rrs    map[string][]dns.RR
+		pseudo map[string][]*models.RecordConfig
+	}
+	convert := func(records models.Records) (side, []dns.RR) {
+		s := side{map[string][]dns.RR{}, map[string][]*models.RecordConfig{}}
+		all := []dns.RR{}
+		for _, rc := range records {
+			label := dnsutil.TrimDomainName(dnsutil.AddOrigin(rc.NameFQDN, "."), origin)
+			if _, ok := dns.StringToType[rc.Type]; ok {
+				rr, err := dns.NewRR(fmt.Sprintf("%s %d IN %s %s", dnsutil.AddOrigin(rc.NameFQDN, "."), rc.TTL, rc.Type, rc.Content()))
+				if err == nil && rr != nil {
+					s.rrs[label] = append(s.rrs[label], rr)
+					all = append(all, rr)
+					continue
+				}
+			}
+			// pseudo records (ALIAS, R53_ALIAS, ...) can not be parsed; they follow the others of their label.
+			s.pseudo[label] = append(s.pseudo[label], rc)
+		}
+		return s, all
+	}
+	sb, allBefore := convert(before)
+	sa, allAfter := convert(after)
+	if len(allBefore) == 0 {
+		allBefore = allAfter
+	}
+	defaultTTL := bind.MostCommonTTL(allBefore)
+
+	format := func(s side) map[string][]string {
+		lines := map[string][]string{}
+		for label, rrs := range s.rrs {
+			bind.SortRecords(rrs, origin)
+			for _, rr := range rrs {
+				lines[label] = append(lines[label], bind.FormatRecord(rr, origin, defaultTTL))
+			}
+		}
+		for label, ps := range s.pseudo {
+			pl := []string{}
+			for _, rc := range ps {
+				ttl := ""
+				if rc.TTL != defaultTTL {
+					ttl = fmt.Sprint(rc.TTL)
+				}
+				pl = append(pl, bind.FormatLine(label, ttl, "IN", rc.Type, rc.Content()))
+			}
+			sort.Strings(pl)
+			lines[label] = append(lines[label], pl...)
+		}
+		return lines
+	}
+	return format(sb), format(sa)
+}
+
+type lineOp struct {
+	kind byte // ' ', '-' or '+'
+	line string
+}
+
+func changed(ops []lineOp) bool {
+	for _, op := range ops {
+		if op.kind != ' ' {
+			return true
+		}
+	}
+	return false
+}
+
+// diffLines computes a minimal line diff, using the longest common subsequence.
+// The records of a single label are few, so the quadratic cost does not matter.
+func diffLines(a, b []string) []lineOp {
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+	ops := []lineOp{}
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			ops = append(ops, lineOp{' ', a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			ops = append(ops, lineOp{'-', a[i]})
+			i++
+		default:
+			ops = append(ops, lineOp{'+', b[j]})
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		ops = append(ops, lineOp{'-', a[i]})
+	}
+	for ; j < len(b); j++ {
+		ops = append(ops, lineOp{'+', b[j]})
+	}
+	return ops
+}
@@ -0,0 +1,61 @@
+package zoneformat
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/StackExchange/dnscontrol/models"
+)
+
+func rec(name, rtype, target string, ttl uint32) *models.RecordConfig {
+	r := &models.RecordConfig{Type: rtype, NameFQDN: name, Target: target, TTL: ttl}
+	if rtype == "TXT" {
+		r.TxtStrings = []string{target}
+	}
+	return r
+}
+
+func TestWriteDiff(t *testing.T) {
+	same := rec("example.com", "A", "1.2.3.4", 300)
+	before := models.Records{
+		same,
+		rec("www.example.com", "A", "1.2.3.7", 300),
+		rec("old.example.com", "CNAME", "www.example.com.", 300),
+		rec("mail.example.com", "A", "1.2.3.9", 300),
+	}
+	after := models.Records{
+		same,
+		rec("www.example.com", "A", "1.2.3.8", 300),
+		rec("www.example.com", "TXT", "hello", 300),
+		rec("mail.example.com", "A", "1.2.3.9", 300),
+		rec("example.com", "ALIAS", "other.example.net.", 600),
+	}
+	buf := &bytes.Buffer{}
+	if err := WriteDiff(buf, before, after, "example.com", false); err != nil {
+		t.Fatal(err)
+	}
+	expected := `--- example.com. (existing)
++++ example.com. (desired)
+@@ @ @@
+ @                IN A     1.2.3.4
++@          600   IN ALIAS other.example.net.
+@@ old @@
+-old              IN CNAME www.example.com.
+@@ www @@
+-www              IN A     1.2.3.7
++www              IN A     1.2.3.8
++www              IN TXT   "hello"
+`
+	if buf.String() != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, buf.String())
+	}
+
+	buf.Reset()
+	if err := WriteDiff(buf, before, before, "example.com", true); err != nil || buf.Len() != 0 {
+		t.Errorf("expected no output without changes, got %q, %v", buf.String(), err)
+	}
+	if err := WriteDiff(buf, before, after, "example.com", true); err != nil || !strings.Contains(buf.String(), colorAdded+"+www") {
+		t.Errorf("expected colored output, got %q, %v", buf.String(), err)
+	}
+}
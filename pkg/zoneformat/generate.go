@@ -0,0 +1,199 @@
+package zoneformat
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/miekg/dns/dnsutil"
+)
+
+// Generate is a $GENERATE directive of a zonefile, which WriteDSL renders as a GENERATE() call.
+type Generate struct {
+	Range string // start-stop[/step]
+	Owner string // relative to the zone
+	TTL   uint32 // 0 if neither the directive nor the zonefile before it has one
+	Type  string
+	Data  []string // the fields of the rdata, unquoted
+}
+
+// generateTypes lists the types ExtractGenerates can render, and the number of fields of their rdata.
+var generateTypes = map[string]int{"A": 1, "AAAA": 1, "CNAME": 1, "NS": 1, "PTR": 1, "TXT": 1, "MX": 2, "SRV": 4}
+
+// ExtractGenerates removes the $GENERATE directives from a zonefile, and returns them.
+// Directives it can not render are left in the zonefile, to be expanded when it is parsed.
+// A directive without a TTL gets the one of $TTL, or of the record before it.
+// If the zonefile changes the origin, or includes other files, it is returned unchanged.
+func ExtractGenerates(zone, zonename string) (rest string, gens []Generate) {
+	origin := dnsutil.AddOrigin(zonename, ".")
+	lines := strings.Split(zone, "\n")
+	for _, line := range lines {
+		f := strings.Fields(line)
+		if len(f) == 0 {
+			continue
+		}
+		switch strings.ToUpper(f[0]) {
+		case "$INCLUDE":
+			return zone, nil
+		case "$ORIGIN":
+			if len(f) < 2 || !strings.EqualFold(dnsutil.AddOrigin(f[1], "."), origin) {
+				return zone, nil
+			}
+		}
+	}
+	// A record without a TTL gets the one of $TTL or, without $TTL, the one of the
+	// previous record, like the parser does. So does a $GENERATE without a TTL.
+	var ttl uint32
+	var ttlByDirective bool
+	for i, line := range lines {
+		f := strings.Fields(strings.SplitN(line, ";", 2)[0])
+		switch {
+		case len(f) == 0:
+		case strings.EqualFold(f[0], "$TTL"):
+			if len(f) > 1 {
+				if t, ok := parseTTL(f[1]); ok {
+					ttl, ttlByDirective = t, true
+				}
+			}
+		case strings.EqualFold(f[0], "$GENERATE"):
+			g, ok := parseGenerate(line, origin)
+			if !ok {
+				break
+			}
+			if g.TTL == 0 {
+				g.TTL = ttl
+			} else if !ttlByDirective {
+				ttl = g.TTL
+			}
+			gens = append(gens, g)
+			lines[i] = "" // keep the line numbers of the errors of the parser
+		case strings.HasPrefix(f[0], "$"):
+		default:
+			if line[0] != ' ' && line[0] != '\t' {
+				f = f[1:] // the owner
+			}
+			for _, field := range f {
+				t, ok := parseTTL(field)
+				if ok && !ttlByDirective {
+					ttl = t
+				}
+				if !ok && !strings.EqualFold(field, "IN") {
+					break
+				}
+			}
+		}
+	}
+	return strings.Join(lines, "\n"), gens
+}
+
+// parseTTL parses a TTL of a zonefile, in seconds or with units like 1h30m.
+func parseTTL(s string) (uint32, bool) {
+	if s == "" || s[0] < '0' || s[0] > '9' {
+		return 0, false
+	}
+	var ttl, n uint32
+	for _, c := range strings.ToLower(s) {
+		switch c {
+		case 's':
+			ttl, n = ttl+n, 0
+		case 'm':
+			ttl, n = ttl+n*60, 0
+		case 'h':
+			ttl, n = ttl+n*3600, 0
+		case 'd':
+			ttl, n = ttl+n*86400, 0
+		case 'w':
+			ttl, n = ttl+n*604800, 0
+		default:
+			if c < '0' || c > '9' {
+				return 0, false
+			}
+			n = n*10 + uint32(c-'0')
+		}
+	}
+	return ttl + n, true
+}
+
+// parseGenerate parses "$GENERATE range lhs [ttl] [class] type rhs".
+func parseGenerate(line, origin string) (g Generate, ok bool) {
+	f, ok := splitFields(line)
+	if !ok || len(f) < 5 {
+		return g, false
+	}
+	g.Range, g.Owner, f = f[1], f[2], f[3:]
+	switch {
+	case g.Owner == "@" || strings.EqualFold(g.Owner, origin):
+		g.Owner = "@"
+	case strings.HasSuffix(strings.ToLower(g.Owner), "."+strings.ToLower(origin)):
+		g.Owner = g.Owner[:len(g.Owner)-len(origin)-1]
+	case strings.HasSuffix(g.Owner, "."):
+		return g, false // not in the zone
+	}
+	for len(f) > 0 {
+		if ttl, ok := parseTTL(f[0]); ok {
+			g.TTL = ttl
+		} else if !strings.EqualFold(f[0], "IN") {
+			break
+		}
+		f = f[1:]
+	}
+	if len(f) < 2 {
+		return g, false
+	}
+	g.Type, g.Data = strings.ToUpper(f[0]), f[1:]
+	if n, ok := generateTypes[g.Type]; !ok || len(g.Data) != n {
+		return g, false
+	}
+	// The priority of MX, and the priority, weight and port of SRV, must be numbers.
+	for _, d := range g.Data[:len(g.Data)-1] {
+		if _, err := strconv.ParseUint(d, 10, 16); err != nil {
+			return g, false
+		}
+	}
+	return g, true
+}
+
+// splitFields splits a line of a zonefile into fields, without the comment.
+// A quoted field is unquoted. It returns false for quotes it does not understand.
+func splitFields(line string) ([]string, bool) {
+	fields := []string{}
+	for {
+		line = strings.TrimLeft(line, " \t\r")
+		switch {
+		case line == "" || line[0] == ';':
+			return fields, true
+		case line[0] == '"':
+			end := strings.IndexByte(line[1:], '"')
+			if end < 0 || strings.Contains(line[1:end+1], `\`) {
+				return nil, false
+			}
+			fields = append(fields, line[1:end+1])
+			line = line[end+2:]
+		case line[0] == '(':
+			return nil, false
+		default:
+			end := strings.IndexAny(line, " \t\r;")
+			if end < 0 {
+				end = len(line)
+			}
+			fields = append(fields, line[:end])
+			line = line[end:]
+		}
+	}
+}
+
+// DSL renders the directive as a GENERATE() call. A TTL equal to defaultTTL is omitted.
+func (g Generate) DSL(defaultTTL uint32) string {
+	args := []string{jsString(g.Range), g.Type, jsString(g.Owner)}
+	args = append(args, g.Data[:len(g.Data)-1]...) // numbers
+	args = append(args, jsString(g.Data[len(g.Data)-1]))
+	if g.TTL != 0 && g.TTL != defaultTTL {
+		args = append(args, fmt.Sprintf("TTL(%d)", g.TTL))
+	}
+	return "GENERATE(" + strings.Join(args, ", ") + ")"
+}
+
+// jsString quotes s like the other strings of WriteDSL.
+func jsString(s string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(s) + "'"
+}
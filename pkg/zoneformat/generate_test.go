@@ -0,0 +1,94 @@
+package zoneformat
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestExtractGenerates(t *testing.T) {
+	zone := `$TTL 300
+@ IN A 1.2.3.4
+$GENERATE 1-100 host-$ A 10.0.0.$ ; pool
+$GENERATE 0-4/2 c${0,3,d}.example.com. 600 IN CNAME host-$
+$GENERATE 1-2 mx$ MX 10 mail$.example.net.
+$GENERATE 1-2 mx$ MX $ mail$.example.net.
+$GENERATE 1-2 other$.example.net. A 10.1.0.$
+$GENERATE 1-2 txt$ TXT "a $"
+`
+	rest, gens := ExtractGenerates(zone, "example.com")
+	expectedRest := `$TTL 300
+@ IN A 1.2.3.4
+
+
+
+$GENERATE 1-2 mx$ MX $ mail$.example.net.
+$GENERATE 1-2 other$.example.net. A 10.1.0.$
+
+`
+	if rest != expectedRest {
+		t.Errorf("expected zone:\n%s\ngot:\n%s", expectedRest, rest)
+	}
+	buf := &bytes.Buffer{}
+	if err := WriteDSL(buf, nil, "example.com", "REG", "DSP", 300, gens...); err != nil {
+		t.Fatal(err)
+	}
+	expected := `D("example.com", REG, DnsProvider(DSP),
+	GENERATE('1-100', A, 'host-$', '10.0.0.$'),
+	GENERATE('0-4/2', CNAME, 'c${0,3,d}', 'host-$', TTL(600)),
+	GENERATE('1-2', MX, 'mx$', 10, 'mail$.example.net.'),
+	GENERATE('1-2', TXT, 'txt$', 'a $')
+)
+`
+	if buf.String() != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, buf.String())
+	}
+}
+
+func TestExtractGeneratesTTL(t *testing.T) {
+	tests := []struct{ desc, zone, expected string }{
+		{"$TTL",
+			"$TTL 86400\nwww IN A 1.2.3.4\n$GENERATE 1-2 host-$ A 10.0.0.$\n",
+			"GENERATE('1-2', A, 'host-$', '10.0.0.$', TTL(86400))"},
+		{"$TTL with units, not changed by a record",
+			"$TTL 1h\nwww 600 IN A 1.2.3.4\n$GENERATE 1-2 host-$ A 10.0.0.$\n",
+			"GENERATE('1-2', A, 'host-$', '10.0.0.$', TTL(3600))"},
+		{"previous record",
+			"www 600 IN A 1.2.3.4\n$GENERATE 1-2 host-$ A 10.0.0.$\n",
+			"GENERATE('1-2', A, 'host-$', '10.0.0.$', TTL(600))"},
+		{"previous record, without owner",
+			"www 600 IN A 1.2.3.4\n    IN 900 A 1.2.3.5\n$GENERATE 1-2 host-$ A 10.0.0.$\n",
+			"GENERATE('1-2', A, 'host-$', '10.0.0.$', TTL(900))"},
+		{"own TTL",
+			"$TTL 86400\n$GENERATE 1-2 host-$ 1d IN A 10.0.0.$\n",
+			"GENERATE('1-2', A, 'host-$', '10.0.0.$', TTL(86400))"},
+		{"default TTL",
+			"$TTL 300\n$GENERATE 1-2 host-$ A 10.0.0.$\n",
+			"GENERATE('1-2', A, 'host-$', '10.0.0.$')"},
+		{"no TTL",
+			"$GENERATE 1-2 host-$ A 10.0.0.$\n",
+			"GENERATE('1-2', A, 'host-$', '10.0.0.$')"},
+	}
+	for _, tst := range tests {
+		t.Run(tst.desc, func(t *testing.T) {
+			_, gens := ExtractGenerates(tst.zone, "example.com")
+			if len(gens) != 1 {
+				t.Fatalf("expected 1 generate, got %d", len(gens))
+			}
+			if dsl := gens[0].DSL(300); dsl != tst.expected {
+				t.Errorf("expected %s, got %s", tst.expected, dsl)
+			}
+		})
+	}
+}
+
+func TestExtractGeneratesOrigin(t *testing.T) {
+	for _, zone := range []string{
+		"$ORIGIN sub.example.com.\n$GENERATE 1-2 host-$ A 10.0.0.$\n",
+		"$INCLUDE other.zone\n$GENERATE 1-2 host-$ A 10.0.0.$\n",
+	} {
+		rest, gens := ExtractGenerates(zone, "example.com")
+		if rest != zone || len(gens) != 0 {
+			t.Errorf("%q: expected the zone unchanged, got %q and %d generates", zone, rest, len(gens))
+		}
+	}
+}
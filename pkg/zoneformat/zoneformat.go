@@ -0,0 +1,140 @@
+// Package zoneformat renders the records of a zone in the formats understood by
+// cmd/convertzone and the get-zones command: dnscontrol DSL, a pretty BIND
+// zonefile, or TAB-separated values.
+package zoneformat
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/StackExchange/dnscontrol/models"
+	"github.com/StackExchange/dnscontrol/providers/bind"
+	"github.com/miekg/dns"
+	"github.com/miekg/dns/dnsutil"
+)
+
+// Formats lists the names of the supported output formats.
+var Formats = []string{"pretty", "dsl", "tsv"}
+
+// Write renders the records of a zone in the named format.
+// registrar and provider are the variable names used in the D() statement of the dsl format.
+func Write(w io.Writer, format string, records []dns.RR, zonename, registrar, provider string, defaultTTL uint32) error {
+	switch format {
+	case "pretty":
+		return bind.WriteZoneFile(w, records, zonename)
+	case "dsl":
+		return WriteDSL(w, records, zonename, registrar, provider, defaultTTL)
+	case "tsv":
+		return WriteTSV(w, records, zonename)
+	}
+	return fmt.Errorf("unknown format %q (expected one of %s)", format, strings.Join(Formats, ", "))
+}
+
+// WriteDSL renders the records, followed by the $GENERATE directives (see ExtractGenerates),
+// as a D() statement for dnsconfig.js. TTLs equal to defaultTTL are omitted.
+func WriteDSL(w io.Writer, records []dns.RR, zonename, registrar, provider string, defaultTTL uint32, gens ...Generate) error {
+	fmt.Fprintf(w, `D("%s", %s, DnsProvider(%s)`, zonename, registrar, provider)
+	if err := rrFormat(w, records, zonename, defaultTTL, true); err != nil {
+		return err
+	}
+	for _, g := range gens {
+		fmt.Fprintf(w, ",\n\t%s", g.DSL(defaultTTL))
+	}
+	_, err := fmt.Fprintln(w, "\n)")
+	return err
+}
+
+// WriteTSV renders the records as TAB-separated values.
+func WriteTSV(w io.Writer, records []dns.RR, zonename string) error {
+	return rrFormat(w, records, zonename, 0, false)
+}
+
+// rrFormat outputs the records in either DSL or TSV format.
+func rrFormat(w io.Writer, records []dns.RR, zonename string, defaultTTL uint32, dsl bool) error {
+	zonenamedot := zonename + "."
+
+	for _, rr := range records {
+		// Parse the formatted version.
+		line := rr.String()
+		items := strings.SplitN(line, "\t", 5)
+		if len(items) < 5 {
+			return fmt.Errorf("too few items in: %v", line)
+		}
+
+		target := items[4]
+
+		hdr := rr.Header()
+		nameFqdn := hdr.Name
+		name := dnsutil.TrimDomainName(nameFqdn, zonenamedot)
+		ttl := strconv.FormatUint(uint64(hdr.Ttl), 10)
+		classStr := dns.ClassToString[hdr.Class]
+		typeStr := dns.TypeToString[hdr.Rrtype]
+
+		// MX records should split out the prio vs. target.
+		if hdr.Rrtype == dns.TypeMX {
+			target = strings.Replace(target, " ", "\t", 1)
+		}
+
+		// NS records at the apex should be NAMESERVER() records.
+		if hdr.Rrtype == dns.TypeNS && name == "@" {
+			typeStr = "NAMESERVER"
+		}
+
+		if !dsl { // TSV format:
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", name, ttl, classStr, typeStr, target)
+			continue
+		}
+
+		// DSL format:
+		switch hdr.Rrtype { // #rtype_variations
+		case dns.TypeMX:
+			m := strings.SplitN(target, "\t", 2)
+			target = m[0] + ", '" + m[1] + "'"
+		case dns.TypeSOA:
+			continue
+		case dns.TypeTXT:
+			if len(rr.(*dns.TXT).Txt) == 1 {
+				target = `'` + rr.(*dns.TXT).Txt[0] + `'`
+			} else {
+				target = `['` + strings.Join(rr.(*dns.TXT).Txt, `', '`) + `']`
+			}
+		default:
+			target = "'" + target + "'"
+		}
+		if hdr.Ttl == defaultTTL {
+			ttl = ""
+		} else {
+			ttl = fmt.Sprintf(", TTL(%d)", hdr.Ttl)
+		}
+		fmt.Fprintf(w, ",\n\t%s('%s', %s%s)", typeStr, name, target, ttl)
+	}
+	return nil
+}
+
+// ToRR converts a record downloaded from a provider to a dns.RR.
+// Unlike RecordConfig.ToRR it accepts records with a combined target,
+// and returns an error for pseudo records (e.g. R53_ALIAS) that have no
+// zonefile representation.
+func ToRR(rc *models.RecordConfig) (dns.RR, error) {
+	if _, ok := dns.StringToType[rc.Type]; !ok {
+		return nil, fmt.Errorf("%s %s: %s is not a standard DNS type", rc.Type, rc.NameFQDN, rc.Type)
+	}
+	if rc.CombinedTarget && !(rc.Type == "TXT" && !models.IsQuoted(rc.Target)) {
+		ttl := rc.TTL
+		if ttl == 0 {
+			ttl = models.DefaultTTL
+		}
+		rr, err := dns.NewRR(fmt.Sprintf("%s. %d IN %s %s", rc.NameFQDN, ttl, rc.Type, rc.Target))
+		if err != nil {
+			return nil, fmt.Errorf("%s %s: %s", rc.Type, rc.NameFQDN, err)
+		}
+		return rr, nil
+	}
+	switch rc.Type { // #rtype_variations
+	case "A", "AAAA", "CNAME", "PTR", "MX", "NS", "SOA", "SRV", "CAA", "TLSA", "TXT":
+		return rc.ToRR(), nil
+	}
+	return nil, fmt.Errorf("%s %s: unsupported record type", rc.Type, rc.NameFQDN)
+}
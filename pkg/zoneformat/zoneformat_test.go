@@ -0,0 +1,80 @@
+package zoneformat
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/StackExchange/dnscontrol/models"
+	"github.com/miekg/dns"
+)
+
+func mustRR(t *testing.T, s string) dns.RR {
+	rr, err := dns.NewRR(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return rr
+}
+
+func TestWriteDSL(t *testing.T) {
+	records := []dns.RR{
+		mustRR(t, "example.com. 300 IN A 1.2.3.4"),
+		mustRR(t, "example.com. 300 IN NS ns1.example.net."),
+		mustRR(t, "example.com. 600 IN MX 10 mx.example.com."),
+		mustRR(t, `txt.example.com. 300 IN TXT "a" "b"`),
+	}
+	buf := &bytes.Buffer{}
+	if err := WriteDSL(buf, records, "example.com", "REG", "DSP", 300); err != nil {
+		t.Fatal(err)
+	}
+	expected := `D("example.com", REG, DnsProvider(DSP),
+	A('@', '1.2.3.4'),
+	NAMESERVER('@', 'ns1.example.net.'),
+	MX('@', 10, 'mx.example.com.', TTL(600)),
+	TXT('txt', ['a', 'b'])
+)
+`
+	if buf.String() != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, buf.String())
+	}
+}
+
+func TestWriteTSV(t *testing.T) {
+	records := []dns.RR{
+		mustRR(t, "www.example.com. 300 IN CNAME example.com."),
+	}
+	buf := &bytes.Buffer{}
+	if err := WriteTSV(buf, records, "example.com"); err != nil {
+		t.Fatal(err)
+	}
+	expected := "www\t300\tIN\tCNAME\texample.com.\n"
+	if buf.String() != expected {
+		t.Errorf("expected %q, got %q", expected, buf.String())
+	}
+}
+
+func TestToRR(t *testing.T) {
+	tests := []struct {
+		rc       *models.RecordConfig
+		expected string
+	}{
+		{&models.RecordConfig{NameFQDN: "example.com", Type: "MX", Target: "mx.example.com.", MxPreference: 5, TTL: 300}, "example.com.\t300\tIN\tMX\t5 mx.example.com."},
+		{&models.RecordConfig{NameFQDN: "example.com", Type: "MX", Target: "5 mx.example.com.", TTL: 300, CombinedTarget: true}, "example.com.\t300\tIN\tMX\t5 mx.example.com."},
+		{&models.RecordConfig{NameFQDN: "example.com", Type: "TXT", Target: `"a b"`, TTL: 300, CombinedTarget: true}, "example.com.\t300\tIN\tTXT\t\"a b\""},
+		{&models.RecordConfig{NameFQDN: "example.com", Type: "TXT", Target: "a b", TxtStrings: []string{"a b"}, TTL: 300, CombinedTarget: true}, "example.com.\t300\tIN\tTXT\t\"a b\""},
+	}
+	for i, tst := range tests {
+		rr, err := ToRR(tst.rc)
+		if err != nil {
+			t.Errorf("%d: %s", i, err)
+			continue
+		}
+		if rr.String() != tst.expected {
+			t.Errorf("%d: expected %q, got %q", i, tst.expected, rr.String())
+		}
+	}
+
+	if _, err := ToRR(&models.RecordConfig{NameFQDN: "example.com", Type: "R53_ALIAS", Target: "foo."}); err == nil {
+		t.Errorf("expected an error for a pseudo record")
+	}
+}
@@ -3,6 +3,7 @@ package activedir
 import (
 	"encoding/json"
 	"fmt"
+	"log"
 	"runtime"
 
 	"github.com/StackExchange/dnscontrol/providers"
@@ -61,6 +62,6 @@ func newDNS(config map[string]string, metadata json.RawMessage) (providers.DNSSe
 		p.adServer = srv
 		return p, nil
 	}
-	fmt.Printf("WARNING: PowerShell not available. ActiveDirectory will not be updated.\n")
+	log.Printf("WARNING: PowerShell not available. ActiveDirectory will not be updated.\n")
 	return providers.None{}, nil
 }
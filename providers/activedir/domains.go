@@ -30,6 +30,18 @@ func (c *adProvider) GetNameservers(string) ([]*models.Nameserver, error) {
 	return nil, nil
 }
 
+// GetZoneRecords gets the records of a zone.
+func (c *adProvider) GetZoneRecords(domain string) (models.Records, error) {
+	foundRecords, err := c.getExistingRecords(domain)
+	if err != nil {
+		return nil, fmt.Errorf("c.getExistingRecords(%v) failed: %v", domain, err)
+	}
+
+	// Normalize
+	models.PostProcessRecords(foundRecords)
+	return foundRecords, nil
+}
+
 // GetDomainCorrections gets existing records, diffs them against existing, and returns corrections.
 func (c *adProvider) GetDomainCorrections(dc *models.DomainConfig) ([]*models.Correction, error) {
 
@@ -42,16 +54,16 @@ func (c *adProvider) GetDomainCorrections(dc *models.DomainConfig) ([]*models.Co
 	})
 
 	// Read foundRecords:
-	foundRecords, err := c.getExistingRecords(dc.Name)
+	foundRecords, err := c.GetZoneRecords(dc.Name)
 	if err != nil {
-		return nil, fmt.Errorf("c.getExistingRecords(%v) failed: %v", dc.Name, err)
+		return nil, err
 	}
 
-	// Normalize
-	models.PostProcessRecords(foundRecords)
-
-	differ := diff.New(dc)
-	_, creates, dels, modifications := differ.IncrementalDiff(foundRecords)
+	differ := diff.NewForProvider("ACTIVEDIRECTORY_PS", dc)
+	_, creates, dels, modifications, err := differ.IncrementalDiff(foundRecords)
+	if err != nil {
+		return nil, err
+	}
 	// NOTE(tlim): This provider does not delete records.  If
 	// you need to delete a record, either delete it manually
 	// or see providers/activedir/doc.md for implementation tips.
@@ -59,13 +71,20 @@ func (c *adProvider) GetDomainCorrections(dc *models.DomainConfig) ([]*models.Co
 	// Generate changes.
 	corrections := []*models.Correction{}
 	for _, del := range dels {
-		corrections = append(corrections, c.deleteRec(dc.Name, del.Existing))
+		corr := c.deleteRec(dc.Name, del.Existing)
+		corr.Changes = []models.Change{del.Change()}
+		corrections = append(corrections, corr)
 	}
 	for _, cre := range creates {
-		corrections = append(corrections, c.createRec(dc.Name, cre.Desired)...)
+		for _, corr := range c.createRec(dc.Name, cre.Desired) {
+			corr.Changes = []models.Change{cre.Change()}
+			corrections = append(corrections, corr)
+		}
 	}
 	for _, m := range modifications {
-		corrections = append(corrections, c.modifyRec(dc.Name, m))
+		corr := c.modifyRec(dc.Name, m)
+		corr.Changes = []models.Change{m.Change()}
+		corrections = append(corrections, corr)
 	}
 	return corrections, nil
 
@@ -82,8 +101,8 @@ func (c *adProvider) readZoneDump(domainname string) ([]byte, error) {
 	// File not found is considered an error.
 	dat, err := utfutil.ReadFile(zoneDumpFilename(domainname), utfutil.WINDOWS)
 	if err != nil {
-		fmt.Println("Powershell to generate zone dump:")
-		fmt.Println(c.generatePowerShellZoneDump(domainname))
+		log.Println("Powershell to generate zone dump:")
+		log.Println(c.generatePowerShellZoneDump(domainname))
 	}
 	return dat, err
 }
@@ -191,6 +191,43 @@ func (c *Bind) GetNameservers(string) ([]*models.Nameserver, error) {
 	return c.nameservers, nil
 }
 
+func (c *Bind) zoneFileName(domain string) string {
+	return filepath.Join(c.directory, strings.Replace(strings.ToLower(domain), "/", "_", -1)+".zone")
+}
+
+// ListZones returns the zones that have a zonefile in the directory.
+func (c *Bind) ListZones() ([]string, error) {
+	files, err := filepath.Glob(filepath.Join(c.directory, "*.zone"))
+	if err != nil {
+		return nil, err
+	}
+	zones := make([]string, 0, len(files))
+	for _, f := range files {
+		zones = append(zones, strings.TrimSuffix(filepath.Base(f), ".zone"))
+	}
+	return zones, nil
+}
+
+// GetZoneRecords gets the records of a zone from its zonefile.
+func (c *Bind) GetZoneRecords(domain string) (models.Records, error) {
+	zonefile := c.zoneFileName(domain)
+	fh, err := os.Open(zonefile)
+	if err != nil {
+		return nil, err
+	}
+	defer fh.Close()
+	records := models.Records{}
+	for x := range dns.ParseZone(fh, domain, zonefile) {
+		if x.Error != nil {
+			return nil, x.Error
+		}
+		rec, _ := rrToRecord(x.RR, domain, 0)
+		records = append(records, &rec)
+	}
+	models.PostProcessRecords(records)
+	return records, nil
+}
+
 // GetDomainCorrections returns a list of corrections to update a domain.
 func (c *Bind) GetDomainCorrections(dc *models.DomainConfig) ([]*models.Correction, error) {
 	dc.Punycode()
@@ -213,13 +250,13 @@ func (c *Bind) GetDomainCorrections(dc *models.DomainConfig) ([]*models.Correcti
 	// Read foundRecords:
 	foundRecords := make([]*models.RecordConfig, 0)
 	var oldSerial, newSerial uint32
-	zonefile := filepath.Join(c.directory, strings.Replace(strings.ToLower(dc.Name), "/", "_", -1)+".zone")
+	zonefile := c.zoneFileName(dc.Name)
 	foundFH, err := os.Open(zonefile)
 	zoneFileFound := err == nil
 	if err != nil && !os.IsNotExist(os.ErrNotExist) {
 		// Don't whine if the file doesn't exist. However all other
 		// errors will be reported.
-		fmt.Printf("Could not read zonefile: %v\n", err)
+		log.Printf("Could not read zonefile: %v\n", err)
 	} else {
 		for x := range dns.ParseZone(foundFH, dc.Name, zonefile) {
 			if x.Error != nil {
@@ -250,8 +287,14 @@ func (c *Bind) GetDomainCorrections(dc *models.DomainConfig) ([]*models.Correcti
 	// Normalize
 	models.PostProcessRecords(foundRecords)
 
-	differ := diff.New(dc)
-	_, create, del, mod := differ.IncrementalDiff(foundRecords)
+	differ := diff.NewForProvider("BIND", dc)
+	_, create, del, mod, err := differ.IncrementalDiff(foundRecords)
+	if err != nil {
+		return nil, err
+	}
+
+	// The zonefile is written anew, so the records the diff left alone must be written again.
+	keep := differ.Kept(foundRecords)
 
 	buf := &bytes.Buffer{}
 	// Print a list of changes. Generate an actual change that is the zone
@@ -283,15 +326,16 @@ func (c *Bind) GetDomainCorrections(dc *models.DomainConfig) ([]*models.Correcti
 	if changes {
 		corrections = append(corrections,
 			&models.Correction{
-				Msg: msg,
+				Msg:     msg,
+				Changes: append(append(create.Changes(), del.Changes()...), mod.Changes()...),
 				F: func() error {
-					fmt.Printf("CREATING ZONEFILE: %v\n", zonefile)
+					log.Printf("CREATING ZONEFILE: %v\n", zonefile)
 					zf, err := os.Create(zonefile)
 					if err != nil {
 						log.Fatalf("Could not create zonefile: %v", err)
 					}
-					zonefilerecords := make([]dns.RR, 0, len(dc.Records))
-					for _, r := range dc.Records {
+					zonefilerecords := make([]dns.RR, 0, len(dc.Records)+len(keep))
+					for _, r := range append(dc.Records, keep...) {
 						zonefilerecords = append(zonefilerecords, r.ToRR())
 					}
 					err = WriteZoneFile(zf, zonefilerecords, dc.Name)
@@ -163,48 +163,94 @@ func (z *zoneGenData) generateZoneFileHelper(w io.Writer) error {
 	sort.Sort(z)
 	fmt.Fprintln(w, "$TTL", z.DefaultTtl)
 	for i, rr := range z.Records {
-		line := rr.String()
-		if line[0] == ';' {
+		if rr.String()[0] == ';' {
 			continue
 		}
-		hdr := rr.Header()
-
-		items := strings.SplitN(line, "\t", 5)
-		if len(items) < 5 {
-			log.Fatalf("Too few items in: %v", line)
-		}
-
-		// items[0]: name
-		nameFqdn := hdr.Name
-		nameShort := dnsutil.TrimDomainName(nameFqdn, z.Origin)
+		nameShort := dnsutil.TrimDomainName(rr.Header().Name, z.Origin)
 		name := nameShort
 		if i > 0 && nameShort == nameShortPrevious {
 			name = ""
-		} else {
-			name = nameShort
 		}
 		nameShortPrevious = nameShort
+		fmt.Fprintln(w, z.formatRecord(rr, name))
+	}
+	return nil
+}
 
-		// items[1]: ttl
-		ttl := ""
-		if hdr.Ttl != z.DefaultTtl && hdr.Ttl != 0 {
-			ttl = items[1]
-		}
+// formatRecord formats a record as a line of the zonefile, with the given label.
+func (z *zoneGenData) formatRecord(rr dns.RR, name string) string {
+	line := rr.String()
+	hdr := rr.Header()
 
-		// items[2]: class
-		if hdr.Class != dns.ClassINET {
-			log.Fatalf("generateZoneFileHelper: Unimplemented class=%v", items[2])
-		}
+	items := strings.SplitN(line, "\t", 5)
+	if len(items) < 5 {
+		log.Fatalf("Too few items in: %v", line)
+	}
 
-		// items[3]: type
-		typeStr := dns.TypeToString[hdr.Rrtype]
+	// items[0]: name
 
-		// items[4]: the remaining line
-		target := items[4]
+	// items[1]: ttl
+	ttl := ""
+	if hdr.Ttl != z.DefaultTtl && hdr.Ttl != 0 {
+		ttl = items[1]
+	}
 
-		fmt.Fprintln(w, formatLine([]int{10, 5, 2, 5, 0}, []string{name, ttl, "IN", typeStr, target}))
+	// items[2]: class
+	if hdr.Class != dns.ClassINET {
+		log.Fatalf("generateZoneFileHelper: Unimplemented class=%v", items[2])
 	}
-	return nil
+
+	// items[3]: type
+	typeStr := dns.TypeToString[hdr.Rrtype]
+
+	// items[4]: the remaining line
+	target := items[4]
+
+	return formatLine([]int{10, 5, 2, 5, 0}, []string{name, ttl, "IN", typeStr, target})
+}
+
+// SortRecords sorts records in the order WriteZoneFile writes them.
+func SortRecords(records []dns.RR, origin string) {
+	sort.Sort(&zoneGenData{Origin: dnsutil.AddOrigin(origin, "."), Records: records})
+}
+
+// FormatRecord formats a record the way WriteZoneFile writes it, except that the label is
+// never omitted. TTLs equal to defaultTTL are omitted.
+func FormatRecord(rr dns.RR, origin string, defaultTTL uint32) string {
+	z := &zoneGenData{Origin: dnsutil.AddOrigin(origin, "."), DefaultTtl: defaultTTL}
+	return z.formatRecord(rr, dnsutil.TrimDomainName(rr.Header().Name, z.Origin))
+}
+
+// FormatLine formats the fields of a record (label, TTL, class, type and data) the way
+// WriteZoneFile aligns them. It is meant for records that have no dns.RR, such as pseudo records.
+func FormatLine(fields ...string) string {
+	return formatLine([]int{10, 5, 2, 5, 0}, fields)
+}
+
+// MostCommonTTL returns the TTL that WriteZoneFile uses as the $TTL of the records.
+func MostCommonTTL(records []dns.RR) uint32 {
+	return mostCommonTTL(records)
+}
+
+// ZoneLabelLess reports whether label a is written before label b by WriteZoneFile.
+// Labels are relative to the origin, "@" being the origin itself.
+func ZoneLabelLess(a, b string) bool {
+	return a != b && zoneLabelLess(a, b)
+}
+
+// ZoneRrtypeLess reports whether records of type a are written before records of type b by
+// WriteZoneFile, when they have the same label. Types that dns does not know, i.e. ALIAS, come
+// last, in alphabetical order.
+func ZoneRrtypeLess(a, b string) bool {
+	ta, okA := dns.StringToType[a]
+	tb, okB := dns.StringToType[b]
+	if okA && okB {
+		return zoneRrtypeLess(ta, tb)
+	}
+	if okA != okB {
+		return okA
+	}
+	return a < b
 }
 
 func formatLine(lengths []int, fields []string) string {
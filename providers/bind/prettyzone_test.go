@@ -441,3 +441,25 @@ func TestZoneRrtypeLess(t *testing.T) {
 		}
 	}
 }
+
+func TestZoneRrtypeLessNames(t *testing.T) {
+	var tests = []struct {
+		e1, e2   string
+		expected bool
+	}{
+		{"NS", "A", true},
+		{"A", "MX", true},
+		{"MX", "AAAA", true},
+		{"TXT", "ALIAS", true},
+		{"ALIAS", "R53_ALIAS", true},
+		{"A", "A", false},
+	}
+	for _, test := range tests {
+		if actual := ZoneRrtypeLess(test.e1, test.e2); actual != test.expected {
+			t.Errorf("%v < %v: expected (%v) got (%v)\n", test.e1, test.e2, test.expected, actual)
+		}
+		if actual := ZoneRrtypeLess(test.e2, test.e1); test.e1 != test.e2 && actual == test.expected {
+			t.Errorf("%v < %v: expected (%v) got (%v)\n", test.e2, test.e1, !test.expected, actual)
+		}
+	}
+}
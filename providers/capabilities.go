@@ -31,7 +31,8 @@ const (
 	// CantUseNOPURGE indicates NO_PURGE is broken for this provider. To make it
 	// work would require complex emulation of an incremental update mechanism,
 	// so it is easier to simply mark this feature as not working for this
-	// provider.
+	// provider. PURGE_OWNED works regardless, as such providers write the
+	// records returned by the differ's Kept() again.
 	CantUseNOPURGE
 
 	// DocOfficiallySupported means it is actively used and maintained by stack exchange
@@ -5,7 +5,9 @@ import (
 	"fmt"
 	"log"
 	"net"
+	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/StackExchange/dnscontrol/models"
@@ -46,12 +48,25 @@ func init() {
 	providers.RegisterDomainServiceProviderType("CLOUDFLAREAPI", newCloudflare, features)
 	providers.RegisterCustomRecordType("CF_REDIRECT", "CLOUDFLAREAPI", "")
 	providers.RegisterCustomRecordType("CF_TEMP_REDIRECT", "CLOUDFLAREAPI", "")
+	diff.RegisterCanonicalizer("CLOUDFLAREAPI", "ttl", canonicalizeTTL)
+}
+
+// canonicalizeTTL maps the default TTL to 1, which is "automatic", and raises
+// TTLs below the minimum of 120 seconds.
+func canonicalizeTTL(rec *models.RecordConfig) {
+	if rec.TTL == 0 || rec.TTL == 300 {
+		rec.TTL = 1
+	}
+	if rec.TTL != 1 && rec.TTL < 120 {
+		rec.TTL = 120
+	}
 }
 
 // CloudflareApi is the handle for API calls.
 type CloudflareApi struct {
-	ApiKey          string `json:"apikey"`
-	ApiUser         string `json:"apiuser"`
+	ApiKey          string     `json:"apikey"`
+	ApiUser         string     `json:"apiuser"`
+	mu              sync.Mutex // Protects domainIndex and nameservers while they are fetched
 	domainIndex     map[string]string
 	nameservers     map[string][]string
 	ipConversions   []transform.IpConversion
@@ -59,22 +74,10 @@ type CloudflareApi struct {
 	manageRedirects bool
 }
 
-func labelMatches(label string, matches []string) bool {
-	// log.Printf("DEBUG: labelMatches(%#v, %#v)\n", label, matches)
-	for _, tst := range matches {
-		if label == tst {
-			return true
-		}
-	}
-	return false
-}
-
 // GetNameservers returns the nameservers for a domain.
 func (c *CloudflareApi) GetNameservers(domain string) ([]*models.Nameserver, error) {
-	if c.domainIndex == nil {
-		if err := c.fetchDomainList(); err != nil {
-			return nil, err
-		}
+	if err := c.fetchDomainList(); err != nil {
+		return nil, err
 	}
 	ns, ok := c.nameservers[domain]
 	if !ok {
@@ -83,77 +86,112 @@ func (c *CloudflareApi) GetNameservers(domain string) ([]*models.Nameserver, err
 	return models.StringsToNameservers(ns), nil
 }
 
-// GetDomainCorrections returns a list of corrections to update a domain.
-func (c *CloudflareApi) GetDomainCorrections(dc *models.DomainConfig) ([]*models.Correction, error) {
-	if c.domainIndex == nil {
-		if err := c.fetchDomainList(); err != nil {
-			return nil, err
-		}
+func (c *CloudflareApi) getDomainID(name string) (string, error) {
+	if err := c.fetchDomainList(); err != nil {
+		return "", err
 	}
-	id, ok := c.domainIndex[dc.Name]
+	id, ok := c.domainIndex[name]
 	if !ok {
-		return nil, fmt.Errorf("%s not listed in zones for cloudflare account", dc.Name)
+		return "", fmt.Errorf("%s not listed in zones for cloudflare account", name)
 	}
-	if err := c.preprocessConfig(dc); err != nil {
+	return id, nil
+}
+
+// ListZones returns all zones in the account.
+func (c *CloudflareApi) ListZones() ([]string, error) {
+	if err := c.fetchDomainList(); err != nil {
 		return nil, err
 	}
-	records, err := c.getRecordsForDomain(id, dc.Name)
+	zones := make([]string, 0, len(c.domainIndex))
+	for d := range c.domainIndex {
+		zones = append(zones, d)
+	}
+	sort.Strings(zones)
+	return zones, nil
+}
+
+// GetZoneRecords gets the records of a zone, including page rules if redirects are managed.
+func (c *CloudflareApi) GetZoneRecords(domain string) (models.Records, error) {
+	id, err := c.getDomainID(domain)
 	if err != nil {
 		return nil, err
 	}
-	for i := len(records) - 1; i >= 0; i-- {
-		rec := records[i]
-		// Delete ignore labels
-		if labelMatches(dnsutil.TrimDomainName(rec.Original.(*cfRecord).Name, dc.Name), c.ignoredLabels) {
-			fmt.Printf("ignored_label: %s\n", rec.Original.(*cfRecord).Name)
-			records = append(records[:i], records[i+1:]...)
-		}
+	records, err := c.getRecordsForDomain(id, domain)
+	if err != nil {
+		return nil, err
 	}
 	if c.manageRedirects {
-		prs, err := c.getPageRules(id, dc.Name)
+		prs, err := c.getPageRules(id, domain)
 		if err != nil {
 			return nil, err
 		}
 		records = append(records, prs...)
 	}
+	models.PostProcessRecords(records)
+	return records, nil
+}
+
+// GetDomainCorrections returns a list of corrections to update a domain.
+func (c *CloudflareApi) GetDomainCorrections(dc *models.DomainConfig) ([]*models.Correction, error) {
+	id, err := c.getDomainID(dc.Name)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.preprocessConfig(dc); err != nil {
+		return nil, err
+	}
+	records, err := c.GetZoneRecords(dc.Name)
+	if err != nil {
+		return nil, err
+	}
+	// The deprecated ignored_labels setting works like IGNORE.
+	for _, l := range c.ignoredLabels {
+		dc.Ignored = append(dc.Ignored, &models.IgnoreRule{Label: l})
+	}
 	for _, rec := range dc.Records {
 		if rec.Type == "ALIAS" {
 			rec.Type = "CNAME"
 		}
-		if labelMatches(rec.Name, c.ignoredLabels) {
-			log.Fatalf("FATAL: dnsconfig contains label that matches ignored_labels: %#v is in %v)\n", rec.Name, c.ignoredLabels)
+		if rule := dc.IgnoredBy(rec); rule != nil {
+			return nil, fmt.Errorf("dnsconfig contains %s %s, which matches ignored_labels %s", rec.Type, rec.NameFQDN, rule.Label)
 		}
 	}
 	checkNSModifications(dc)
 
-	// Normalize
-	models.PostProcessRecords(records)
-
-	differ := diff.New(dc, getProxyMetadata)
-	_, create, del, mod := differ.IncrementalDiff(records)
+	differ := diff.NewForProvider("CLOUDFLAREAPI", dc, getProxyMetadata)
+	_, create, del, mod, err := differ.IncrementalDiff(records)
+	if err != nil {
+		return nil, err
+	}
 	corrections := []*models.Correction{}
 
 	for _, d := range del {
 		ex := d.Existing
 		if ex.Type == "PAGE_RULE" {
 			corrections = append(corrections, &models.Correction{
-				Msg: d.String(),
-				F:   func() error { return c.deletePageRule(ex.Original.(*pageRule).ID, id) },
+				Msg:     d.String(),
+				Changes: []models.Change{d.Change()},
+				F:       func() error { return c.deletePageRule(ex.Original.(*pageRule).ID, id) },
 			})
 
 		} else {
-			corrections = append(corrections, c.deleteRec(ex.Original.(*cfRecord), id))
+			corr := c.deleteRec(ex.Original.(*cfRecord), id)
+			corr.Changes = []models.Change{d.Change()}
+			corrections = append(corrections, corr)
 		}
 	}
 	for _, d := range create {
 		des := d.Desired
 		if des.Type == "PAGE_RULE" {
 			corrections = append(corrections, &models.Correction{
-				Msg: d.String(),
-				F:   func() error { return c.createPageRule(id, des.Target) },
+				Msg:     d.String(),
+				Changes: []models.Change{d.Change()},
+				F:       func() error { return c.createPageRule(id, des.Target) },
 			})
 		} else {
-			corrections = append(corrections, c.createRec(des, id)...)
+			corrs := c.createRec(des, id)
+			corrs[0].Changes = []models.Change{d.Change()} // the others activate the proxy
+			corrections = append(corrections, corrs...)
 		}
 	}
 
@@ -162,15 +200,17 @@ func (c *CloudflareApi) GetDomainCorrections(dc *models.DomainConfig) ([]*models
 		ex := d.Existing
 		if rec.Type == "PAGE_RULE" {
 			corrections = append(corrections, &models.Correction{
-				Msg: d.String(),
-				F:   func() error { return c.updatePageRule(ex.Original.(*pageRule).ID, id, rec.Target) },
+				Msg:     d.String(),
+				Changes: []models.Change{d.Change()},
+				F:       func() error { return c.updatePageRule(ex.Original.(*pageRule).ID, id, rec.Target) },
 			})
 		} else {
 			e := ex.Original.(*cfRecord)
 			proxy := e.Proxiable && rec.Metadata[metaProxy] != "off"
 			corrections = append(corrections, &models.Correction{
-				Msg: d.String(),
-				F:   func() error { return c.modifyRecord(id, e.ID, proxy, rec) },
+				Msg:     d.String(),
+				Changes: []models.Change{d.Change()},
+				F:       func() error { return c.modifyRecord(id, e.ID, proxy, rec) },
 			})
 		}
 	}
@@ -231,12 +271,6 @@ func (c *CloudflareApi) preprocessConfig(dc *models.DomainConfig) error {
 		if rec.Metadata == nil {
 			rec.Metadata = map[string]string{}
 		}
-		if rec.TTL == 0 || rec.TTL == 300 {
-			rec.TTL = 1
-		}
-		if rec.TTL != 1 && rec.TTL < 120 {
-			rec.TTL = 120
-		}
 		if rec.Type != "A" && rec.Type != "CNAME" && rec.Type != "AAAA" && rec.Type != "ALIAS" {
 			if rec.Metadata[metaProxy] != "" {
 				return fmt.Errorf("cloudflare_proxy set on %v record: %#v cloudflare_proxy=%#v", rec.Type, rec.Name, rec.Metadata[metaProxy])
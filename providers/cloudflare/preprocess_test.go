@@ -114,3 +114,13 @@ func TestIpRewriting(t *testing.T) {
 		}
 	}
 }
+
+func TestCanonicalizeTTL(t *testing.T) {
+	for given, expected := range map[uint32]uint32{0: 1, 1: 1, 60: 120, 120: 120, 300: 1, 3600: 3600} {
+		rec := &models.RecordConfig{Type: "A", TTL: given}
+		canonicalizeTTL(rec)
+		if rec.TTL != expected {
+			t.Errorf("TTL %d: expected %d, got %d", given, expected, rec.TTL)
+		}
+	}
+}
@@ -25,8 +25,14 @@ const (
 
 // get list of domains for account. Cache so the ids can be looked up from domain name
 func (c *CloudflareApi) fetchDomainList() error {
-	c.domainIndex = map[string]string{}
-	c.nameservers = map[string][]string{}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.domainIndex != nil {
+		return nil
+	}
+	// The maps are only stored once complete, so that no caller sees them half filled.
+	domainIndex := map[string]string{}
+	nameservers := map[string][]string{}
 	page := 1
 	for {
 		zr := &zoneResponse{}
@@ -38,9 +44,9 @@ func (c *CloudflareApi) fetchDomainList() error {
 			return fmt.Errorf("Error fetching domain list from cloudflare: %s", stringifyErrors(zr.Errors))
 		}
 		for _, zone := range zr.Result {
-			c.domainIndex[zone.Name] = zone.ID
+			domainIndex[zone.Name] = zone.ID
 			for _, ns := range zone.Nameservers {
-				c.nameservers[zone.Name] = append(c.nameservers[zone.Name], ns)
+				nameservers[zone.Name] = append(nameservers[zone.Name], ns)
 			}
 		}
 		ri := zr.ResultInfo
@@ -49,6 +55,7 @@ func (c *CloudflareApi) fetchDomainList() error {
 		}
 		page++
 	}
+	c.domainIndex, c.nameservers = domainIndex, nameservers
 	return nil
 }
 
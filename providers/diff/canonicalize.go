@@ -0,0 +1,155 @@
+package diff
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/StackExchange/dnscontrol/models"
+)
+
+// Canonicalizer rewrites a record the way a provider stores it, i.e. the TTL the provider
+// actually uses. The differ applies it to the existing and the desired records before comparing
+// them, so that differences the provider does not preserve do not cause a correction that never goes away.
+// It must be idempotent.
+type Canonicalizer func(rec *models.RecordConfig)
+
+type namedCanonicalizer struct {
+	name string
+	f    Canonicalizer
+}
+
+var (
+	canonicalizersMu sync.RWMutex
+	canonicalizers   = map[string][]namedCanonicalizer{}
+)
+
+// RegisterCanonicalizer registers a canonicalizer for a provider type.
+// Canonicalizers run in the order they are registered. The name is shown by -explain.
+func RegisterCanonicalizer(providerType, name string, f Canonicalizer) {
+	canonicalizersMu.Lock()
+	defer canonicalizersMu.Unlock()
+	canonicalizers[providerType] = append(canonicalizers[providerType], namedCanonicalizer{name, f})
+}
+
+// Canonicalizers returns the names of the canonicalizers registered for a provider type.
+func Canonicalizers(providerType string) []string {
+	canonicalizersMu.RLock()
+	defer canonicalizersMu.RUnlock()
+	names := []string{}
+	for _, c := range canonicalizers[providerType] {
+		names = append(names, c.name)
+	}
+	return names
+}
+
+// CanonicalizeTXT splits the value of a TXT record that a provider returned as a single,
+// possibly quoted, combined target into its strings, which is how declared TXT records
+// are compared. Providers that return TXT records both with and without quotes register it,
+// so that the quotes do not cause a correction. Records that are not combined are left alone.
+func CanonicalizeTXT(rec *models.RecordConfig) {
+	if rec.Type != "TXT" || !rec.CombinedTarget {
+		return
+	}
+	rec.SetTxtParse(rec.Target)
+	rec.CombinedTarget = false
+}
+
+// NewForProvider is like New, but the differ also applies the canonicalizers registered for the provider type.
+// They change the records in place, so that the corrections use the canonical form.
+// If dc.Explain is set, the differ prints what the canonicalizers changed with it.
+func NewForProvider(providerType string, dc *models.DomainConfig, extraValues ...func(*models.RecordConfig) map[string]string) Differ {
+	canonicalizersMu.RLock()
+	cs := canonicalizers[providerType]
+	canonicalizersMu.RUnlock()
+	return &differ{
+		dc:             dc,
+		extraValues:    extraValues,
+		providerType:   providerType,
+		canonicalizers: cs,
+		explainf:       dc.Explain,
+	}
+}
+
+// canonicalization records how the canonicalizers changed a record, for -explain.
+type canonicalization struct {
+	original string   // the content before any canonicalizer ran
+	by       []string // the canonicalizers that changed the record
+}
+
+// canonicalize applies the canonicalizers to the records.
+func (d *differ) canonicalize(side string, records []*models.RecordConfig) {
+	if len(d.canonicalizers) == 0 {
+		return
+	}
+	for _, r := range records {
+		if _, done := d.canonical[r]; done {
+			continue
+		}
+		c := &canonicalization{original: d.content(r)}
+		before := c.original
+		for _, cz := range d.canonicalizers {
+			cz.f(r)
+			if after := d.content(r); after != before {
+				c.by = append(c.by, cz.name)
+				if d.explainf != nil {
+					d.explainf("EXPLAIN: %s canonicalizer %s changed %s %s %s: %s -> %s\n",
+						d.providerType, cz.name, side, r.Type, r.NameFQDN, before, after)
+				}
+				before = after
+			}
+		}
+		if d.canonical == nil {
+			d.canonical = map[*models.RecordConfig]*canonicalization{}
+		}
+		d.canonical[r] = c
+	}
+}
+
+// explain prints the corrections that exist, or do not, because of the canonicalizers.
+func (d *differ) explain(unchanged, create, toDelete, modify Changeset) {
+	if d.explainf == nil || len(d.canonical) == 0 {
+		return
+	}
+	original := func(r *models.RecordConfig) string {
+		if c := d.canonical[r]; c != nil {
+			return c.original
+		}
+		return d.content(r)
+	}
+	by := func(c Correlation) string {
+		names := []string{}
+		seen := map[string]bool{}
+		for _, r := range []*models.RecordConfig{c.Existing, c.Desired} {
+			if r == nil || d.canonical[r] == nil {
+				continue
+			}
+			for _, n := range d.canonical[r].by {
+				if !seen[n] {
+					seen[n] = true
+					names = append(names, n)
+				}
+			}
+		}
+		return strings.Join(names, ", ")
+	}
+	for _, c := range unchanged {
+		if original(c.Existing) != original(c.Desired) {
+			d.explainf("EXPLAIN: no change to %s %s, suppressed by %s: %s at the provider, %s declared\n",
+				c.Desired.Type, c.Desired.NameFQDN, by(c), original(c.Existing), original(c.Desired))
+		}
+	}
+	for _, c := range modify {
+		if original(c.Existing) == original(c.Desired) {
+			d.explainf("EXPLAIN: %s caused by %s\n", c, by(c))
+		} else if names := by(c); names != "" {
+			d.explainf("EXPLAIN: %s after %s\n", c, names)
+		}
+	}
+	for _, cs := range []Changeset{create, toDelete} {
+		for _, c := range cs {
+			if names := by(c); names != "" {
+				d.explainf("EXPLAIN: %s after %s\n", c, names)
+			}
+		}
+	}
+}
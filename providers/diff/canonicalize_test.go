@@ -0,0 +1,84 @@
+package diff
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/StackExchange/dnscontrol/models"
+)
+
+func init() {
+	RegisterCanonicalizer("TEST", "min-ttl", func(rec *models.RecordConfig) {
+		if rec.TTL < 120 {
+			rec.TTL = 120
+		}
+	})
+}
+
+func TestCanonicalizers(t *testing.T) {
+	existing := []*models.RecordConfig{
+		myRecord("www A 120 1.1.1.1"),
+		myRecord("mail A 120 2.2.2.2"),
+	}
+	desired := []*models.RecordConfig{
+		myRecord("www A 60 1.1.1.1"),
+		myRecord("mail A 90 3.3.3.3"),
+	}
+	var buf bytes.Buffer
+	explain := func(format string, args ...interface{}) { fmt.Fprintf(&buf, format, args...) }
+	dc := &models.DomainConfig{Name: "example.com", Records: desired, Explain: explain}
+
+	un, cre, del, mod, err := NewForProvider("TEST", dc).IncrementalDiff(existing)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(un) != 1 || len(cre) != 0 || len(del) != 0 || len(mod) != 1 {
+		t.Fatalf("expected the TTL difference to be suppressed, got %v %v %v %v", un, cre, del, mod)
+	}
+	if desired[1].TTL != 120 {
+		t.Errorf("expected the desired record to be canonicalized in place, got TTL %d", desired[1].TTL)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		"canonicalizer min-ttl changed desired A www.example.com: 1.1.1.1 ttl=60 -> 1.1.1.1 ttl=120",
+		"no change to A www.example.com, suppressed by min-ttl",
+		"MODIFY A mail.example.com: (2.2.2.2 ttl=120) -> (3.3.3.3 ttl=120) after min-ttl",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected the explanation to contain %q, got:\n%s", want, out)
+		}
+	}
+
+	if names := Canonicalizers("TEST"); len(names) != 1 || names[0] != "min-ttl" {
+		t.Errorf("unexpected canonicalizers %v", names)
+	}
+	if _, _, _, mod, _ := New(&models.DomainConfig{Name: "example.com", Records: []*models.RecordConfig{myRecord("www A 60 1.1.1.1")}}).IncrementalDiff(existing[:1]); len(mod) != 1 {
+		t.Errorf("expected New to not canonicalize, got %v", mod)
+	}
+}
+
+func TestCanonicalizeTXT(t *testing.T) {
+	quoted := &models.RecordConfig{Type: "TXT", Name: "@", NameFQDN: "example.com", Target: `"v=spf1 -all"`, TTL: 300, CombinedTarget: true}
+	unquoted := &models.RecordConfig{Type: "TXT", Name: "@", NameFQDN: "example.com", Target: "v=spf1 -all", TTL: 300, CombinedTarget: true}
+	declared := &models.RecordConfig{Type: "TXT", Name: "@", NameFQDN: "example.com", TTL: 300}
+	declared.SetTxt("v=spf1 -all")
+	for _, existing := range []*models.RecordConfig{quoted, unquoted} {
+		CanonicalizeTXT(existing)
+		if existing.CombinedTarget || existing.Content() != declared.Content() {
+			t.Errorf("expected %q, got %q", declared.Content(), existing.Content())
+		}
+		CanonicalizeTXT(existing)
+		if existing.Content() != declared.Content() {
+			t.Errorf("expected CanonicalizeTXT to be idempotent, got %q", existing.Content())
+		}
+	}
+
+	literal := &models.RecordConfig{Type: "TXT", Name: "@", NameFQDN: "example.com", TTL: 300}
+	literal.SetTxt(`"quoted"`)
+	CanonicalizeTXT(literal)
+	if len(literal.TxtStrings) != 1 || literal.TxtStrings[0] != `"quoted"` {
+		t.Errorf("expected a declared record to be left alone, got %q", literal.TxtStrings)
+	}
+}
@@ -0,0 +1,69 @@
+package diff
+
+import "github.com/StackExchange/dnscontrol/models"
+
+// CorrectionFunc makes the correction for a single change of IncrementalDiff.
+// It may return nil to skip the change.
+type CorrectionFunc func(kind models.CorrectionKind, c Correlation) (*models.Correction, error)
+
+// Corrections turns the changes of IncrementalDiff into corrections for providers
+// that change one record at a time, and orders them safely. It sets the Kind and
+// Changes of the corrections.
+//
+// Creations and modifications come before deletions, so that a name never goes
+// without records while they are replaced. The exception are CNAMEs, which can not
+// coexist with other records of the same name: a creation then depends on the
+// deletion, so it runs after it, and not at all if the deletion fails.
+func Corrections(create, toDelete, modify Changeset, mk CorrectionFunc) ([]*models.Correction, error) {
+	type deletion struct {
+		rType string
+		corr  *models.Correction
+	}
+	deletions := []*models.Correction{}
+	byName := map[string][]deletion{}
+	for _, c := range toDelete {
+		corr, err := mk(models.CorrectionDelete, c)
+		if err != nil {
+			return nil, err
+		}
+		if corr == nil {
+			continue
+		}
+		corr.Kind = models.CorrectionDelete
+		corr.Changes = []models.Change{c.Change()}
+		deletions = append(deletions, corr)
+		byName[c.Existing.NameFQDN] = append(byName[c.Existing.NameFQDN], deletion{c.Existing.Type, corr})
+	}
+
+	corrections := []*models.Correction{}
+	for _, c := range create {
+		corr, err := mk(models.CorrectionCreate, c)
+		if err != nil {
+			return nil, err
+		}
+		if corr == nil {
+			continue
+		}
+		corr.Kind = models.CorrectionCreate
+		corr.Changes = []models.Change{c.Change()}
+		for _, d := range byName[c.Desired.NameFQDN] {
+			if d.rType == "CNAME" || c.Desired.Type == "CNAME" {
+				corr.DependsOn = append(corr.DependsOn, d.corr)
+			}
+		}
+		corrections = append(corrections, corr)
+	}
+	for _, c := range modify {
+		corr, err := mk(models.CorrectionModify, c)
+		if err != nil {
+			return nil, err
+		}
+		if corr == nil {
+			continue
+		}
+		corr.Kind = models.CorrectionModify
+		corr.Changes = []models.Change{c.Change()}
+		corrections = append(corrections, corr)
+	}
+	return models.OrderCorrections(append(corrections, deletions...))
+}
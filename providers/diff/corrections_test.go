@@ -0,0 +1,87 @@
+package diff
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/StackExchange/dnscontrol/models"
+)
+
+func TestCorrectionsOrder(t *testing.T) {
+	existing := []*models.RecordConfig{
+		myRecord("www A 1 1.1.1.1"),
+		myRecord("foo CNAME 1 www"),
+		myRecord("bar MX 1 mx1"),
+		myRecord("bar MX 1 mx2"),
+	}
+	desired := []*models.RecordConfig{
+		myRecord("www AAAA 1 ::1"),
+		myRecord("foo A 1 1.2.3.4"),
+		myRecord("bar MX 1 mx3"),
+	}
+	_, create, del, mod := checkLengths(t, existing, desired, 0, 2, 3, 1)
+	corrections, err := Corrections(create, del, mod, func(kind models.CorrectionKind, c Correlation) (*models.Correction, error) {
+		return &models.Correction{Msg: c.String()}, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The new AAAA is created before the A is deleted, but the CNAME must go before the new A.
+	expected := []struct {
+		kind models.CorrectionKind
+		msg  string
+		deps int
+	}{
+		{models.CorrectionCreate, "CREATE AAAA www.example.com ::1 ttl=1", 0},
+		{models.CorrectionModify, "MODIFY MX bar.example.com: (0 mx1 ttl=1) -> (0 mx3 ttl=1)", 0},
+		{models.CorrectionDelete, "DELETE A www.example.com 1.1.1.1 ttl=1", 0},
+		{models.CorrectionDelete, "DELETE MX bar.example.com 0 mx2 ttl=1", 0},
+		{models.CorrectionDelete, "DELETE CNAME foo.example.com www ttl=1", 0},
+		{models.CorrectionCreate, "CREATE A foo.example.com 1.2.3.4 ttl=1", 1},
+	}
+	got := map[string]*models.Correction{}
+	for _, c := range corrections {
+		got[c.Msg] = c
+	}
+	if len(corrections) != len(expected) {
+		t.Fatalf("expected %d corrections, got %d", len(expected), len(corrections))
+	}
+	position := map[*models.Correction]int{}
+	for i, c := range corrections {
+		position[c] = i
+	}
+	for _, e := range expected {
+		c := got[e.msg]
+		if c == nil {
+			t.Errorf("missing correction %q", e.msg)
+			continue
+		}
+		if c.Kind != e.kind || len(c.DependsOn) != e.deps {
+			t.Errorf("%q: expected kind %s with %d dependencies, got %s with %d", e.msg, e.kind, e.deps, c.Kind, len(c.DependsOn))
+		}
+		if len(c.Changes) != 1 || strings.ToLower(c.Changes[0].Action) != string(c.Kind) || !strings.Contains(e.msg, c.Changes[0].Name) {
+			t.Errorf("%q: expected its change, got %+v", e.msg, c.Changes)
+		}
+		for _, d := range c.DependsOn {
+			if position[d] > position[c] {
+				t.Errorf("%q runs before %q, which it depends on", c.Msg, d.Msg)
+			}
+		}
+		if c.Kind == models.CorrectionCreate && c.DependsOn == nil && position[c] > 1 {
+			t.Errorf("%q should run before the deletions", c.Msg)
+		}
+	}
+}
+
+func TestCorrectionsSkip(t *testing.T) {
+	existing := []*models.RecordConfig{
+		myRecord("@ NS 1 ns1.example.net."),
+	}
+	_, create, del, mod := checkLengths(t, existing, nil, 0, 0, 1, 0)
+	corrections, err := Corrections(create, del, mod, func(kind models.CorrectionKind, c Correlation) (*models.Correction, error) {
+		return nil, nil
+	})
+	if err != nil || len(corrections) != 0 {
+		t.Errorf("expected no corrections, got %v, %v", corrections, err)
+	}
+}
@@ -6,7 +6,6 @@ import (
 	"sort"
 
 	"github.com/StackExchange/dnscontrol/models"
-	"github.com/miekg/dns/dnsutil"
 )
 
 // Correlation stores a difference between two domains.
@@ -22,10 +21,18 @@ type Changeset []Correlation
 // Differ is an interface for computing the difference between two zones.
 type Differ interface {
 	// IncrementalDiff performs a diff on a record-by-record basis, and returns a sets for which records need to be created, deleted, or modified.
-	IncrementalDiff(existing []*models.RecordConfig) (unchanged, create, toDelete, modify Changeset)
-	// ChangedGroups performs a diff more appropriate for providers with a "RecordSet" model, where all records with the same name and type are grouped.
-	// Individual record changes are often not useful in such scenarios. Instead we return a map of record keys to a list of change descriptions within that group.
-	ChangedGroups(existing []*models.RecordConfig) map[models.RecordKey][]string
+	// It returns an error if the same record exists twice, or is declared twice.
+	IncrementalDiff(existing []*models.RecordConfig) (unchanged, create, toDelete, modify Changeset, err error)
+	// ChangedRecordSets performs a diff more appropriate for providers with a "RecordSet" model, where all records with the same name and type are grouped.
+	// For every set that changed it returns the whole existing and desired set, along with the changes of the individual records.
+	ChangedRecordSets(existing []*models.RecordConfig) ([]RecordSetChange, error)
+	// ChangedGroups returns the descriptions of the changes of ChangedRecordSets, by record key.
+	//
+	// Deprecated: use ChangedRecordSets, which does not require the desired records to be looked up again.
+	ChangedGroups(existing []*models.RecordConfig) (map[models.RecordKey][]string, error)
+	// Kept returns the existing records the diff leaves alone, because of IGNORE or PURGE_OWNED.
+	// Providers that replace the whole zone must write them again.
+	Kept(existing []*models.RecordConfig) models.Records
 }
 
 // New is a constructor for a Differ.
@@ -39,11 +46,25 @@ func New(dc *models.DomainConfig, extraValues ...func(*models.RecordConfig) map[
 type differ struct {
 	dc          *models.DomainConfig
 	extraValues []func(*models.RecordConfig) map[string]string
+
+	providerType   string
+	canonicalizers []namedCanonicalizer
+	canonical      map[*models.RecordConfig]*canonicalization // the records canonicalize has seen
+	explainf       func(format string, args ...interface{})   // prints what the canonicalizers changed
+}
+
+// key identifies a record set.
+type key struct {
+	name, rType string
 }
 
 // get normalized content for record. target, ttl, mxprio, and specified metadata
 func (d *differ) content(r *models.RecordConfig) string {
-	content := fmt.Sprintf("%v ttl=%d", r.Content(), r.TTL)
+	return d.value(r, fmt.Sprintf("%v ttl=%d", r.Content(), r.TTL))
+}
+
+// value appends the provider's extra values to content.
+func (d *differ) value(r *models.RecordConfig, content string) string {
 	for _, f := range d.extraValues {
 		// sort the extra values map keys to perform a deterministic
 		// comparison since Golang maps iteration order is not guaranteed
@@ -61,139 +82,226 @@ func (d *differ) content(r *models.RecordConfig) string {
 	return content
 }
 
-func (d *differ) IncrementalDiff(existing []*models.RecordConfig) (unchanged, create, toDelete, modify Changeset) {
+func (d *differ) IncrementalDiff(existing []*models.RecordConfig) (unchanged, create, toDelete, modify Changeset, err error) {
 	unchanged = Changeset{}
 	create = Changeset{}
 	toDelete = Changeset{}
 	modify = Changeset{}
 	desired := d.dc.Records
+	d.canonicalize("existing", existing)
+	d.canonicalize("desired", desired)
 
-	// sort existing and desired by name
-	type key struct {
-		name, rType string
+	// group existing and desired by name and type, keeping the order in which the sets appear
+	sets := map[key]*recordSet{}
+	order := []key{}
+	set := func(r *models.RecordConfig) *recordSet {
+		k := key{r.NameFQDN, r.Type}
+		s := sets[k]
+		if s == nil {
+			s = &recordSet{key: k}
+			sets[k] = s
+			order = append(order, k)
+		}
+		return s
 	}
-	existingByNameAndType := map[key][]*models.RecordConfig{}
-	desiredByNameAndType := map[key][]*models.RecordConfig{}
+	declared := d.declared()
 	for _, e := range existing {
-		if d.matchIgnored(e.NameFQDN, d.dc.Name) {
+		switch {
+		case d.matchIgnored(e):
 			log.Printf("Ignoring record %s %s due to IGNORE", e.NameFQDN, e.Type)
-		} else {
-			k := key{e.NameFQDN, e.Type}
-			existingByNameAndType[k] = append(existingByNameAndType[k], e)
+		case d.unowned(e, declared):
+			log.Printf("Ignoring record %s %s due to PURGE_OWNED, as DNSControl did not create it", e.NameFQDN, e.Type)
+		default:
+			s := set(e)
+			s.existing = append(s.existing, e)
 		}
 	}
 	for _, dr := range desired {
-		if d.matchIgnored(dr.NameFQDN, d.dc.Name) {
-			panic(fmt.Sprintf("Trying to update/add IGNOREd record: %s %s", dr.NameFQDN, dr.Type))
+		if d.matchIgnored(dr) {
+			// normalize refuses such records; this only happens when IGNORE rules are added later.
+			log.Printf("Not managing record %s %s due to IGNORE", dr.NameFQDN, dr.Type)
 		} else {
-			k := key{dr.NameFQDN, dr.Type}
-			desiredByNameAndType[k] = append(desiredByNameAndType[k], dr)
-		}
-	}
-	// if NO_PURGE is set, just remove anything that is only in existing.
-	if d.dc.KeepUnknown {
-		for k := range existingByNameAndType {
-			if _, ok := desiredByNameAndType[k]; !ok {
-				log.Printf("Ignoring record set %s %s due to NO_PURGE", k.rType, k.name)
-				delete(existingByNameAndType, k)
-			}
-		}
-	}
-	// Look through existing records. This will give us changes and deletions and some additions.
-	// Each iteration is only for a single type/name record set
-	for key, existingRecords := range existingByNameAndType {
-		desiredRecords := desiredByNameAndType[key]
-		// first look through records that are the same target on both sides. Those are either modifications or unchanged
-		for i := len(existingRecords) - 1; i >= 0; i-- {
-			ex := existingRecords[i]
-			for j, de := range desiredRecords {
-				if de.Target == ex.Target {
-					// they're either identical or should be a modification of each other (ttl or metadata changes)
-					if d.content(de) == d.content(ex) {
-						unchanged = append(unchanged, Correlation{d, ex, de})
-					} else {
-						modify = append(modify, Correlation{d, ex, de})
-					}
-					// remove from both slices by index
-					existingRecords = existingRecords[:i+copy(existingRecords[i:], existingRecords[i+1:])]
-					desiredRecords = desiredRecords[:j+copy(desiredRecords[j:], desiredRecords[j+1:])]
-					break
-				}
-			}
-		}
-
-		desiredLookup := map[string]*models.RecordConfig{}
-		existingLookup := map[string]*models.RecordConfig{}
-		// build index based on normalized content data
-		for _, ex := range existingRecords {
-			normalized := d.content(ex)
-			if existingLookup[normalized] != nil {
-				panic(fmt.Sprintf("DUPLICATE E_RECORD FOUND: %s %s", key, normalized))
-			}
-			existingLookup[normalized] = ex
-		}
-		for _, de := range desiredRecords {
-			normalized := d.content(de)
-			if desiredLookup[normalized] != nil {
-				panic(fmt.Sprintf("DUPLICATE D_RECORD FOUND: %s %s", key, normalized))
-			}
-			desiredLookup[normalized] = de
-		}
-		// if a record is in both, it is unchanged
-		for norm, ex := range existingLookup {
-			if de, ok := desiredLookup[norm]; ok {
-				unchanged = append(unchanged, Correlation{d, ex, de})
-				delete(existingLookup, norm)
-				delete(desiredLookup, norm)
-			}
-		}
-		// sort records by normalized text. Keeps behaviour deterministic
-		existingStrings, desiredStrings := sortedKeys(existingLookup), sortedKeys(desiredLookup)
-		// Modifications. Take 1 from each side.
-		for len(desiredStrings) > 0 && len(existingStrings) > 0 {
-			modify = append(modify, Correlation{d, existingLookup[existingStrings[0]], desiredLookup[desiredStrings[0]]})
-			existingStrings = existingStrings[1:]
-			desiredStrings = desiredStrings[1:]
-		}
-		// If desired still has things they are additions
-		for _, norm := range desiredStrings {
-			rec := desiredLookup[norm]
-			create = append(create, Correlation{d, nil, rec})
-		}
-		// if found , but not desired, delete it
-		for _, norm := range existingStrings {
-			rec := existingLookup[norm]
-			toDelete = append(toDelete, Correlation{d, rec, nil})
-		}
-		// remove this set from the desired list to indicate we have processed it.
-		delete(desiredByNameAndType, key)
-	}
-
-	// any name/type sets not already processed are pure additions
-	for name := range existingByNameAndType {
-		delete(desiredByNameAndType, name)
-	}
-	for _, desiredList := range desiredByNameAndType {
-		for _, rec := range desiredList {
-			create = append(create, Correlation{d, nil, rec})
+			s := set(dr)
+			s.desired = append(s.desired, dr)
 		}
 	}
+	for _, k := range order {
+		s := sets[k]
+		// if NO_PURGE is set, just leave alone anything that is only in existing.
+		if d.dc.KeepUnknown && len(s.desired) == 0 {
+			log.Printf("Ignoring record set %s %s due to NO_PURGE", k.rType, k.name)
+			continue
+		}
+		if err := d.diffSet(s, &unchanged, &create, &toDelete, &modify); err != nil {
+			return nil, nil, nil, nil, err
+		}
+	}
+	d.explain(unchanged, create, toDelete, modify)
+	if d.dc.OnDiff != nil {
+		d.dc.OnDiff(existing, result(existing, create, toDelete, modify))
+	}
 	return
 }
 
-func (d *differ) ChangedGroups(existing []*models.RecordConfig) map[models.RecordKey][]string {
-	changedKeys := map[models.RecordKey][]string{}
-	_, create, delete, modify := d.IncrementalDiff(existing)
+// result returns the records the zone holds once the changes are made.
+func result(existing []*models.RecordConfig, create, toDelete, modify Changeset) models.Records {
+	replaced := map[*models.RecordConfig]bool{}
+	for _, c := range toDelete {
+		replaced[c.Existing] = true
+	}
+	for _, c := range modify {
+		replaced[c.Existing] = true
+	}
+	records := models.Records{}
+	for _, r := range existing {
+		if !replaced[r] {
+			records = append(records, r)
+		}
+	}
 	for _, c := range create {
-		changedKeys[c.Desired.Key()] = append(changedKeys[c.Desired.Key()], c.String())
+		records = append(records, c.Desired)
 	}
-	for _, d := range delete {
-		changedKeys[d.Existing.Key()] = append(changedKeys[d.Existing.Key()], d.String())
+	for _, c := range modify {
+		records = append(records, c.Desired)
 	}
-	for _, m := range modify {
-		changedKeys[m.Desired.Key()] = append(changedKeys[m.Desired.Key()], m.String())
+	return records
+}
+
+// recordSet holds the existing and desired records with the same name and type.
+type recordSet struct {
+	key               key
+	existing, desired []*models.RecordConfig
+}
+
+// diffSet compares the existing and desired records of a single set.
+// It takes time proportional to the size of the set.
+func (d *differ) diffSet(s *recordSet, unchanged, create, toDelete, modify *Changeset) error {
+	if len(s.existing) == 0 {
+		// a new set: pure additions
+		for _, de := range s.desired {
+			*create = append(*create, Correlation{d, nil, de})
+		}
+		return nil
+	}
+	// First pair records with the same target. Those are either modifications or unchanged.
+	byTarget := map[string][]int{}
+	for j, de := range s.desired {
+		byTarget[de.Target] = append(byTarget[de.Target], j)
+	}
+	pairedDesired := make([]bool, len(s.desired))
+	existingRecords := []*models.RecordConfig{}
+	for i := len(s.existing) - 1; i >= 0; i-- {
+		ex := s.existing[i]
+		js := byTarget[ex.Target]
+		if len(js) == 0 {
+			existingRecords = append(existingRecords, ex)
+			continue
+		}
+		de := s.desired[js[0]]
+		pairedDesired[js[0]] = true
+		byTarget[ex.Target] = js[1:]
+		// they're either identical or should be a modification of each other (ttl or metadata changes)
+		if d.same(de, ex) {
+			*unchanged = append(*unchanged, Correlation{d, ex, de})
+		} else {
+			*modify = append(*modify, Correlation{d, ex, de})
+		}
+	}
+
+	// Then compare the rest by normalized content.
+	existingLookup := map[string]*models.RecordConfig{}
+	for _, ex := range existingRecords {
+		normalized := d.content(ex)
+		if existingLookup[normalized] != nil {
+			return fmt.Errorf("%s %s exists twice at the provider: %s", s.key.rType, s.key.name, normalized)
+		}
+		existingLookup[normalized] = ex
 	}
-	return changedKeys
+	desiredLookup := map[string]*models.RecordConfig{}
+	for j, de := range s.desired {
+		if pairedDesired[j] {
+			continue
+		}
+		normalized := d.content(de)
+		if desiredLookup[normalized] != nil {
+			return fmt.Errorf("%s %s is declared twice: %s", s.key.rType, s.key.name, normalized)
+		}
+		desiredLookup[normalized] = de
+	}
+	// if a record is in both, it is unchanged
+	for norm, ex := range existingLookup {
+		if de, ok := desiredLookup[norm]; ok {
+			*unchanged = append(*unchanged, Correlation{d, ex, de})
+			delete(existingLookup, norm)
+			delete(desiredLookup, norm)
+		}
+	}
+	// sort records by normalized text. Keeps behaviour deterministic
+	existingStrings, desiredStrings := sortedKeys(existingLookup), sortedKeys(desiredLookup)
+	// Modifications. Take 1 from each side.
+	for len(desiredStrings) > 0 && len(existingStrings) > 0 {
+		*modify = append(*modify, Correlation{d, existingLookup[existingStrings[0]], desiredLookup[desiredStrings[0]]})
+		existingStrings = existingStrings[1:]
+		desiredStrings = desiredStrings[1:]
+	}
+	// If desired still has things they are additions
+	for _, norm := range desiredStrings {
+		*create = append(*create, Correlation{d, nil, desiredLookup[norm]})
+	}
+	// if found, but not desired, delete it
+	for _, norm := range existingStrings {
+		*toDelete = append(*toDelete, Correlation{d, existingLookup[norm], nil})
+	}
+	return nil
+}
+
+// same reports whether two records with the same name and type have the same content.
+// It is equivalent to comparing their content, but avoids formatting the records when
+// all fields that make up the content are equal, which is by far the most common case.
+func (d *differ) same(a, b *models.RecordConfig) bool {
+	if len(d.extraValues) == 0 &&
+		a.Target == b.Target &&
+		a.TTL == b.TTL &&
+		a.CombinedTarget == b.CombinedTarget &&
+		a.MxPreference == b.MxPreference &&
+		a.SrvPriority == b.SrvPriority &&
+		a.SrvWeight == b.SrvWeight &&
+		a.SrvPort == b.SrvPort &&
+		a.CaaTag == b.CaaTag &&
+		a.CaaFlag == b.CaaFlag &&
+		a.TlsaUsage == b.TlsaUsage &&
+		a.TlsaSelector == b.TlsaSelector &&
+		a.TlsaMatchingType == b.TlsaMatchingType &&
+		equalStrings(a.TxtStrings, b.TxtStrings) {
+		return true
+	}
+	return d.content(a) == d.content(b)
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func (d *differ) ChangedGroups(existing []*models.RecordConfig) (map[models.RecordKey][]string, error) {
+	sets, err := d.ChangedRecordSets(existing)
+	if err != nil {
+		return nil, err
+	}
+	changedKeys := map[models.RecordKey][]string{}
+	for _, set := range sets {
+		for _, c := range set.Changes {
+			changedKeys[set.Key] = append(changedKeys[set.Key], c.String())
+		}
+	}
+	return changedKeys, nil
 }
 
 func (c Correlation) String() string {
@@ -206,6 +314,30 @@ func (c Correlation) String() string {
 	return fmt.Sprintf("MODIFY %s %s: (%s) -> (%s)", c.Existing.Type, c.Existing.NameFQDN, c.d.content(c.Existing), c.d.content(c.Desired))
 }
 
+// Change returns the change in structured form, with the same content as String.
+func (c Correlation) Change() models.Change {
+	switch {
+	case c.Existing == nil:
+		return models.Change{Action: "CREATE", Type: c.Desired.Type, Name: c.Desired.NameFQDN,
+			New: c.d.value(c.Desired, c.Desired.Content()), NewTTL: c.Desired.TTL}
+	case c.Desired == nil:
+		return models.Change{Action: "DELETE", Type: c.Existing.Type, Name: c.Existing.NameFQDN,
+			Old: c.d.value(c.Existing, c.Existing.Content()), OldTTL: c.Existing.TTL}
+	}
+	return models.Change{Action: "MODIFY", Type: c.Existing.Type, Name: c.Existing.NameFQDN,
+		Old: c.d.value(c.Existing, c.Existing.Content()), OldTTL: c.Existing.TTL,
+		New: c.d.value(c.Desired, c.Desired.Content()), NewTTL: c.Desired.TTL}
+}
+
+// Changes returns the changes in structured form, for models.Correction.
+func (cs Changeset) Changes() []models.Change {
+	changes := make([]models.Change, len(cs))
+	for i, c := range cs {
+		changes[i] = c.Change()
+	}
+	return changes
+}
+
 func sortedKeys(m map[string]*models.RecordConfig) []string {
 	s := []string{}
 	for v := range m {
@@ -215,13 +347,37 @@ func sortedKeys(m map[string]*models.RecordConfig) []string {
 	return s
 }
 
-func (d *differ) matchIgnored(nameFQDN, domain string) bool {
-	// ignored labels are not fqdn
-	name := dnsutil.TrimDomainName(nameFQDN, domain)
-	for _, tst := range d.dc.IgnoredLabels {
-		if name == tst || nameFQDN == tst {
-			return true
+func (d *differ) matchIgnored(rec *models.RecordConfig) bool {
+	return d.dc.IgnoredBy(rec) != nil
+}
+
+// declared returns the OwnershipKey of the desired records if the domain uses PURGE_OWNED, or nil.
+func (d *differ) declared() map[string]bool {
+	if !d.dc.PurgeOwned {
+		return nil
+	}
+	keys := make(map[string]bool, len(d.dc.Records))
+	for _, r := range d.dc.Records {
+		keys[r.OwnershipKey()] = true
+	}
+	return keys
+}
+
+// unowned reports whether an existing record must be left alone because of PURGE_OWNED.
+// Records that are declared are managed even if DNSControl did not create them.
+func (d *differ) unowned(rec *models.RecordConfig, declared map[string]bool) bool {
+	return !d.dc.IsOwned(rec) && !declared[rec.OwnershipKey()]
+}
+
+func (d *differ) Kept(existing []*models.RecordConfig) models.Records {
+	d.canonicalize("existing", existing)
+	d.canonicalize("desired", d.dc.Records)
+	declared := d.declared()
+	kept := models.Records{}
+	for _, r := range existing {
+		if d.matchIgnored(r) || d.unowned(r, declared) {
+			kept = append(kept, r)
 		}
 	}
-	return false
+	return kept
 }
@@ -1,6 +1,7 @@
 package diff
 
 import (
+	"fmt"
 	"strconv"
 	"strings"
 	"testing"
@@ -153,18 +154,21 @@ func checkLengths(t *testing.T, existing, desired []*models.RecordConfig, unCoun
 }
 
 func checkLengthsWithKeepUnknown(t *testing.T, existing, desired []*models.RecordConfig, unCount, createCount, delCount, modCount int, keepUnknown bool, valFuncs ...func(*models.RecordConfig) map[string]string) (un, cre, del, mod Changeset) {
-	return checkLengthsFull(t, existing, desired, unCount, createCount, delCount, modCount, keepUnknown, []string{}, valFuncs...)
+	return checkLengthsFull(t, existing, desired, unCount, createCount, delCount, modCount, keepUnknown, nil, valFuncs...)
 }
 
-func checkLengthsFull(t *testing.T, existing, desired []*models.RecordConfig, unCount, createCount, delCount, modCount int, keepUnknown bool, ignoredRecords []string, valFuncs ...func(*models.RecordConfig) map[string]string) (un, cre, del, mod Changeset) {
+func checkLengthsFull(t *testing.T, existing, desired []*models.RecordConfig, unCount, createCount, delCount, modCount int, keepUnknown bool, ignored []*models.IgnoreRule, valFuncs ...func(*models.RecordConfig) map[string]string) (un, cre, del, mod Changeset) {
 	dc := &models.DomainConfig{
-		Name:          "example.com",
-		Records:       desired,
-		KeepUnknown:   keepUnknown,
-		IgnoredLabels: ignoredRecords,
+		Name:        "example.com",
+		Records:     desired,
+		KeepUnknown: keepUnknown,
+		Ignored:     ignored,
 	}
 	d := New(dc, valFuncs...)
-	un, cre, del, mod = d.IncrementalDiff(existing)
+	un, cre, del, mod, err := d.IncrementalDiff(existing)
+	if err != nil {
+		t.Fatal(err)
+	}
 	if len(un) != unCount {
 		t.Errorf("Got %d unchanged records, but expected %d", len(un), unCount)
 	}
@@ -195,6 +199,66 @@ func TestNoPurge(t *testing.T) {
 	checkLengthsWithKeepUnknown(t, existing, desired, 1, 0, 1, 0, true)
 }
 
+func TestPurgeOwned(t *testing.T) {
+	old := myRecord("old A 1 1.1.1.1")
+	existing := []*models.RecordConfig{
+		old,
+		myRecord("ext A 1 2.2.2.2"),
+		myRecord("www A 1 3.3.3.3"),
+		myRecord("www A 1 4.4.4.4"),
+	}
+	desired := []*models.RecordConfig{
+		myRecord("www A 1 3.3.3.3"),
+		myRecord("new A 1 5.5.5.5"),
+	}
+	dc := &models.DomainConfig{
+		Name:       "example.com",
+		Records:    desired,
+		PurgeOwned: true,
+		Owned:      map[string]bool{old.OwnershipKey(): true},
+	}
+	d := New(dc)
+	un, cre, del, mod, err := d.IncrementalDiff(existing)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(un) != 1 || len(cre) != 1 || len(del) != 1 || len(mod) != 0 || del[0].Existing != old {
+		t.Fatalf("expected only the owned record to be deleted, got %v %v %v %v", un, cre, del, mod)
+	}
+	kept := d.Kept(existing)
+	if len(kept) != 2 || kept[0] != existing[1] || kept[1] != existing[3] {
+		t.Errorf("expected the records not owned to be kept, got %v", kept)
+	}
+}
+
+func TestOnDiff(t *testing.T) {
+	existing := []*models.RecordConfig{
+		myRecord("www A 1 1.1.1.1"),
+		myRecord("old A 1 2.2.2.2"),
+		myRecord("same A 1 3.3.3.3"),
+	}
+	desired := []*models.RecordConfig{
+		myRecord("www A 1 4.4.4.4"),
+		myRecord("new A 1 5.5.5.5"),
+		myRecord("same A 1 3.3.3.3"),
+	}
+	var before, after models.Records
+	dc := &models.DomainConfig{Name: "example.com", Records: desired, OnDiff: func(e, r models.Records) { before, after = e, r }}
+	if _, _, _, _, err := New(dc).IncrementalDiff(existing); err != nil {
+		t.Fatal(err)
+	}
+	if len(before) != 3 || len(after) != 3 {
+		t.Fatalf("expected 3 records before and after, got %v and %v", before, after)
+	}
+	targets := map[string]bool{}
+	for _, r := range after {
+		targets[r.Target] = true
+	}
+	if !targets["3.3.3.3"] || !targets["4.4.4.4"] || !targets["5.5.5.5"] {
+		t.Errorf("unexpected result %v", after)
+	}
+}
+
 func TestIgnoredRecords(t *testing.T) {
 	existing := []*models.RecordConfig{
 		myRecord("www1 MX 1 1.1.1.1"),
@@ -204,7 +268,40 @@ func TestIgnoredRecords(t *testing.T) {
 	desired := []*models.RecordConfig{
 		myRecord("www3 MX 1 2.2.2.2"),
 	}
-	checkLengthsFull(t, existing, desired, 0, 0, 0, 1, false, []string{"www1", "www2"})
+	checkLengthsFull(t, existing, desired, 0, 0, 0, 1, false, ignoreLabels("www1", "www2"))
+}
+
+func ignoreLabels(labels ...string) []*models.IgnoreRule {
+	rules := []*models.IgnoreRule{}
+	for _, l := range labels {
+		rules = append(rules, &models.IgnoreRule{Label: l})
+	}
+	return rules
+}
+
+func TestIgnoredPatterns(t *testing.T) {
+	existing := []*models.RecordConfig{
+		myRecord("a.k8s A 1 10.0.0.1"),
+		myRecord("b.k8s A 1 10.0.0.2"),
+		myRecord("x.b.k8s A 1 10.0.0.3"),
+		myRecord("www A 1 10.0.0.4"),
+		myRecord("www A 1 192.168.0.1"),
+		myRecord("www MX 1 mx.example.net."),
+	}
+	ignored := []*models.IgnoreRule{
+		{Label: "*.k8s"},
+		{Label: "www", Types: []string{"A"}, Target: "10.0.*.*"},
+	}
+	// x.b.k8s is not matched by "*", which stays within one label; the MX has another type.
+	_, _, del, _ := checkLengthsFull(t, existing, nil, 0, 0, 3, 0, false, ignored)
+	for _, c := range del {
+		if c.Existing.Name != "x.b.k8s" && c.Existing.Name != "www" {
+			t.Errorf("deleted ignored record %s", c)
+		}
+		if c.Existing.Name == "www" && c.Existing.Target == "10.0.0.4" {
+			t.Errorf("deleted ignored record %s", c)
+		}
+	}
 }
 
 func TestModifyingIgnoredRecords(t *testing.T) {
@@ -216,12 +313,105 @@ func TestModifyingIgnoredRecords(t *testing.T) {
 	desired := []*models.RecordConfig{
 		myRecord("www2 MX 1 2.2.2.2"),
 	}
+	// normalize refuses to manage IGNOREd records; should one get here, it is left alone.
+	checkLengthsFull(t, existing, desired, 0, 0, 1, 0, false, ignoreLabels("www1", "www2"))
+}
+
+func TestDuplicateRecords(t *testing.T) {
+	dup := []*models.RecordConfig{
+		myRecord("www A 1 1.1.1.1"),
+		myRecord("www A 1 1.1.1.1"),
+	}
+	other := []*models.RecordConfig{
+		myRecord("www A 1 2.2.2.2"),
+	}
+	for _, tst := range []struct {
+		desc              string
+		existing, desired []*models.RecordConfig
+	}{
+		{"existing", dup, other},
+		{"desired", other, dup},
+	} {
+		dc := &models.DomainConfig{Name: "example.com", Records: tst.desired}
+		if _, _, _, _, err := New(dc).IncrementalDiff(tst.existing); err == nil {
+			t.Errorf("%s: expected an error for the duplicate record", tst.desc)
+		}
+	}
+}
+
+func TestLargeRecordSet(t *testing.T) {
+	// A single set with many records must be paired by target, not compared pairwise.
+	existing, desired := []*models.RecordConfig{}, []*models.RecordConfig{}
+	for i := 0; i < 20000; i++ {
+		existing = append(existing, myRecord(fmt.Sprintf("@ NS 1 ns%d.example.net.", i)))
+		desired = append(desired, myRecord(fmt.Sprintf("@ NS 1 ns%d.example.net.", i+1)))
+	}
+	checkLengths(t, existing, desired, 19999, 0, 0, 1)
+}
+
+func TestChanges(t *testing.T) {
+	existing := []*models.RecordConfig{
+		myRecord("www A 1 1.1.1.1"),
+		myRecord("old A 1 2.2.2.2"),
+	}
+	desired := []*models.RecordConfig{
+		myRecord("www A 10 1.1.1.1"),
+		myRecord("new A 1 3.3.3.3"),
+	}
+	dc := &models.DomainConfig{Name: "example.com", Records: desired}
+	_, cre, del, mod, err := New(dc).IncrementalDiff(existing)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []models.Change{
+		{Action: "CREATE", Type: "A", Name: "new.example.com", New: "3.3.3.3", NewTTL: 1},
+		{Action: "DELETE", Type: "A", Name: "old.example.com", Old: "2.2.2.2", OldTTL: 1},
+		{Action: "MODIFY", Type: "A", Name: "www.example.com", Old: "1.1.1.1", OldTTL: 1, New: "1.1.1.1", NewTTL: 10},
+	}
+	changes := append(append(cre.Changes(), del.Changes()...), mod.Changes()...)
+	if len(changes) != len(expected) {
+		t.Fatalf("Expected %d changes, got %d: %v", len(expected), len(changes), changes)
+	}
+	for i := range expected {
+		if changes[i] != expected[i] {
+			t.Errorf("Expected %+v, got %+v", expected[i], changes[i])
+		}
+	}
+}
 
-	defer func() {
-		if r := recover(); r == nil {
-			t.Errorf("should panic: modification of IGNOREd record")
+// reverseZone returns n PTR records of 10.in-addr.arpa. If changed is true,
+// every 100th record is deleted, modified or added, in turn.
+func reverseZone(n int, changed bool) []*models.RecordConfig {
+	recs := make([]*models.RecordConfig, 0, n)
+	for i := 0; i < n; i++ {
+		name := fmt.Sprintf("%d.%d.%d", i%256, i/256%256, i/65536)
+		target := fmt.Sprintf("host%d.example.com.", i)
+		if changed && i%100 == 0 {
+			switch i / 100 % 3 {
+			case 0:
+				continue
+			case 1:
+				target = fmt.Sprintf("renamed%d.example.com.", i)
+			case 2:
+				recs = append(recs, &models.RecordConfig{Type: "PTR", Name: "new." + name, NameFQDN: "new." + name + ".10.in-addr.arpa", Target: target, TTL: 300})
+			}
 		}
-	}()
+		recs = append(recs, &models.RecordConfig{Type: "PTR", Name: name, NameFQDN: name + ".10.in-addr.arpa", Target: target, TTL: 300})
+	}
+	return recs
+}
 
-	checkLengthsFull(t, existing, desired, 0, 0, 0, 1, false, []string{"www1", "www2"})
+func BenchmarkIncrementalDiff(b *testing.B) {
+	for _, n := range []int{1000, 10000, 100000} {
+		b.Run(strconv.Itoa(n), func(b *testing.B) {
+			existing := reverseZone(n, false)
+			dc := &models.DomainConfig{Name: "10.in-addr.arpa", Records: reverseZone(n, true)}
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, _, _, _, err := New(dc).IncrementalDiff(existing); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
 }
@@ -0,0 +1,93 @@
+package diff
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/StackExchange/dnscontrol/models"
+)
+
+// RecordSetChange is a change to a record set: all records with the same name and type.
+// Providers with a "RecordSet" model replace the whole set at once.
+type RecordSetChange struct {
+	Key      models.RecordKey // the short name and type of the set
+	NameFQDN string
+	Existing models.Records // the whole set as it is now; empty if the set is created
+	Desired  models.Records // the whole set as it should be, including the records left alone because of IGNORE or PURGE_OWNED; empty if the set is deleted
+	Changes  Changeset      // the records created, deleted or modified, in that order
+}
+
+// Kind returns whether the set is created, deleted or modified.
+func (c RecordSetChange) Kind() models.CorrectionKind {
+	switch {
+	case len(c.Existing) == 0:
+		return models.CorrectionCreate
+	case len(c.Desired) == 0:
+		return models.CorrectionDelete
+	default:
+		return models.CorrectionModify
+	}
+}
+
+// String describes the changes of the set, one record per line.
+func (c RecordSetChange) String() string {
+	lines := make([]string, len(c.Changes))
+	for i, ch := range c.Changes {
+		lines[i] = ch.String()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// ChangedRecordSets performs a diff and returns the record sets that changed, sorted by name and type.
+func (d *differ) ChangedRecordSets(existing []*models.RecordConfig) ([]RecordSetChange, error) {
+	_, create, toDelete, modify, err := d.IncrementalDiff(existing)
+	if err != nil {
+		return nil, err
+	}
+	sets := map[key]*RecordSetChange{}
+	change := func(r *models.RecordConfig, c Correlation) {
+		k := key{r.NameFQDN, r.Type}
+		if sets[k] == nil {
+			sets[k] = &RecordSetChange{Key: r.Key(), NameFQDN: r.NameFQDN}
+		}
+		sets[k].Changes = append(sets[k].Changes, c)
+	}
+	for _, c := range create {
+		change(c.Desired, c)
+	}
+	for _, c := range toDelete {
+		change(c.Existing, c)
+	}
+	for _, c := range modify {
+		change(c.Desired, c)
+	}
+	if len(sets) == 0 {
+		return nil, nil
+	}
+	declared := d.declared()
+	for _, r := range existing {
+		if s := sets[key{r.NameFQDN, r.Type}]; s != nil {
+			s.Existing = append(s.Existing, r)
+			if d.matchIgnored(r) || d.unowned(r, declared) {
+				// replacing the set must keep the records left alone
+				s.Desired = append(s.Desired, r)
+			}
+		}
+	}
+	for _, r := range d.dc.Records {
+		if s := sets[key{r.NameFQDN, r.Type}]; s != nil && !d.matchIgnored(r) {
+			s.Desired = append(s.Desired, r)
+		}
+	}
+	changes := make([]RecordSetChange, 0, len(sets))
+	for _, s := range sets {
+		changes = append(changes, *s)
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		if changes[i].NameFQDN != changes[j].NameFQDN {
+			return changes[i].NameFQDN < changes[j].NameFQDN
+		}
+		return changes[i].Key.Type < changes[j].Key.Type
+	})
+	return changes, nil
+}
@@ -0,0 +1,83 @@
+package diff
+
+import (
+	"testing"
+
+	"github.com/StackExchange/dnscontrol/models"
+)
+
+func TestChangedRecordSets(t *testing.T) {
+	existing := []*models.RecordConfig{
+		myRecord("www A 1 1.1.1.1"),
+		myRecord("www A 1 2.2.2.2"),
+		myRecord("old CNAME 1 target.example.net."),
+		myRecord("same A 1 3.3.3.3"),
+	}
+	desired := []*models.RecordConfig{
+		myRecord("www A 1 1.1.1.1"),
+		myRecord("www A 1 4.4.4.4"),
+		myRecord("new MX 1 mx1"),
+		myRecord("same A 1 3.3.3.3"),
+	}
+	dc := &models.DomainConfig{Name: "example.com", Records: desired}
+	sets, err := New(dc).ChangedRecordSets(existing)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []struct {
+		name     string
+		kind     models.CorrectionKind
+		existing int
+		desired  int
+		msg      string
+	}{
+		{"new.example.com", models.CorrectionCreate, 0, 1, "CREATE MX new.example.com 0 mx1 ttl=1"},
+		{"old.example.com", models.CorrectionDelete, 1, 0, "DELETE CNAME old.example.com target.example.net. ttl=1"},
+		{"www.example.com", models.CorrectionModify, 2, 2, "MODIFY A www.example.com: (2.2.2.2 ttl=1) -> (4.4.4.4 ttl=1)"},
+	}
+	if len(sets) != len(expected) {
+		t.Fatalf("expected %d changed sets, got %d: %v", len(expected), len(sets), sets)
+	}
+	for i, e := range expected {
+		s := sets[i]
+		if s.NameFQDN != e.name || s.Kind() != e.kind || len(s.Existing) != e.existing || len(s.Desired) != e.desired {
+			t.Errorf("set %d: expected %s %s with %d existing and %d desired records, got %s %s with %d and %d",
+				i, e.kind, e.name, e.existing, e.desired, s.Kind(), s.NameFQDN, len(s.Existing), len(s.Desired))
+		}
+		if s.String() != e.msg {
+			t.Errorf("set %d: expected %q, got %q", i, e.msg, s.String())
+		}
+	}
+	if www := sets[2]; www.Key != (models.RecordKey{Name: "www", Type: "A"}) || www.Desired[1] != desired[1] {
+		t.Errorf("expected the desired records of www, got %+v", www)
+	}
+}
+
+func TestChangedRecordSetsUnchanged(t *testing.T) {
+	records := []*models.RecordConfig{myRecord("www A 1 1.1.1.1")}
+	dc := &models.DomainConfig{Name: "example.com", Records: records}
+	if sets, err := New(dc).ChangedRecordSets(records); err != nil || len(sets) != 0 {
+		t.Errorf("expected no changes, got %v, %v", sets, err)
+	}
+}
+
+func TestChangedRecordSetsKeepIgnored(t *testing.T) {
+	existing := []*models.RecordConfig{
+		myRecord("www A 1 10.0.0.1"),
+		myRecord("www A 1 1.1.1.1"),
+	}
+	desired := []*models.RecordConfig{
+		myRecord("www A 1 2.2.2.2"),
+	}
+	dc := &models.DomainConfig{Name: "example.com", Records: desired, Ignored: []*models.IgnoreRule{{Label: "www", Target: "10.*.*.*"}}}
+	sets, err := New(dc).ChangedRecordSets(existing)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sets) != 1 || len(sets[0].Desired) != 2 || len(sets[0].Existing) != 2 {
+		t.Fatalf("expected the set to keep the ignored record, got %v", sets)
+	}
+	if s := sets[0].String(); s != "MODIFY A www.example.com: (1.1.1.1 ttl=1) -> (2.2.2.2 ttl=1)" {
+		t.Errorf("unexpected change %q", s)
+	}
+}
@@ -88,74 +88,122 @@ func (api *DoApi) EnsureDomainExists(domain string) error {
 
 // GetNameservers returns the nameservers for domain.
 func (api *DoApi) GetNameservers(domain string) ([]*models.Nameserver, error) {
+	return api.GetNameserversContext(context.Background(), domain)
+}
+
+// GetNameserversContext returns the nameservers for domain.
+func (api *DoApi) GetNameserversContext(ctx context.Context, domain string) ([]*models.Nameserver, error) {
 	return models.StringsToNameservers(defaultNameServerNames), nil
 }
 
-// GetDomainCorrections returns a list of corretions for the  domain.
-func (api *DoApi) GetDomainCorrections(dc *models.DomainConfig) ([]*models.Correction, error) {
+// ListZones returns all domains in the account.
+func (api *DoApi) ListZones() ([]string, error) {
 	ctx := context.Background()
-	dc.Punycode()
 
-	records, err := getRecords(api, dc.Name)
+	zones := []string{}
+	opt := &godo.ListOptions{}
+	for {
+		result, resp, err := api.client.Domains.List(ctx, opt)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, d := range result {
+			zones = append(zones, d.Name)
+		}
+
+		if resp.Links == nil || resp.Links.IsLastPage() {
+			break
+		}
+
+		page, err := resp.Links.CurrentPage()
+		if err != nil {
+			return nil, err
+		}
+
+		opt.Page = page + 1
+	}
+
+	return zones, nil
+}
+
+// GetZoneRecords gets the records of a zone.
+func (api *DoApi) GetZoneRecords(domain string) (models.Records, error) {
+	return api.getZoneRecords(context.Background(), domain)
+}
+
+func (api *DoApi) getZoneRecords(ctx context.Context, domain string) (models.Records, error) {
+	records, err := getRecords(ctx, api, domain)
 	if err != nil {
 		return nil, err
 	}
 
-	existingRecords := make([]*models.RecordConfig, len(records))
+	existingRecords := make(models.Records, len(records))
 	for i := range records {
-		existingRecords[i] = toRc(dc, &records[i])
+		existingRecords[i] = toRc(domain, &records[i])
 	}
 
 	// Normalize
 	models.PostProcessRecords(existingRecords)
+	return existingRecords, nil
+}
 
-	differ := diff.New(dc)
-	_, create, delete, modify := differ.IncrementalDiff(existingRecords)
+// GetDomainCorrections returns a list of corretions for the  domain.
+func (api *DoApi) GetDomainCorrections(dc *models.DomainConfig) ([]*models.Correction, error) {
+	return api.GetDomainCorrectionsContext(context.Background(), dc)
+}
 
-	var corrections = []*models.Correction{}
+// GetDomainCorrectionsContext returns a list of corretions for the domain.
+// The API calls, including those of the corrections, stop when ctx is done.
+func (api *DoApi) GetDomainCorrectionsContext(ctx context.Context, dc *models.DomainConfig) ([]*models.Correction, error) {
+	dc.Punycode()
 
-	// Deletes first so changing type works etc.
-	for _, m := range delete {
-		id := m.Existing.Original.(*godo.DomainRecord).ID
-		corr := &models.Correction{
-			Msg: fmt.Sprintf("%s, DO ID: %d", m.String(), id),
-			F: func() error {
-				_, err := api.client.Domains.DeleteRecord(ctx, dc.Name, id)
-				return err
-			},
-		}
-		corrections = append(corrections, corr)
-	}
-	for _, m := range create {
-		req := toReq(dc, m.Desired)
-		corr := &models.Correction{
-			Msg: m.String(),
-			F: func() error {
-				_, _, err := api.client.Domains.CreateRecord(ctx, dc.Name, req)
-				return err
-			},
-		}
-		corrections = append(corrections, corr)
+	existingRecords, err := api.getZoneRecords(ctx, dc.Name)
+	if err != nil {
+		return nil, err
 	}
-	for _, m := range modify {
-		id := m.Existing.Original.(*godo.DomainRecord).ID
-		req := toReq(dc, m.Desired)
-		corr := &models.Correction{
-			Msg: fmt.Sprintf("%s, DO ID: %d", m.String(), id),
-			F: func() error {
-				_, _, err := api.client.Domains.EditRecord(ctx, dc.Name, id, req)
-				return err
-			},
-		}
-		corrections = append(corrections, corr)
+
+	differ := diff.NewForProvider("DIGITALOCEAN", dc)
+	_, create, delete, modify, err := differ.IncrementalDiff(existingRecords)
+	if err != nil {
+		return nil, err
 	}
 
-	return corrections, nil
+	return diff.Corrections(create, delete, modify, func(kind models.CorrectionKind, m diff.Correlation) (*models.Correction, error) {
+		switch kind {
+		case models.CorrectionDelete:
+			id := m.Existing.Original.(*godo.DomainRecord).ID
+			return &models.Correction{
+				Msg: fmt.Sprintf("%s, DO ID: %d", m.String(), id),
+				FC: func(ctx context.Context) error {
+					_, err := api.client.Domains.DeleteRecord(ctx, dc.Name, id)
+					return err
+				},
+			}, nil
+		case models.CorrectionCreate:
+			req := toReq(dc, m.Desired)
+			return &models.Correction{
+				Msg: m.String(),
+				FC: func(ctx context.Context) error {
+					_, _, err := api.client.Domains.CreateRecord(ctx, dc.Name, req)
+					return err
+				},
+			}, nil
+		default:
+			id := m.Existing.Original.(*godo.DomainRecord).ID
+			req := toReq(dc, m.Desired)
+			return &models.Correction{
+				Msg: fmt.Sprintf("%s, DO ID: %d", m.String(), id),
+				FC: func(ctx context.Context) error {
+					_, _, err := api.client.Domains.EditRecord(ctx, dc.Name, id, req)
+					return err
+				},
+			}, nil
+		}
+	})
 }
 
-func getRecords(api *DoApi, name string) ([]godo.DomainRecord, error) {
-	ctx := context.Background()
-
+func getRecords(ctx context.Context, api *DoApi, name string) ([]godo.DomainRecord, error) {
 	records := []godo.DomainRecord{}
 	opt := &godo.ListOptions{}
 	for {
@@ -183,9 +231,9 @@ func getRecords(api *DoApi, name string) ([]godo.DomainRecord, error) {
 	return records, nil
 }
 
-func toRc(dc *models.DomainConfig, r *godo.DomainRecord) *models.RecordConfig {
+func toRc(origin string, r *godo.DomainRecord) *models.RecordConfig {
 	// This handles "@" etc.
-	name := dnsutil.AddOrigin(r.Name, dc.Name)
+	name := dnsutil.AddOrigin(r.Name, origin)
 
 	target := r.Data
 	// Make target FQDN (#rtype_variations)
@@ -193,9 +241,9 @@ func toRc(dc *models.DomainConfig, r *godo.DomainRecord) *models.RecordConfig {
 		// If target is the domainname, e.g. cname foo.example.com -> example.com,
 		// DO returns "@" on read even if fqdn was written.
 		if target == "@" {
-			target = dc.Name
+			target = origin
 		}
-		target = dnsutil.AddOrigin(target+".", dc.Name)
+		target = dnsutil.AddOrigin(target+".", origin)
 	}
 
 	return &models.RecordConfig{
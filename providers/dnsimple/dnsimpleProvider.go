@@ -3,9 +3,11 @@ package dnsimple
 import (
 	"encoding/json"
 	"fmt"
+	"log"
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/StackExchange/dnscontrol/models"
 	"github.com/StackExchange/dnscontrol/providers"
@@ -45,6 +47,7 @@ type DnsimpleApi struct {
 	AccountToken string // The account access token
 	BaseURL      string // An alternate base URI
 	accountID    string // Account id cache
+	mu           sync.Mutex
 }
 
 // GetNameservers returns the name servers for a domain.
@@ -52,16 +55,44 @@ func (c *DnsimpleApi) GetNameservers(domainName string) ([]*models.Nameserver, e
 	return models.StringsToNameservers(defaultNameServerNames), nil
 }
 
-// GetDomainCorrections returns corrections that update a domain.
-func (c *DnsimpleApi) GetDomainCorrections(dc *models.DomainConfig) ([]*models.Correction, error) {
-	corrections := []*models.Correction{}
-	dc.Punycode()
-	records, err := c.getRecords(dc.Name)
+// ListZones returns all domains in the account.
+func (c *DnsimpleApi) ListZones() ([]string, error) {
+	client := c.getClient()
+
+	accountID, err := c.getAccountID()
 	if err != nil {
 		return nil, err
 	}
 
-	var actual []*models.RecordConfig
+	opts := &dnsimpleapi.DomainListOptions{}
+	zones := []string{}
+	opts.Page = 1
+	for {
+		domainsResponse, err := client.Domains.ListDomains(accountID, opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, d := range domainsResponse.Data {
+			zones = append(zones, d.Name)
+		}
+		pg := domainsResponse.Pagination
+		if pg.CurrentPage >= pg.TotalPages {
+			break
+		}
+		opts.Page++
+	}
+
+	return zones, nil
+}
+
+// GetZoneRecords gets the records of a zone.
+func (c *DnsimpleApi) GetZoneRecords(domain string) (models.Records, error) {
+	records, err := c.getRecords(domain)
+	if err != nil {
+		return nil, err
+	}
+
+	actual := models.Records{}
 	for _, r := range records {
 		if r.Type == "SOA" || r.Type == "NS" {
 			continue
@@ -78,7 +109,7 @@ func (c *DnsimpleApi) GetDomainCorrections(dc *models.DomainConfig) ([]*models.C
 			continue
 		}
 		rec := &models.RecordConfig{
-			NameFQDN:     dnsutil.AddOrigin(r.Name, dc.Name),
+			NameFQDN:     dnsutil.AddOrigin(r.Name, domain),
 			Type:         r.Type,
 			Target:       r.Content,
 			TTL:          uint32(r.TTL),
@@ -90,6 +121,21 @@ func (c *DnsimpleApi) GetDomainCorrections(dc *models.DomainConfig) ([]*models.C
 		}
 		actual = append(actual, rec)
 	}
+
+	// Normalize
+	models.PostProcessRecords(actual)
+	return actual, nil
+}
+
+// GetDomainCorrections returns corrections that update a domain.
+func (c *DnsimpleApi) GetDomainCorrections(dc *models.DomainConfig) ([]*models.Correction, error) {
+	corrections := []*models.Correction{}
+	dc.Punycode()
+	actual, err := c.GetZoneRecords(dc.Name)
+	if err != nil {
+		return nil, err
+	}
+
 	removeOtherNS(dc)
 	dc.Filter(func(r *models.RecordConfig) bool {
 		if r.Type == "CAA" || r.Type == "SRV" {
@@ -98,38 +144,38 @@ func (c *DnsimpleApi) GetDomainCorrections(dc *models.DomainConfig) ([]*models.C
 		return true
 	})
 
-	// Normalize
-	models.PostProcessRecords(actual)
-
-	differ := diff.New(dc)
-	_, create, delete, modify := differ.IncrementalDiff(actual)
-
-	for _, del := range delete {
-		rec := del.Existing.Original.(dnsimpleapi.ZoneRecord)
-		corrections = append(corrections, &models.Correction{
-			Msg: del.String(),
-			F:   c.deleteRecordFunc(rec.ID, dc.Name),
-		})
-	}
-
-	for _, cre := range create {
-		rec := cre.Desired
-		corrections = append(corrections, &models.Correction{
-			Msg: cre.String(),
-			F:   c.createRecordFunc(rec, dc.Name),
-		})
+	differ := diff.NewForProvider("DNSIMPLE", dc)
+	_, create, delete, modify, err := differ.IncrementalDiff(actual)
+	if err != nil {
+		return nil, err
 	}
 
-	for _, mod := range modify {
-		old := mod.Existing.Original.(dnsimpleapi.ZoneRecord)
-		new := mod.Desired
-		corrections = append(corrections, &models.Correction{
-			Msg: mod.String(),
-			F:   c.updateRecordFunc(&old, new, dc.Name),
-		})
+	changes, err := diff.Corrections(create, delete, modify, func(kind models.CorrectionKind, m diff.Correlation) (*models.Correction, error) {
+		switch kind {
+		case models.CorrectionDelete:
+			rec := m.Existing.Original.(dnsimpleapi.ZoneRecord)
+			return &models.Correction{
+				Msg: m.String(),
+				F:   c.deleteRecordFunc(rec.ID, dc.Name),
+			}, nil
+		case models.CorrectionCreate:
+			return &models.Correction{
+				Msg: m.String(),
+				F:   c.createRecordFunc(m.Desired, dc.Name),
+			}, nil
+		default:
+			old := m.Existing.Original.(dnsimpleapi.ZoneRecord)
+			return &models.Correction{
+				Msg: m.String(),
+				F:   c.updateRecordFunc(&old, m.Desired, dc.Name),
+			}, nil
+		}
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return corrections, nil
+	return append(corrections, changes...), nil
 }
 
 // GetRegistrarCorrections returns corrections that update a domain's registrar.
@@ -153,8 +199,9 @@ func (c *DnsimpleApi) GetRegistrarCorrections(dc *models.DomainConfig) ([]*model
 	if actual != expected {
 		return []*models.Correction{
 			{
-				Msg: fmt.Sprintf("Update nameservers %s -> %s", actual, expected),
-				F:   c.updateNameserversFunc(expectedSet, dc.Name),
+				Msg:  fmt.Sprintf("Update nameservers %s -> %s", actual, expected),
+				Kind: models.CorrectionNameservers,
+				F:    c.updateNameserversFunc(expectedSet, dc.Name),
 			},
 		}, nil
 	}
@@ -173,6 +220,8 @@ func (c *DnsimpleApi) getClient() *dnsimpleapi.Client {
 }
 
 func (c *DnsimpleApi) getAccountID() (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
 	if c.accountID == "" {
 		client := c.getClient()
 		whoamiResponse, err := client.Identity.Whoami()
@@ -369,7 +418,7 @@ func removeOtherNS(dc *models.DomainConfig) {
 			if rec.NameFQDN == dc.Name && strings.HasSuffix(rec.Target, ".dnsimple.com.") {
 				continue
 			}
-			fmt.Printf("Warning: dnsimple.com does not allow NS records to be modified. %s will not be added.\n", rec.Target)
+			log.Printf("Warning: dnsimple.com does not allow NS records to be modified. %s will not be added.\n", rec.Target)
 			continue
 		}
 		newList = append(newList, rec)
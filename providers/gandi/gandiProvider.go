@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"log"
 	"sort"
+	"sync"
 
 	"github.com/StackExchange/dnscontrol/models"
 	"github.com/StackExchange/dnscontrol/providers"
@@ -46,6 +47,7 @@ type GandiApi struct {
 	domainIndex map[string]int64 // Map of domainname to index
 	nameservers map[string][]*models.Nameserver
 	ZoneId      int64
+	mu          sync.Mutex // Protects domainIndex while it is fetched
 }
 
 type gandiRecord struct {
@@ -76,6 +78,35 @@ func (c *GandiApi) GetNameservers(domain string) ([]*models.Nameserver, error) {
 	return ns, nil
 }
 
+// ListZones returns all domains in the account.
+func (c *GandiApi) ListZones() ([]string, error) {
+	if err := c.fetchDomainList(); err != nil {
+		return nil, err
+	}
+	zones := make([]string, 0, len(c.domainIndex))
+	for d := range c.domainIndex {
+		zones = append(zones, d)
+	}
+	sort.Strings(zones)
+	return zones, nil
+}
+
+// GetZoneRecords gets the records of a zone.
+func (c *GandiApi) GetZoneRecords(domain string) (models.Records, error) {
+	domaininfo, err := c.getDomainInfo(domain)
+	if err != nil {
+		return nil, err
+	}
+	foundRecords, err := c.getZoneRecords(domaininfo.ZoneId, domain)
+	if err != nil {
+		return nil, err
+	}
+
+	// Normalize
+	models.PostProcessRecords(foundRecords)
+	return foundRecords, nil
+}
+
 // GetDomainCorrections returns a list of corrections recommended for this domain.
 func (c *GandiApi) GetDomainCorrections(dc *models.DomainConfig) ([]*models.Correction, error) {
 	dc.Punycode()
@@ -124,8 +155,14 @@ func (c *GandiApi) GetDomainCorrections(dc *models.DomainConfig) ([]*models.Corr
 	// Normalize
 	models.PostProcessRecords(foundRecords)
 
-	differ := diff.New(dc)
-	_, create, del, mod := differ.IncrementalDiff(foundRecords)
+	differ := diff.NewForProvider("GANDI", dc)
+	_, create, del, mod, err := differ.IncrementalDiff(foundRecords)
+	if err != nil {
+		return nil, err
+	}
+
+	// The zone is replaced, so the records the diff left alone must be sent again.
+	expectedRecordSets = append(expectedRecordSets, keptRecordSets(differ.Kept(foundRecords))...)
 
 	// Print a list of changes. Generate an actual change that is the zone
 	changes := false
@@ -148,9 +185,10 @@ func (c *GandiApi) GetDomainCorrections(dc *models.DomainConfig) ([]*models.Corr
 	if changes {
 		corrections = append(corrections,
 			&models.Correction{
-				Msg: msg,
+				Msg:     msg,
+				Changes: append(append(create.Changes(), del.Changes()...), mod.Changes()...),
 				F: func() error {
-					fmt.Printf("CREATING ZONE: %v\n", dc.Name)
+					log.Printf("CREATING ZONE: %v\n", dc.Name)
 					return c.createGandiZone(dc.Name, domaininfo.ZoneId, expectedRecordSets)
 				},
 			})
@@ -159,6 +197,30 @@ func (c *GandiApi) GetDomainCorrections(dc *models.DomainConfig) ([]*models.Corr
 	return corrections, nil
 }
 
+// keptRecordSets returns the record sets of existing records that must stay in the zone.
+// Their values are combined and quoted again, like those of the expected records,
+// since convert() split them.
+func keptRecordSets(records models.Records) []gandirecord.RecordSet {
+	kept := &models.DomainConfig{Records: records}
+	kept.CombineSRVs()
+	kept.CombineCAAs()
+	kept.CombineMXs()
+	sets := make([]gandirecord.RecordSet, 0, len(records))
+	for _, rec := range kept.Records {
+		value := rec.Target
+		if rec.Type == "TXT" {
+			value = "\"" + rec.Target + "\""
+		}
+		sets = append(sets, gandirecord.RecordSet{
+			"type":  rec.Type,
+			"name":  rec.Name,
+			"value": value,
+			"ttl":   rec.TTL,
+		})
+	}
+	return sets
+}
+
 func newDsp(conf map[string]string, metadata json.RawMessage) (providers.DNSServiceProvider, error) {
 	return newGandi(conf, metadata)
 }
@@ -194,7 +256,8 @@ func (c *GandiApi) GetRegistrarCorrections(dc *models.DomainConfig) ([]*models.C
 	if found != desired {
 		return []*models.Correction{
 			{
-				Msg: fmt.Sprintf("Change Nameservers from '%s' to '%s'", found, desired),
+				Msg:  fmt.Sprintf("Change Nameservers from '%s' to '%s'", found, desired),
+				Kind: models.CorrectionNameservers,
 				F: func() (err error) {
 					_, err = c.setDomainNameservers(dc.Name, desiredNs)
 					return
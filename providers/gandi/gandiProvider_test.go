@@ -0,0 +1,42 @@
+package gandi
+
+import (
+	"testing"
+
+	"github.com/StackExchange/dnscontrol/models"
+	"github.com/StackExchange/dnscontrol/providers/diff"
+	gandirecord "github.com/prasmussen/gandi-api/domain/zone/record"
+)
+
+func TestKeptRecordSets(t *testing.T) {
+	found := []*gandirecord.RecordInfo{
+		{Name: "@", Type: "MX", Ttl: 300, Value: "10 mail.example.com."},
+		{Name: "_sip._tcp", Type: "SRV", Ttl: 300, Value: "10 20 5060 sip.example.com."},
+		{Name: "@", Type: "CAA", Ttl: 300, Value: `0 issue "letsencrypt.org"`},
+		{Name: "@", Type: "TXT", Ttl: 300, Value: `"v=spf1 -all"`},
+		{Name: "www", Type: "A", Ttl: 300, Value: "1.2.3.4"},
+	}
+	existing := []*models.RecordConfig{}
+	for _, r := range found {
+		existing = append(existing, convert(r, "example.com"))
+	}
+	// All but www are ignored, so the differ keeps them.
+	dc := &models.DomainConfig{
+		Name:    "example.com",
+		Records: models.Records{{Type: "A", Name: "www", NameFQDN: "www.example.com", Target: "1.2.3.5", TTL: 300}},
+		Ignored: []*models.IgnoreRule{{Label: "@"}, {Label: "_sip._tcp"}},
+	}
+	differ := diff.NewForProvider("GANDI", dc)
+	if _, _, _, _, err := differ.IncrementalDiff(existing); err != nil {
+		t.Fatal(err)
+	}
+	sets := keptRecordSets(differ.Kept(existing))
+	if len(sets) != len(found)-1 {
+		t.Fatalf("expected %d record sets, got %d", len(found)-1, len(sets))
+	}
+	for i, r := range found[:len(found)-1] {
+		if sets[i]["value"] != r.Value {
+			t.Errorf("%s %s: expected the value %q, got %q", r.Type, r.Name, r.Value, sets[i]["value"])
+		}
+	}
+}
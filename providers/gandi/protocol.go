@@ -2,6 +2,7 @@ package gandi
 
 import (
 	"fmt"
+	"log"
 
 	gandiclient "github.com/prasmussen/gandi-api/client"
 	gandidomain "github.com/prasmussen/gandi-api/domain"
@@ -17,6 +18,8 @@ import (
 
 // fetchDomainList gets list of domains for account. Cache ids for easy lookup.
 func (c *GandiApi) fetchDomainList() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
 	if c.domainIndex != nil {
 		return nil
 	}
@@ -96,7 +99,7 @@ func (c *GandiApi) getEditableZone(domainname string, zoneinfo *gandizone.ZoneIn
 	if zoneinfo.Domains < 2 {
 		// If there is only on{ domain linked to this zone, use it.
 		zoneID = zoneinfo.Id
-		fmt.Printf("Using zone id=%d named %#v\n", zoneID, zoneinfo.Name)
+		log.Printf("Using zone id=%d named %#v\n", zoneID, zoneinfo.Name)
 		return zoneID, nil
 	}
 
@@ -109,7 +112,7 @@ func (c *GandiApi) getEditableZone(domainname string, zoneinfo *gandizone.ZoneIn
 	for _, z := range zones {
 		if z.Name == zonename {
 			zoneID = z.Id
-			fmt.Printf("Recycling zone id=%d named %#v\n", zoneID, z.Name)
+			log.Printf("Recycling zone id=%d named %#v\n", zoneID, z.Name)
 			return zoneID, nil
 		}
 	}
@@ -118,7 +121,7 @@ func (c *GandiApi) getEditableZone(domainname string, zoneinfo *gandizone.ZoneIn
 		return 0, err
 	}
 	zoneID = zoneinfo.Id
-	fmt.Printf("Created zone id=%d named %#v\n", zoneID, zoneinfo.Name)
+	log.Printf("Created zone id=%d named %#v\n", zoneID, zoneinfo.Name)
 	return zoneID, nil
 }
 
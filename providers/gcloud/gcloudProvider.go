@@ -4,7 +4,9 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sort"
 	"strings"
+	"sync"
 
 	gauth "golang.org/x/oauth2/google"
 	"google.golang.org/api/dns/v1"
@@ -31,6 +33,7 @@ type gcloud struct {
 	client  *dns.Service
 	project string
 	zones   map[string]*dns.ManagedZone
+	mu      sync.Mutex // Protects zones
 }
 
 // New creates a new gcloud provider
@@ -63,29 +66,56 @@ func (e errNoExist) Error() string {
 	return fmt.Sprintf("Domain %s not found in gcloud account", e.domain)
 }
 
-func (g *gcloud) getZone(domain string) (*dns.ManagedZone, error) {
-	if g.zones == nil {
-		g.zones = map[string]*dns.ManagedZone{}
-		pageToken := ""
-		for {
-			resp, err := g.client.ManagedZones.List(g.project).PageToken(pageToken).Do()
-			if err != nil {
-				return nil, err
-			}
-			for _, z := range resp.ManagedZones {
-				g.zones[z.DnsName] = z
-			}
-			if pageToken = resp.NextPageToken; pageToken == "" {
-				break
-			}
+// loadZones fetches the list of zones, unless already done. g.mu must be held.
+func (g *gcloud) loadZones() error {
+	if g.zones != nil {
+		return nil
+	}
+	zones := map[string]*dns.ManagedZone{}
+	pageToken := ""
+	for {
+		resp, err := g.client.ManagedZones.List(g.project).PageToken(pageToken).Do()
+		if err != nil {
+			return err
+		}
+		for _, z := range resp.ManagedZones {
+			zones[z.DnsName] = z
+		}
+		if pageToken = resp.NextPageToken; pageToken == "" {
+			break
 		}
 	}
+	g.zones = zones
+	return nil
+}
+
+func (g *gcloud) getZone(domain string) (*dns.ManagedZone, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if err := g.loadZones(); err != nil {
+		return nil, err
+	}
 	if g.zones[domain+"."] == nil {
 		return nil, errNoExist{domain}
 	}
 	return g.zones[domain+"."], nil
 }
 
+// ListZones returns all managed zones in the project.
+func (g *gcloud) ListZones() ([]string, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if err := g.loadZones(); err != nil {
+		return nil, err
+	}
+	zones := make([]string, 0, len(g.zones))
+	for d := range g.zones {
+		zones = append(zones, strings.TrimSuffix(d, "."))
+	}
+	sort.Strings(zones)
+	return zones, nil
+}
+
 func (g *gcloud) GetNameservers(domain string) ([]*models.Nameserver, error) {
 	zone, err := g.getZone(domain)
 	if err != nil {
@@ -102,27 +132,15 @@ type key struct {
 func keyFor(r *dns.ResourceRecordSet) key {
 	return key{Type: r.Type, Name: r.Name}
 }
-func keyForRec(r *models.RecordConfig) key {
-	return key{Type: r.Type, Name: r.NameFQDN + "."}
-}
 
-func (g *gcloud) GetDomainCorrections(dc *models.DomainConfig) ([]*models.Correction, error) {
-	if err := dc.Punycode(); err != nil {
-		return nil, err
-	}
-	rrs, zoneName, err := g.getRecords(dc.Name)
-	if err != nil {
-		return nil, err
-	}
-	// convert to dnscontrol RecordConfig format
-	existingRecords := []*models.RecordConfig{}
-	oldRRs := map[key]*dns.ResourceRecordSet{}
+// toRecords converts record sets to dnscontrol RecordConfig format.
+func toRecords(rrs []*dns.ResourceRecordSet) models.Records {
+	existingRecords := models.Records{}
 	for _, set := range rrs {
 		nameWithoutDot := set.Name
 		if strings.HasSuffix(nameWithoutDot, ".") {
 			nameWithoutDot = nameWithoutDot[:len(nameWithoutDot)-1]
 		}
-		oldRRs[keyFor(set)] = set
 		for _, rec := range set.Rrdatas {
 			r := &models.RecordConfig{
 				NameFQDN:       nameWithoutDot,
@@ -135,54 +153,71 @@ func (g *gcloud) GetDomainCorrections(dc *models.DomainConfig) ([]*models.Correc
 		}
 	}
 
-	for _, want := range dc.Records {
-		want.MergeToTarget()
-	}
-
 	// Normalize
 	models.PostProcessRecords(existingRecords)
+	return existingRecords
+}
 
-	// first collect keys that have changed
-	differ := diff.New(dc)
-	_, create, delete, modify := differ.IncrementalDiff(existingRecords)
-	changedKeys := map[key]bool{}
-	desc := ""
-	for _, c := range create {
-		desc += fmt.Sprintln(c)
-		changedKeys[keyForRec(c.Desired)] = true
+// GetZoneRecords gets the records of a zone.
+func (g *gcloud) GetZoneRecords(domain string) (models.Records, error) {
+	rrs, _, err := g.getRecords(domain)
+	if err != nil {
+		return nil, err
+	}
+	return toRecords(rrs), nil
+}
+
+func (g *gcloud) GetDomainCorrections(dc *models.DomainConfig) ([]*models.Correction, error) {
+	if err := dc.Punycode(); err != nil {
+		return nil, err
+	}
+	rrs, zoneName, err := g.getRecords(dc.Name)
+	if err != nil {
+		return nil, err
 	}
-	for _, d := range delete {
-		desc += fmt.Sprintln(d)
-		changedKeys[keyForRec(d.Existing)] = true
+	// convert to dnscontrol RecordConfig format
+	existingRecords := toRecords(rrs)
+	oldRRs := map[key]*dns.ResourceRecordSet{}
+	for _, set := range rrs {
+		oldRRs[keyFor(set)] = set
 	}
-	for _, m := range modify {
-		desc += fmt.Sprintln(m)
-		changedKeys[keyForRec(m.Existing)] = true
+
+	for _, want := range dc.Records {
+		want.MergeToTarget()
 	}
-	if len(changedKeys) == 0 {
+
+	// every set that changed is deleted (if present) and added again as desired
+	differ := diff.NewForProvider("GCLOUD", dc)
+	sets, err := differ.ChangedRecordSets(existingRecords)
+	if err != nil {
+		return nil, err
+	}
+	if len(sets) == 0 {
 		return nil, nil
 	}
+	desc := ""
+	changes := []models.Change{}
 	chg := &dns.Change{Kind: "dns#change"}
-	for ck := range changedKeys {
-		// remove old version (if present)
+	for _, set := range sets {
+		desc += set.String() + "\n"
+		changes = append(changes, set.Changes.Changes()...)
+		ck := key{Type: set.Key.Type, Name: set.NameFQDN + "."}
 		if old, ok := oldRRs[ck]; ok {
 			chg.Deletions = append(chg.Deletions, old)
 		}
-		// collect records to replace with
+		if len(set.Desired) == 0 {
+			continue
+		}
 		newRRs := &dns.ResourceRecordSet{
 			Name: ck.Name,
 			Type: ck.Type,
 			Kind: "dns#resourceRecordSet",
 		}
-		for _, r := range dc.Records {
-			if keyForRec(r) == ck {
-				newRRs.Rrdatas = append(newRRs.Rrdatas, r.Target)
-				newRRs.Ttl = int64(r.TTL)
-			}
-		}
-		if len(newRRs.Rrdatas) > 0 {
-			chg.Additions = append(chg.Additions, newRRs)
+		for _, r := range set.Desired {
+			newRRs.Rrdatas = append(newRRs.Rrdatas, r.Target)
+			newRRs.Ttl = int64(r.TTL)
 		}
+		chg.Additions = append(chg.Additions, newRRs)
 	}
 
 	runChange := func() error {
@@ -190,8 +225,9 @@ func (g *gcloud) GetDomainCorrections(dc *models.DomainConfig) ([]*models.Correc
 		return err
 	}
 	return []*models.Correction{{
-		Msg: desc,
-		F:   runChange,
+		Msg:     desc,
+		Changes: changes,
+		F:       runChange,
 	}}, nil
 }
 
@@ -240,7 +276,9 @@ func (g *gcloud) EnsureDomainExists(domain string) error {
 		Name:        strings.Replace(domain, ".", "-", -1),
 		Description: "zone added by dnscontrol",
 	}
+	g.mu.Lock()
 	g.zones = nil // reset cache
+	g.mu.Unlock()
 	_, err = g.client.ManagedZones.Create(g.project, mz).Do()
 	return err
 }
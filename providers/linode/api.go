@@ -16,7 +16,13 @@ const (
 )
 
 func (c *LinodeApi) fetchDomainList() error {
-	c.domainIndex = map[string]int{}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.domainIndex != nil {
+		return nil
+	}
+	// The map is only stored once complete, so that no caller sees it half filled.
+	domainIndex := map[string]int{}
 	page := 1
 	for {
 		dr := &domainResponse{}
@@ -25,13 +31,14 @@ func (c *LinodeApi) fetchDomainList() error {
 			return fmt.Errorf("Error fetching domain list from Linode: %s", err)
 		}
 		for _, domain := range dr.Data {
-			c.domainIndex[domain.Domain] = domain.ID
+			domainIndex[domain.Domain] = domain.ID
 		}
 		if len(dr.Data) == 0 || dr.Page >= dr.Pages {
 			break
 		}
 		page++
 	}
+	c.domainIndex = domainIndex
 	return nil
 }
 
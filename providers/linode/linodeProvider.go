@@ -14,7 +14,9 @@ import (
 	"net/url"
 
 	"regexp"
+	"sort"
 	"strings"
+	"sync"
 
 	"golang.org/x/oauth2"
 )
@@ -49,6 +51,7 @@ var srvRegexp = regexp.MustCompile(`^_(?P<Service>\w+)\.\_(?P<Protocol>\w+)$`)
 type LinodeApi struct {
 	client      *http.Client
 	baseURL     *url.URL
+	mu          sync.Mutex // Protects domainIndex while it is fetched
 	domainIndex map[string]int
 }
 
@@ -95,6 +98,13 @@ var features = providers.DocumentationNotes{
 func init() {
 	// SRV support is in this provider, but Linode doesn't seem to support it properly
 	providers.RegisterDomainServiceProviderType("LINODE", NewLinode, features)
+	// Linode doesn't allow selecting an arbitrary TTL, only a set of predefined values
+	// We need to make sure we don't change it every time if it is as close as it's going to get
+	// By experimentation, Linode always rounds up. 300 -> 300, 301 -> 3600.
+	// https://github.com/linode/manager/blob/edd99dc4e1be5ab8190f243c3dbf8b830716255e/src/domains/components/SelectDNSSeconds.js#L19
+	diff.RegisterCanonicalizer("LINODE", "ttl", func(rec *models.RecordConfig) {
+		rec.TTL = fixTTL(rec.TTL)
+	})
 }
 
 // GetNameservers returns the nameservers for a domain.
@@ -102,23 +112,35 @@ func (api *LinodeApi) GetNameservers(domain string) ([]*models.Nameserver, error
 	return models.StringsToNameservers(defaultNameServerNames), nil
 }
 
-// GetDomainCorrections returns the corrections for a domain.
-func (api *LinodeApi) GetDomainCorrections(dc *models.DomainConfig) ([]*models.Correction, error) {
-	dc, err := dc.Copy()
-	if err != nil {
-		return nil, err
+func (api *LinodeApi) getDomainID(name string) (int, error) {
+	if err := api.fetchDomainList(); err != nil {
+		return 0, err
 	}
+	domainID, ok := api.domainIndex[name]
+	if !ok {
+		return 0, fmt.Errorf("%s not listed in domains for Linode account", name)
+	}
+	return domainID, nil
+}
 
-	dc.Punycode()
-
-	if api.domainIndex == nil {
-		if err := api.fetchDomainList(); err != nil {
-			return nil, err
-		}
+// ListZones returns all domains in the account.
+func (api *LinodeApi) ListZones() ([]string, error) {
+	if err := api.fetchDomainList(); err != nil {
+		return nil, err
 	}
-	domainID, ok := api.domainIndex[dc.Name]
-	if !ok {
-		return nil, fmt.Errorf("%s not listed in domains for Linode account", dc.Name)
+	zones := make([]string, 0, len(api.domainIndex))
+	for d := range api.domainIndex {
+		zones = append(zones, d)
+	}
+	sort.Strings(zones)
+	return zones, nil
+}
+
+// GetZoneRecords gets the records of a zone.
+func (api *LinodeApi) GetZoneRecords(domain string) (models.Records, error) {
+	domainID, err := api.getDomainID(domain)
+	if err != nil {
+		return nil, err
 	}
 
 	records, err := api.getRecords(domainID)
@@ -126,16 +148,16 @@ func (api *LinodeApi) GetDomainCorrections(dc *models.DomainConfig) ([]*models.C
 		return nil, err
 	}
 
-	existingRecords := make([]*models.RecordConfig, len(records), len(records)+len(defaultNameServerNames))
+	existingRecords := make(models.Records, len(records), len(records)+len(defaultNameServerNames))
 	for i := range records {
-		existingRecords[i] = toRc(dc, &records[i])
+		existingRecords[i] = toRc(domain, &records[i])
 	}
 
 	// Linode always has read-only NS servers, but these are not mentioned in the API response
 	// https://github.com/linode/manager/blob/edd99dc4e1be5ab8190f243c3dbf8b830716255e/src/constants.js#L184
 	for _, name := range defaultNameServerNames {
 		existingRecords = append(existingRecords, &models.RecordConfig{
-			NameFQDN: dc.Name,
+			NameFQDN: domain,
 			Type:     "NS",
 			Target:   name,
 			Original: &domainRecord{},
@@ -144,60 +166,67 @@ func (api *LinodeApi) GetDomainCorrections(dc *models.DomainConfig) ([]*models.C
 
 	// Normalize
 	models.PostProcessRecords(existingRecords)
+	return existingRecords, nil
+}
 
-	// Linode doesn't allow selecting an arbitrary TTL, only a set of predefined values
-	// We need to make sure we don't change it every time if it is as close as it's going to get
-	// By experimentation, Linode always rounds up. 300 -> 300, 301 -> 3600.
-	// https://github.com/linode/manager/blob/edd99dc4e1be5ab8190f243c3dbf8b830716255e/src/domains/components/SelectDNSSeconds.js#L19
-	for _, record := range dc.Records {
-		record.TTL = fixTTL(record.TTL)
+// GetDomainCorrections returns the corrections for a domain.
+func (api *LinodeApi) GetDomainCorrections(dc *models.DomainConfig) ([]*models.Correction, error) {
+	dc, err := dc.Copy()
+	if err != nil {
+		return nil, err
 	}
 
-	differ := diff.New(dc)
-	_, create, del, modify := differ.IncrementalDiff(existingRecords)
+	dc.Punycode()
 
-	var corrections []*models.Correction
+	domainID, err := api.getDomainID(dc.Name)
+	if err != nil {
+		return nil, err
+	}
 
-	// Deletes first so changing type works etc.
-	for _, m := range del {
-		id := m.Existing.Original.(*domainRecord).ID
-		if id == 0 { // Skip ID 0, these are the default nameservers always present
-			continue
-		}
-		corr := &models.Correction{
-			Msg: fmt.Sprintf("%s, Linode ID: %d", m.String(), id),
-			F: func() error {
-				return api.deleteRecord(domainID, id)
-			},
-		}
-		corrections = append(corrections, corr)
+	existingRecords, err := api.GetZoneRecords(dc.Name)
+	if err != nil {
+		return nil, err
 	}
-	for _, m := range create {
-		req, err := toReq(dc, m.Desired)
-		if err != nil {
-			return nil, err
-		}
-		j, err := json.Marshal(req)
-		if err != nil {
-			return nil, err
-		}
-		corr := &models.Correction{
-			Msg: fmt.Sprintf("%s: %s", m.String(), string(j)),
-			F: func() error {
-				record, err := api.createRecord(domainID, req)
-				if err != nil {
-					return err
-				}
-				// TTL isn't saved when creating a record, so we will need to modify it immediately afterwards
-				return api.modifyRecord(domainID, record.ID, req)
-			},
-		}
-		corrections = append(corrections, corr)
+
+	differ := diff.NewForProvider("LINODE", dc)
+	_, create, del, modify, err := differ.IncrementalDiff(existingRecords)
+	if err != nil {
+		return nil, err
 	}
-	for _, m := range modify {
+
+	return diff.Corrections(create, del, modify, func(kind models.CorrectionKind, m diff.Correlation) (*models.Correction, error) {
+		if kind == models.CorrectionCreate {
+			req, err := toReq(dc, m.Desired)
+			if err != nil {
+				return nil, err
+			}
+			j, err := json.Marshal(req)
+			if err != nil {
+				return nil, err
+			}
+			return &models.Correction{
+				Msg: fmt.Sprintf("%s: %s", m.String(), string(j)),
+				F: func() error {
+					record, err := api.createRecord(domainID, req)
+					if err != nil {
+						return err
+					}
+					// TTL isn't saved when creating a record, so we will need to modify it immediately afterwards
+					return api.modifyRecord(domainID, record.ID, req)
+				},
+			}, nil
+		}
 		id := m.Existing.Original.(*domainRecord).ID
 		if id == 0 { // Skip ID 0, these are the default nameservers always present
-			continue
+			return nil, nil
+		}
+		if kind == models.CorrectionDelete {
+			return &models.Correction{
+				Msg: fmt.Sprintf("%s, Linode ID: %d", m.String(), id),
+				F: func() error {
+					return api.deleteRecord(domainID, id)
+				},
+			}, nil
 		}
 		req, err := toReq(dc, m.Desired)
 		if err != nil {
@@ -207,26 +236,23 @@ func (api *LinodeApi) GetDomainCorrections(dc *models.DomainConfig) ([]*models.C
 		if err != nil {
 			return nil, err
 		}
-		corr := &models.Correction{
+		return &models.Correction{
 			Msg: fmt.Sprintf("%s, Linode ID: %d: %s", m.String(), id, string(j)),
 			F: func() error {
 				return api.modifyRecord(domainID, id, req)
 			},
-		}
-		corrections = append(corrections, corr)
-	}
-
-	return corrections, nil
+		}, nil
+	})
 }
 
-func toRc(dc *models.DomainConfig, r *domainRecord) *models.RecordConfig {
+func toRc(origin string, r *domainRecord) *models.RecordConfig {
 	// This handles "@" etc.
-	name := dnsutil.AddOrigin(r.Name, dc.Name)
+	name := dnsutil.AddOrigin(r.Name, origin)
 
 	target := r.Target
 	// Make target FQDN (#rtype_variations)
 	if r.Type == "CNAME" || r.Type == "MX" || r.Type == "NS" || r.Type == "SRV" {
-		target = dnsutil.AddOrigin(target+".", dc.Name)
+		target = dnsutil.AddOrigin(target+".", origin)
 	}
 
 	return &models.RecordConfig{
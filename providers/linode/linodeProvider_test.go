@@ -1,6 +1,12 @@
 package linode
 
 import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"sync/atomic"
 	"testing"
 )
 
@@ -21,3 +27,33 @@ func TestFixTTL(t *testing.T) {
 		}
 	}
 }
+
+func TestGetDomainIDConcurrently(t *testing.T) {
+	var lists int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") == "1" {
+			atomic.AddInt32(&lists, 1)
+			fmt.Fprint(w, `{"page": 1, "pages": 2, "data": [{"id": 1, "domain": "example.com"}]}`)
+			return
+		}
+		fmt.Fprint(w, `{"page": 2, "pages": 2, "data": [{"id": 2, "domain": "example.org"}]}`)
+	}))
+	defer srv.Close()
+	baseURL, _ := url.Parse(srv.URL + "/")
+	api := &LinodeApi{client: srv.Client(), baseURL: baseURL}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if id, err := api.getDomainID("example.org"); err != nil || id != 2 {
+				t.Errorf("expected id 2, got %d, %v", id, err)
+			}
+		}()
+	}
+	wg.Wait()
+	if lists != 1 {
+		t.Errorf("expected the domains to be listed once, got %d", lists)
+	}
+}
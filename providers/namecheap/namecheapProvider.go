@@ -1,6 +1,7 @@
 package namecheap
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"log"
@@ -11,6 +12,7 @@ import (
 	"golang.org/x/net/publicsuffix"
 
 	"github.com/StackExchange/dnscontrol/models"
+	"github.com/StackExchange/dnscontrol/pkg/retry"
 	"github.com/StackExchange/dnscontrol/providers"
 	"github.com/StackExchange/dnscontrol/providers/diff"
 	nc "github.com/billputer/go-namecheap"
@@ -84,32 +86,16 @@ func splitDomain(domain string) (sld string, tld string) {
 // this helper performs some api action, checks for rate limited response, and if so, enters a retry loop until it resolves
 // if you are consistently hitting this, you may have success asking their support to increase your account's limits.
 func doWithRetry(f func() error) {
-	// sleep 5 seconds at a time, up to 23 times (1 minute, 15 seconds)
-	const maxRetries = 23
-	const sleepTime = 5 * time.Second
-	var currentRetry int
-	for {
-		err := f()
-		if err == nil {
-			return
-		}
-		if strings.Contains(err.Error(), "Error 500000: Too many requests") {
-			currentRetry++
-			if currentRetry >= maxRetries {
-				return
-			}
-			log.Printf("Namecheap rate limit exceeded. Waiting %s to retry.", sleepTime)
-			time.Sleep(sleepTime)
-		} else {
-			return
-		}
-	}
+	// wait about 5 seconds at a time, up to 23 times (1 minute, 15 seconds)
+	ctx := retry.WithNotify(context.Background(), func(err error, attempt, maxAttempts int, wait time.Duration) {
+		log.Printf("Namecheap rate limit exceeded. Waiting %s to retry.", wait)
+	})
+	retry.Policy{MaxAttempts: 23, Delay: 5 * time.Second, MaxDelay: 5 * time.Second}.Do(ctx, retry.RateLimited, f)
 }
 
-// GetDomainCorrections returns the corrections for the domain.
-func (n *Namecheap) GetDomainCorrections(dc *models.DomainConfig) ([]*models.Correction, error) {
-	dc.Punycode()
-	sld, tld := splitDomain(dc.Name)
+// GetZoneRecords gets the records of a zone.
+func (n *Namecheap) GetZoneRecords(domain string) (models.Records, error) {
+	sld, tld := splitDomain(domain)
 	var records *nc.DomainDNSGetHostsResult
 	var err error
 	doWithRetry(func() error {
@@ -120,13 +106,40 @@ func (n *Namecheap) GetDomainCorrections(dc *models.DomainConfig) ([]*models.Cor
 		return nil, err
 	}
 
-	var actual []*models.RecordConfig
+	actual := models.Records{}
+	for _, r := range records.Hosts {
+		if r.Type == "SOA" {
+			continue
+		}
+		rec := &models.RecordConfig{
+			NameFQDN:     dnsutil.AddOrigin(r.Name, domain),
+			Type:         r.Type,
+			Target:       r.Address,
+			TTL:          uint32(r.TTL),
+			MxPreference: uint16(r.MXPref),
+			Original:     r,
+		}
+		actual = append(actual, rec)
+	}
+
+	// Normalize
+	models.PostProcessRecords(actual)
+	return actual, nil
+}
+
+// GetDomainCorrections returns the corrections for the domain.
+func (n *Namecheap) GetDomainCorrections(dc *models.DomainConfig) ([]*models.Correction, error) {
+	dc.Punycode()
+	actual, err := n.GetZoneRecords(dc.Name)
+	if err != nil {
+		return nil, err
+	}
 
 	// namecheap does not allow setting @ NS with basic DNS
 	dc.Filter(func(r *models.RecordConfig) bool {
 		if r.Type == "NS" && r.Name == "@" {
 			if !strings.HasSuffix(r.Target, "registrar-servers.com.") {
-				fmt.Println("\n", r.Target, "Namecheap does not support changing apex NS records. Skipping.")
+				log.Println(r.Target, "Namecheap does not support changing apex NS records. Skipping.")
 			}
 			return false
 		}
@@ -136,34 +149,21 @@ func (n *Namecheap) GetDomainCorrections(dc *models.DomainConfig) ([]*models.Cor
 	// namecheap has this really annoying feature where they add some parking records if you have no records.
 	// This causes a few problems for our purposes, specifically the integration tests.
 	// lets detect that one case and pretend it is a no-op.
-	if len(dc.Records) == 0 && len(records.Hosts) == 2 {
-		if records.Hosts[0].Type == "CNAME" &&
-			strings.Contains(records.Hosts[0].Address, "parkingpage") &&
-			records.Hosts[1].Type == "URL" {
+	if len(dc.Records) == 0 && len(actual) == 2 {
+		if actual[0].Type == "CNAME" &&
+			strings.Contains(actual[0].Target, "parkingpage") &&
+			actual[1].Type == "URL" {
 			return nil, nil
 		}
 	}
 
-	for _, r := range records.Hosts {
-		if r.Type == "SOA" {
-			continue
-		}
-		rec := &models.RecordConfig{
-			NameFQDN:     dnsutil.AddOrigin(r.Name, dc.Name),
-			Type:         r.Type,
-			Target:       r.Address,
-			TTL:          uint32(r.TTL),
-			MxPreference: uint16(r.MXPref),
-			Original:     r,
-		}
-		actual = append(actual, rec)
+	differ := diff.NewForProvider("NAMECHEAP", dc)
+	_, create, delete, modify, err := differ.IncrementalDiff(actual)
+	if err != nil {
+		return nil, err
 	}
-
-	// Normalize
-	models.PostProcessRecords(actual)
-
-	differ := diff.New(dc)
-	_, create, delete, modify := differ.IncrementalDiff(actual)
+	// the zone is replaced, so the records the diff left alone must be sent again.
+	keep := differ.Kept(actual)
 
 	// // because namecheap doesn't have selective create, delete, modify,
 	// // we bundle them all up to send at once.  We *do* want to see the
@@ -187,9 +187,10 @@ func (n *Namecheap) GetDomainCorrections(dc *models.DomainConfig) ([]*models.Cor
 	if len(desc) > 0 {
 		corrections = append(corrections,
 			&models.Correction{
-				Msg: msg,
+				Msg:     msg,
+				Changes: append(append(create.Changes(), delete.Changes()...), modify.Changes()...),
 				F: func() error {
-					return n.generateRecords(dc)
+					return n.generateRecords(dc, keep)
 				},
 			})
 	}
@@ -197,12 +198,12 @@ func (n *Namecheap) GetDomainCorrections(dc *models.DomainConfig) ([]*models.Cor
 	return corrections, nil
 }
 
-func (n *Namecheap) generateRecords(dc *models.DomainConfig) error {
+func (n *Namecheap) generateRecords(dc *models.DomainConfig, keep models.Records) error {
 
 	var recs []nc.DomainDNSHost
 
 	id := 1
-	for _, r := range dc.Records {
+	for _, r := range append(dc.Records, keep...) {
 		name := dnsutil.TrimDomainName(r.NameFQDN, dc.Name)
 		rec := nc.DomainDNSHost{
 			ID:      id,
@@ -256,7 +257,8 @@ func (n *Namecheap) GetRegistrarCorrections(dc *models.DomainConfig) ([]*models.
 		sld, tld := parts[0], parts[1]
 		return []*models.Correction{
 			{
-				Msg: fmt.Sprintf("Change Nameservers from '%s' to '%s'", found, desired),
+				Msg:  fmt.Sprintf("Change Nameservers from '%s' to '%s'", found, desired),
+				Kind: models.CorrectionNameservers,
 				F: func() (err error) {
 					doWithRetry(func() error {
 						_, err = n.client.DomainDNSSetCustom(sld, tld, desired)
@@ -61,8 +61,9 @@ func (n *NameCom) GetRegistrarCorrections(dc *models.DomainConfig) ([]*models.Co
 	if foundNameservers != expectedNameservers {
 		return []*models.Correction{
 			{
-				Msg: fmt.Sprintf("Update nameservers %s -> %s", foundNameservers, expectedNameservers),
-				F:   n.updateNameservers(expected, dc.Name),
+				Msg:  fmt.Sprintf("Update nameservers %s -> %s", foundNameservers, expectedNameservers),
+				Kind: models.CorrectionNameservers,
+				F:    n.updateNameservers(expected, dc.Name),
 			},
 		}, nil
 	}
@@ -21,17 +21,50 @@ var defaultNameservers = []*models.Nameserver{
 	{Name: "ns4.name.com"},
 }
 
-func (n *NameCom) GetDomainCorrections(dc *models.DomainConfig) ([]*models.Correction, error) {
-	dc.Punycode()
-	records, err := n.getRecords(dc.Name)
+// ListZones returns all domains in the account.
+func (n *NameCom) ListZones() ([]string, error) {
+	zones := []string{}
+	request := &namecom.ListDomainsRequest{
+		Page: 1,
+	}
+
+	for request.Page > 0 {
+		response, err := n.client.ListDomains(request)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, d := range response.Domains {
+			zones = append(zones, d.DomainName)
+		}
+		request.Page = response.NextPage
+	}
+	return zones, nil
+}
+
+// GetZoneRecords gets the records of a zone.
+func (n *NameCom) GetZoneRecords(domain string) (models.Records, error) {
+	records, err := n.getRecords(domain)
 	if err != nil {
 		return nil, err
 	}
-	actual := make([]*models.RecordConfig, len(records))
+	actual := make(models.Records, len(records))
 	for i, r := range records {
 		actual[i] = toRecord(r)
 	}
 
+	// Normalize
+	models.PostProcessRecords(actual)
+	return actual, nil
+}
+
+func (n *NameCom) GetDomainCorrections(dc *models.DomainConfig) ([]*models.Correction, error) {
+	dc.Punycode()
+	actual, err := n.GetZoneRecords(dc.Name)
+	if err != nil {
+		return nil, err
+	}
+
 	for _, rec := range dc.Records {
 		if rec.Type == "ALIAS" {
 			rec.Type = "ANAME"
@@ -40,36 +73,31 @@ func (n *NameCom) GetDomainCorrections(dc *models.DomainConfig) ([]*models.Corre
 
 	checkNSModifications(dc)
 
-	// Normalize
-	models.PostProcessRecords(actual)
-
-	differ := diff.New(dc)
-	_, create, del, mod := differ.IncrementalDiff(actual)
-	corrections := []*models.Correction{}
-
-	for _, d := range del {
-		rec := d.Existing.Original.(*namecom.Record)
-		c := &models.Correction{Msg: d.String(), F: func() error { return n.deleteRecord(rec.ID, dc.Name) }}
-		corrections = append(corrections, c)
-	}
-	for _, cre := range create {
-		rec := cre.Desired
-		c := &models.Correction{Msg: cre.String(), F: func() error { return n.createRecord(rec, dc.Name) }}
-		corrections = append(corrections, c)
-	}
-	for _, chng := range mod {
-		old := chng.Existing.Original.(*namecom.Record)
-		new := chng.Desired
-		c := &models.Correction{Msg: chng.String(), F: func() error {
-			err := n.deleteRecord(old.ID, dc.Name)
-			if err != nil {
-				return err
-			}
-			return n.createRecord(new, dc.Name)
-		}}
-		corrections = append(corrections, c)
+	differ := diff.NewForProvider("NAMEDOTCOM", dc)
+	_, create, del, mod, err := differ.IncrementalDiff(actual)
+	if err != nil {
+		return nil, err
 	}
-	return corrections, nil
+	return diff.Corrections(create, del, mod, func(kind models.CorrectionKind, c diff.Correlation) (*models.Correction, error) {
+		switch kind {
+		case models.CorrectionDelete:
+			rec := c.Existing.Original.(*namecom.Record)
+			return &models.Correction{Msg: c.String(), F: func() error { return n.deleteRecord(rec.ID, dc.Name) }}, nil
+		case models.CorrectionCreate:
+			rec := c.Desired
+			return &models.Correction{Msg: c.String(), F: func() error { return n.createRecord(rec, dc.Name) }}, nil
+		default:
+			old := c.Existing.Original.(*namecom.Record)
+			new := c.Desired
+			return &models.Correction{Msg: c.String(), F: func() error {
+				err := n.deleteRecord(old.ID, dc.Name)
+				if err != nil {
+					return err
+				}
+				return n.createRecord(new, dc.Name)
+			}}, nil
+		}
+	})
 }
 
 func checkNSModifications(dc *models.DomainConfig) {
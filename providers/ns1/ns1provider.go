@@ -47,56 +47,66 @@ func (n *nsone) GetNameservers(domain string) ([]*models.Nameserver, error) {
 	return models.StringsToNameservers(z.DNSServers), nil
 }
 
-func (n *nsone) GetDomainCorrections(dc *models.DomainConfig) ([]*models.Correction, error) {
-	dc.Punycode()
-	dc.CombineMXs()
-	z, _, err := n.Zones.Get(dc.Name)
+// ListZones returns all zones in the account.
+func (n *nsone) ListZones() ([]string, error) {
+	zs, _, err := n.Zones.List()
+	if err != nil {
+		return nil, err
+	}
+	zones := make([]string, 0, len(zs))
+	for _, z := range zs {
+		zones = append(zones, z.Zone)
+	}
+	return zones, nil
+}
+
+// GetZoneRecords gets the records of a zone.
+func (n *nsone) GetZoneRecords(domain string) (models.Records, error) {
+	z, _, err := n.Zones.Get(domain)
 	if err != nil {
 		return nil, err
 	}
 
 	found := models.Records{}
 	for _, r := range z.Records {
-		zrs, err := convert(r, dc.Name)
+		zrs, err := convert(r, domain)
 		if err != nil {
 			return nil, err
 		}
 		found = append(found, zrs...)
 	}
-	foundGrouped := found.Grouped()
-	desiredGrouped := dc.Records.Grouped()
 
-	//  Normalize
+	// Normalize
 	models.PostProcessRecords(found)
+	return found, nil
+}
 
-	differ := diff.New(dc)
-	changedGroups := differ.ChangedGroups(found)
+func (n *nsone) GetDomainCorrections(dc *models.DomainConfig) ([]*models.Correction, error) {
+	dc.Punycode()
+	dc.CombineMXs()
+	found, err := n.GetZoneRecords(dc.Name)
+	if err != nil {
+		return nil, err
+	}
+	differ := diff.NewForProvider("NS1", dc)
+	sets, err := differ.ChangedRecordSets(found)
+	if err != nil {
+		return nil, err
+	}
 	corrections := []*models.Correction{}
 	// each name/type is given to the api as a unit.
-	for k, descs := range changedGroups {
-		key := k
-		desc := strings.Join(descs, "\n")
-		_, current := foundGrouped[k]
-		recs, wanted := desiredGrouped[k]
-		if wanted && !current {
-			// pure addition
-			corrections = append(corrections, &models.Correction{
-				Msg: desc,
-				F:   func() error { return n.add(recs, dc.Name) },
-			})
-		} else if current && !wanted {
-			// pure deletion
-			corrections = append(corrections, &models.Correction{
-				Msg: desc,
-				F:   func() error { return n.remove(key, dc.Name) },
-			})
-		} else {
-			// modification
-			corrections = append(corrections, &models.Correction{
-				Msg: desc,
-				F:   func() error { return n.modify(recs, dc.Name) },
-			})
+	for _, set := range sets {
+		key, recs := set.Key, set.Desired
+		corr := &models.Correction{Msg: set.String(), Kind: set.Kind(), Changes: set.Changes.Changes()}
+		switch corr.Kind {
+		case models.CorrectionCreate:
+			corr.F = func() error { return n.add(recs, dc.Name) }
+		case models.CorrectionDelete:
+			corr.F = func() error { return n.remove(key, dc.Name) }
+		default:
+			corr.F = func() error { return n.modify(recs, dc.Name) }
 		}
+		corrections = append(corrections, corr)
 	}
 	return corrections, nil
 }
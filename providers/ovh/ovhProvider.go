@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+	"sync"
 
 	"github.com/StackExchange/dnscontrol/models"
 	"github.com/StackExchange/dnscontrol/providers"
@@ -16,6 +17,7 @@ import (
 type ovhProvider struct {
 	client *ovh.Client
 	zones  map[string]bool
+	mu     sync.Mutex // Protects zones while it is fetched
 }
 
 var features = providers.DocumentationNotes{
@@ -53,6 +55,9 @@ func newReg(conf map[string]string) (providers.Registrar, error) {
 func init() {
 	providers.RegisterRegistrarType("OVH", newReg)
 	providers.RegisterDomainServiceProviderType("OVH", newDsp, features)
+	// OVH returns TXT records, and the SPF and DKIM records read as TXT, as a single value
+	// that may or may not be quoted.
+	diff.RegisterCanonicalizer("OVH", "txt", diff.CanonicalizeTXT)
 }
 
 func (c *ovhProvider) GetNameservers(domain string) ([]*models.Nameserver, error) {
@@ -80,20 +85,31 @@ func (e errNoExist) Error() string {
 	return fmt.Sprintf("Domain %s not found in your ovh account", e.domain)
 }
 
-func (c *ovhProvider) GetDomainCorrections(dc *models.DomainConfig) ([]*models.Correction, error) {
-	dc.Punycode()
-	dc.CombineMXs()
+// ListZones returns all zones in the account.
+func (c *ovhProvider) ListZones() ([]string, error) {
+	if err := c.fetchZones(); err != nil {
+		return nil, err
+	}
+	zones := make([]string, 0, len(c.zones))
+	for d := range c.zones {
+		zones = append(zones, d)
+	}
+	sort.Strings(zones)
+	return zones, nil
+}
 
-	if !c.zones[dc.Name] {
-		return nil, errNoExist{dc.Name}
+// GetZoneRecords gets the records of a zone.
+func (c *ovhProvider) GetZoneRecords(domain string) (models.Records, error) {
+	if !c.zones[domain] {
+		return nil, errNoExist{domain}
 	}
 
-	records, err := c.fetchRecords(dc.Name)
+	records, err := c.fetchRecords(domain)
 	if err != nil {
 		return nil, err
 	}
 
-	var actual []*models.RecordConfig
+	actual := models.Records{}
 	for _, r := range records {
 		if r.FieldType == "SOA" {
 			continue
@@ -114,7 +130,7 @@ func (c *ovhProvider) GetDomainCorrections(dc *models.DomainConfig) ([]*models.C
 		}
 
 		rec := &models.RecordConfig{
-			NameFQDN:       dnsutil.AddOrigin(r.SubDomain, dc.Name),
+			NameFQDN:       dnsutil.AddOrigin(r.SubDomain, domain),
 			Name:           r.SubDomain,
 			Type:           r.FieldType,
 			Target:         r.Target,
@@ -127,25 +143,41 @@ func (c *ovhProvider) GetDomainCorrections(dc *models.DomainConfig) ([]*models.C
 
 	// Normalize
 	models.PostProcessRecords(actual)
+	return actual, nil
+}
+
+func (c *ovhProvider) GetDomainCorrections(dc *models.DomainConfig) ([]*models.Correction, error) {
+	dc.Punycode()
+	dc.CombineMXs()
+
+	actual, err := c.GetZoneRecords(dc.Name)
+	if err != nil {
+		return nil, err
+	}
 
-	differ := diff.New(dc)
-	_, create, delete, modify := differ.IncrementalDiff(actual)
+	differ := diff.NewForProvider("OVH", dc)
+	_, create, delete, modify, err := differ.IncrementalDiff(actual)
+	if err != nil {
+		return nil, err
+	}
 
 	corrections := []*models.Correction{}
 
 	for _, del := range delete {
 		rec := del.Existing.Original.(*Record)
 		corrections = append(corrections, &models.Correction{
-			Msg: del.String(),
-			F:   c.deleteRecordFunc(rec.ID, dc.Name),
+			Msg:     del.String(),
+			Changes: []models.Change{del.Change()},
+			F:       c.deleteRecordFunc(rec.ID, dc.Name),
 		})
 	}
 
 	for _, cre := range create {
 		rec := cre.Desired
 		corrections = append(corrections, &models.Correction{
-			Msg: cre.String(),
-			F:   c.createRecordFunc(rec, dc.Name),
+			Msg:     cre.String(),
+			Changes: []models.Change{cre.Change()},
+			F:       c.createRecordFunc(rec, dc.Name),
 		})
 	}
 
@@ -153,8 +185,9 @@ func (c *ovhProvider) GetDomainCorrections(dc *models.DomainConfig) ([]*models.C
 		oldR := mod.Existing.Original.(*Record)
 		newR := mod.Desired
 		corrections = append(corrections, &models.Correction{
-			Msg: mod.String(),
-			F:   c.updateRecordFunc(oldR, newR, dc.Name),
+			Msg:     mod.String(),
+			Changes: []models.Change{mod.Change()},
+			F:       c.updateRecordFunc(oldR, newR, dc.Name),
 		})
 	}
 
@@ -190,7 +223,8 @@ func (c *ovhProvider) GetRegistrarCorrections(dc *models.DomainConfig) ([]*model
 	if found != desired {
 		return []*models.Correction{
 			{
-				Msg: fmt.Sprintf("Change Nameservers from '%s' to '%s'", found, desired),
+				Msg:  fmt.Sprintf("Change Nameservers from '%s' to '%s'", found, desired),
+				Kind: models.CorrectionNameservers,
 				F: func() error {
 					err := c.updateNS(dc.Name, desiredNs)
 					if err != nil {
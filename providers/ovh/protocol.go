@@ -13,6 +13,8 @@ type Void struct {
 
 // fetchDomainList gets list of zones for account
 func (c *ovhProvider) fetchZones() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
 	if c.zones != nil {
 		return nil
 	}
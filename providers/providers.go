@@ -24,6 +24,19 @@ type DomainCreator interface {
 	EnsureDomainExists(domain string) error
 }
 
+// ZoneRecordsGetter should be implemented by providers that can download the existing records of a zone.
+// The get-zones command uses it to export a live zone. The records are returned the way the provider
+// compares them to the desired records: normalized, but possibly with a combined target.
+type ZoneRecordsGetter interface {
+	GetZoneRecords(domain string) (models.Records, error)
+}
+
+// ZoneLister should be implemented by providers that can list all zones in an account.
+// The check-orphans command uses it to find zones that are not in dnsconfig.js.
+type ZoneLister interface {
+	ListZones() ([]string, error)
+}
+
 // RegistrarInitializer is a function to create a registrar. Function will be passed the unprocessed json payload from the configuration file for the given provider.
 type RegistrarInitializer func(map[string]string) (Registrar, error)
 
@@ -3,6 +3,7 @@ package route53
 import (
 	"encoding/json"
 	"fmt"
+	"log"
 	"sort"
 	"strings"
 	"time"
@@ -82,7 +83,7 @@ func (r *route53Provider) getZones() error {
 	r.zones = make(map[string]*r53.HostedZone)
 	for {
 		if nextMarker != nil {
-			fmt.Println(*nextMarker)
+			log.Println(*nextMarker)
 		}
 		inp := &r53.ListHostedZonesInput{Marker: nextMarker}
 		out, err := r.client.ListHostedZones(inp)
@@ -109,10 +110,6 @@ type key struct {
 	Name, Type string
 }
 
-func getKey(r *models.RecordConfig) key {
-	return key{r.NameFQDN, r.Type}
-}
-
 type errNoExist struct {
 	domain string
 }
@@ -140,22 +137,33 @@ func (r *route53Provider) GetNameservers(domain string) ([]*models.Nameserver, e
 	return ns, nil
 }
 
-func (r *route53Provider) GetDomainCorrections(dc *models.DomainConfig) ([]*models.Correction, error) {
-	dc.Punycode()
+// ListZones returns all hosted zones in the account.
+func (r *route53Provider) ListZones() ([]string, error) {
+	zones := make([]string, 0, len(r.zones))
+	for d := range r.zones {
+		zones = append(zones, d)
+	}
+	sort.Strings(zones)
+	return zones, nil
+}
 
-	var corrections = []*models.Correction{}
-	zone, ok := r.zones[dc.Name]
-	// add zone if it doesn't exist
+// GetZoneRecords gets the records of a zone.
+func (r *route53Provider) GetZoneRecords(domain string) (models.Records, error) {
+	zone, ok := r.zones[domain]
 	if !ok {
-		return nil, errNoExist{dc.Name}
+		return nil, errNoExist{domain}
 	}
 
 	records, err := r.fetchRecordSets(zone.Id)
 	if err != nil {
 		return nil, err
 	}
+	return toRecords(records), nil
+}
 
-	var existingRecords = []*models.RecordConfig{}
+// toRecords converts record sets to dnscontrol RecordConfig format.
+func toRecords(records []*r53.ResourceRecordSet) models.Records {
+	var existingRecords = models.Records{}
 	for _, set := range records {
 		if set.AliasTarget == nil {
 			for _, rec := range set.ResourceRecords {
@@ -186,6 +194,28 @@ func (r *route53Provider) GetDomainCorrections(dc *models.DomainConfig) ([]*mode
 			existingRecords = append(existingRecords, r)
 		}
 	}
+
+	// Normalize
+	models.PostProcessRecords(existingRecords)
+	return existingRecords
+}
+
+func (r *route53Provider) GetDomainCorrections(dc *models.DomainConfig) ([]*models.Correction, error) {
+	dc.Punycode()
+
+	var corrections = []*models.Correction{}
+	zone, ok := r.zones[dc.Name]
+	// add zone if it doesn't exist
+	if !ok {
+		return nil, errNoExist{dc.Name}
+	}
+
+	records, err := r.fetchRecordSets(zone.Id)
+	if err != nil {
+		return nil, err
+	}
+	existingRecords := toRecords(records)
+
 	for _, want := range dc.Records {
 		want.MergeToTarget()
 		// update zone_id to current zone.id if not specified by the user
@@ -194,50 +224,31 @@ func (r *route53Provider) GetDomainCorrections(dc *models.DomainConfig) ([]*mode
 		}
 	}
 
-	// Normalize
-	models.PostProcessRecords(existingRecords)
-
 	// diff
-	differ := diff.New(dc, getAliasMap)
-	_, create, delete, modify := differ.IncrementalDiff(existingRecords)
-
-	namesToUpdate := map[key][]string{}
-	for _, c := range create {
-		namesToUpdate[getKey(c.Desired)] = append(namesToUpdate[getKey(c.Desired)], c.String())
-	}
-	for _, d := range delete {
-		namesToUpdate[getKey(d.Existing)] = append(namesToUpdate[getKey(d.Existing)], d.String())
-	}
-	for _, m := range modify {
-		namesToUpdate[getKey(m.Desired)] = append(namesToUpdate[getKey(m.Desired)], m.String())
+	differ := diff.NewForProvider("ROUTE53", dc, getAliasMap)
+	sets, err := differ.ChangedRecordSets(existingRecords)
+	if err != nil {
+		return nil, err
 	}
-
-	if len(namesToUpdate) == 0 {
+	if len(sets) == 0 {
 		return nil, nil
 	}
 
-	updates := map[key][]*models.RecordConfig{}
-	// for each name we need to update, collect relevant records from dc
-	for k := range namesToUpdate {
-		updates[k] = nil
-		for _, rc := range dc.Records {
-			if getKey(rc) == k {
-				updates[k] = append(updates[k], rc)
-			}
-		}
-	}
-
 	dels := []*r53.Change{}
 	changes := []*r53.Change{}
 	changeDesc := ""
 	delDesc := ""
-	for k, recs := range updates {
+	changeChanges := []models.Change{}
+	delChanges := []models.Change{}
+	for _, set := range sets {
+		k := key{set.NameFQDN, set.Key.Type}
 		chg := &r53.Change{}
 		var rrset *r53.ResourceRecordSet
-		if len(recs) == 0 {
+		if len(set.Desired) == 0 {
 			dels = append(dels, chg)
 			chg.Action = sPtr("DELETE")
-			delDesc += strings.Join(namesToUpdate[k], "\n") + "\n"
+			delDesc += set.String() + "\n"
+			delChanges = append(delChanges, set.Changes.Changes()...)
 			// on delete just submit the original resource set we got from r53.
 			for _, r := range records {
 				if *r.Name == k.Name+"." && (*r.Type == k.Type || k.Type == "R53_ALIAS") {
@@ -247,14 +258,15 @@ func (r *route53Provider) GetDomainCorrections(dc *models.DomainConfig) ([]*mode
 			}
 		} else {
 			changes = append(changes, chg)
-			changeDesc += strings.Join(namesToUpdate[k], "\n") + "\n"
+			changeDesc += set.String() + "\n"
+			changeChanges = append(changeChanges, set.Changes.Changes()...)
 			// on change or create, just build a new record set from our desired state
 			chg.Action = sPtr("UPSERT")
 			rrset = &r53.ResourceRecordSet{
 				Name: sPtr(k.Name),
 				Type: sPtr(k.Type),
 			}
-			for _, r := range recs {
+			for _, r := range set.Desired {
 				val := r.Target
 				if r.Type != "R53_ALIAS" {
 					rr := &r53.ResourceRecord{
@@ -279,10 +291,11 @@ func (r *route53Provider) GetDomainCorrections(dc *models.DomainConfig) ([]*mode
 		ChangeBatch: &r53.ChangeBatch{Changes: dels},
 	}
 
-	addCorrection := func(msg string, req *r53.ChangeResourceRecordSetsInput) {
+	addCorrection := func(msg string, changes []models.Change, req *r53.ChangeResourceRecordSetsInput) {
 		corrections = append(corrections,
 			&models.Correction{
-				Msg: msg,
+				Msg:     msg,
+				Changes: changes,
 				F: func() error {
 					req.HostedZoneId = zone.Id
 					_, err := r.client.ChangeResourceRecordSets(req)
@@ -292,11 +305,11 @@ func (r *route53Provider) GetDomainCorrections(dc *models.DomainConfig) ([]*mode
 	}
 
 	if len(dels) > 0 {
-		addCorrection(delDesc, delReq)
+		addCorrection(delDesc, delChanges, delReq)
 	}
 
 	if len(changes) > 0 {
-		addCorrection(changeDesc, changeReq)
+		addCorrection(changeDesc, changeChanges, changeReq)
 	}
 
 	return corrections, nil
@@ -355,7 +368,8 @@ func (r *route53Provider) GetRegistrarCorrections(dc *models.DomainConfig) ([]*m
 	if actual != expected {
 		return []*models.Correction{
 			{
-				Msg: fmt.Sprintf("Update nameservers %s -> %s", actual, expected),
+				Msg:  fmt.Sprintf("Update nameservers %s -> %s", actual, expected),
+				Kind: models.CorrectionNameservers,
 				F: func() error {
 					_, err := r.updateRegistrarNameservers(dc.Name, expectedSet)
 					return err
@@ -53,6 +53,39 @@ func (s *SoftLayer) GetNameservers(domain string) ([]*models.Nameserver, error)
 	return models.StringsToNameservers(nservers), nil
 }
 
+// ListZones returns all domains in the account.
+func (s *SoftLayer) ListZones() ([]string, error) {
+	domains, err := services.GetAccountService(s.Session).
+		Mask("name").
+		GetDomains()
+	if err != nil {
+		return nil, err
+	}
+
+	zones := make([]string, 0, len(domains))
+	for _, d := range domains {
+		zones = append(zones, *d.Name)
+	}
+	return zones, nil
+}
+
+// GetZoneRecords gets the records of a zone.
+func (s *SoftLayer) GetZoneRecords(domain string) (models.Records, error) {
+	sldomain, err := s.getDomain(&domain)
+	if err != nil {
+		return nil, err
+	}
+
+	actual, err := s.getExistingRecords(sldomain)
+	if err != nil {
+		return nil, err
+	}
+
+	// Normalize
+	models.PostProcessRecords(actual)
+	return actual, nil
+}
+
 // GetDomainCorrections returns corrections to update a domain.
 func (s *SoftLayer) GetDomainCorrections(dc *models.DomainConfig) ([]*models.Correction, error) {
 	corrections := []*models.Correction{}
@@ -69,28 +102,34 @@ func (s *SoftLayer) GetDomainCorrections(dc *models.DomainConfig) ([]*models.Cor
 		return nil, err
 	}
 
-	_, create, delete, modify := diff.New(dc).IncrementalDiff(actual)
+	_, create, delete, modify, err := diff.NewForProvider("SOFTLAYER", dc).IncrementalDiff(actual)
+	if err != nil {
+		return nil, err
+	}
 
 	for _, del := range delete {
 		existing := del.Existing.Original.(datatypes.Dns_Domain_ResourceRecord)
 		corrections = append(corrections, &models.Correction{
-			Msg: del.String(),
-			F:   s.deleteRecordFunc(*existing.Id),
+			Msg:     del.String(),
+			Changes: []models.Change{del.Change()},
+			F:       s.deleteRecordFunc(*existing.Id),
 		})
 	}
 
 	for _, cre := range create {
 		corrections = append(corrections, &models.Correction{
-			Msg: cre.String(),
-			F:   s.createRecordFunc(cre.Desired, domain),
+			Msg:     cre.String(),
+			Changes: []models.Change{cre.Change()},
+			F:       s.createRecordFunc(cre.Desired, domain),
 		})
 	}
 
 	for _, mod := range modify {
 		existing := mod.Existing.Original.(datatypes.Dns_Domain_ResourceRecord)
 		corrections = append(corrections, &models.Correction{
-			Msg: mod.String(),
-			F:   s.updateRecordFunc(&existing, mod.Desired),
+			Msg:     mod.String(),
+			Changes: []models.Change{mod.Change()},
+			F:       s.updateRecordFunc(&existing, mod.Desired),
 		})
 	}
 
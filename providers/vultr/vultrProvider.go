@@ -71,25 +71,38 @@ func NewVultr(m map[string]string, metadata json.RawMessage) (providers.DNSServi
 	return api, nil
 }
 
-// GetDomainCorrections gets the corrections for a DomainConfig
-func (api *VultrApi) GetDomainCorrections(dc *models.DomainConfig) ([]*models.Correction, error) {
-	dc.Punycode()
+// ListZones returns all domains in the account.
+func (api *VultrApi) ListZones() ([]string, error) {
+	domains, err := api.client.GetDNSDomains()
+	if err != nil {
+		return nil, err
+	}
+
+	zones := make([]string, 0, len(domains))
+	for _, d := range domains {
+		zones = append(zones, d.Domain)
+	}
+	return zones, nil
+}
 
-	ok, err := api.isDomainInAccount(dc.Name)
+// GetZoneRecords gets the records of a zone.
+func (api *VultrApi) GetZoneRecords(domain string) (models.Records, error) {
+	ok, err := api.isDomainInAccount(domain)
 	if err != nil {
 		return nil, err
 	}
 
 	if !ok {
-		return nil, fmt.Errorf("%s is not a domain in the Vultr account", dc.Name)
+		return nil, fmt.Errorf("%s is not a domain in the Vultr account", domain)
 	}
 
-	records, err := api.client.GetDNSRecords(dc.Name)
+	records, err := api.client.GetDNSRecords(domain)
 	if err != nil {
 		return nil, err
 	}
 
-	curRecords := make([]*models.RecordConfig, len(records))
+	dc := &models.DomainConfig{Name: domain}
+	curRecords := make(models.Records, len(records))
 	for i := range records {
 		r, err := toRecordConfig(dc, &records[i])
 		if err != nil {
@@ -101,45 +114,54 @@ func (api *VultrApi) GetDomainCorrections(dc *models.DomainConfig) ([]*models.Co
 
 	// Normalize
 	models.PostProcessRecords(curRecords)
+	return curRecords, nil
+}
 
-	differ := diff.New(dc)
-	_, create, delete, modify := differ.IncrementalDiff(curRecords)
-
-	corrections := []*models.Correction{}
-
-	for _, mod := range delete {
-		id := mod.Existing.Original.(*vultr.DNSRecord).RecordID
-		corrections = append(corrections, &models.Correction{
-			Msg: fmt.Sprintf("%s; Vultr RecordID: %v", mod.String(), id),
-			F: func() error {
-				return api.client.DeleteDNSRecord(dc.Name, id)
-			},
-		})
-	}
+// GetDomainCorrections gets the corrections for a DomainConfig
+func (api *VultrApi) GetDomainCorrections(dc *models.DomainConfig) ([]*models.Correction, error) {
+	dc.Punycode()
 
-	for _, mod := range create {
-		r := toVultrRecord(dc, mod.Desired)
-		corrections = append(corrections, &models.Correction{
-			Msg: mod.String(),
-			F: func() error {
-				return api.client.CreateDNSRecord(dc.Name, r.Name, r.Type, r.Data, r.Priority, r.TTL)
-			},
-		})
+	curRecords, err := api.GetZoneRecords(dc.Name)
+	if err != nil {
+		return nil, err
 	}
 
-	for _, mod := range modify {
-		id := mod.Existing.Original.(*vultr.DNSRecord).RecordID
-		r := toVultrRecord(dc, mod.Desired)
-		r.RecordID = id
-		corrections = append(corrections, &models.Correction{
-			Msg: fmt.Sprintf("%s; Vultr RecordID: %v", mod.String(), id),
-			F: func() error {
-				return api.client.UpdateDNSRecord(dc.Name, *r)
-			},
-		})
+	differ := diff.NewForProvider("VULTR", dc)
+	_, create, delete, modify, err := differ.IncrementalDiff(curRecords)
+	if err != nil {
+		return nil, err
 	}
 
-	return corrections, nil
+	return diff.Corrections(create, delete, modify, func(kind models.CorrectionKind, mod diff.Correlation) (*models.Correction, error) {
+		switch kind {
+		case models.CorrectionDelete:
+			id := mod.Existing.Original.(*vultr.DNSRecord).RecordID
+			return &models.Correction{
+				Msg: fmt.Sprintf("%s; Vultr RecordID: %v", mod.String(), id),
+				F: func() error {
+					return api.client.DeleteDNSRecord(dc.Name, id)
+				},
+			}, nil
+		case models.CorrectionCreate:
+			r := toVultrRecord(dc, mod.Desired)
+			return &models.Correction{
+				Msg: mod.String(),
+				F: func() error {
+					return api.client.CreateDNSRecord(dc.Name, r.Name, r.Type, r.Data, r.Priority, r.TTL)
+				},
+			}, nil
+		default:
+			id := mod.Existing.Original.(*vultr.DNSRecord).RecordID
+			r := toVultrRecord(dc, mod.Desired)
+			r.RecordID = id
+			return &models.Correction{
+				Msg: fmt.Sprintf("%s; Vultr RecordID: %v", mod.String(), id),
+				F: func() error {
+					return api.client.UpdateDNSRecord(dc.Name, *r)
+				},
+			}, nil
+		}
+	})
 }
 
 // GetNameservers gets the Vultr nameservers for a domain